@@ -0,0 +1,134 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Aliases information struct
+type Aliases struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	alias *prometheus.Desc
+}
+
+func init() {
+	registerCollector("aliases", defaultDisabled, "Export informational alias metrics.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewAliases(logger, client, url)
+	})
+}
+
+// NewAliases defines Aliases Prometheus metrics
+func NewAliases(logger log.Logger, client *http.Client, url *url.URL) *Aliases {
+	subsystem := "index_alias"
+
+	return &Aliases{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch aliases endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch aliases scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		alias: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "index", "alias"),
+			"Alias pointing to an index, with whether the index is the write index of the alias",
+			[]string{"alias", "index", "is_write_index"}, nil,
+		),
+	}
+}
+
+// Describe add Aliases metrics descriptions
+func (a *Aliases) Describe(ch chan<- *prometheus.Desc) {
+	ch <- a.alias
+	ch <- a.up.Desc()
+	ch <- a.totalScrapes.Desc()
+	ch <- a.jsonParseFailures.Desc()
+}
+
+func (a *Aliases) fetchAndDecodeAliases() (aliasesResponse, error) {
+	var ar aliasesResponse
+
+	u := *a.url
+	u.Path = path.Join(u.Path, "/_alias")
+
+	res, err := a.client.Get(u.String())
+	if err != nil {
+		return ar, fmt.Errorf("failed to get aliases from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(a.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return ar, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&ar); err != nil {
+		a.jsonParseFailures.Inc()
+		return ar, err
+	}
+	return ar, nil
+}
+
+// Collect gets Aliases metric values
+func (a *Aliases) Collect(ch chan<- prometheus.Metric) {
+	a.totalScrapes.Inc()
+	defer func() {
+		ch <- a.up
+		ch <- a.totalScrapes
+		ch <- a.jsonParseFailures
+	}()
+
+	ar, err := a.fetchAndDecodeAliases()
+	if err != nil {
+		a.up.Set(0)
+		_ = level.Warn(a.logger).Log(
+			"msg", "failed to fetch and decode aliases",
+			"err", err,
+		)
+		return
+	}
+	a.up.Set(1)
+
+	for index, indexAliases := range ar {
+		for alias, aliasResponse := range indexAliases.Aliases {
+			ch <- prometheus.MustNewConstMetric(
+				a.alias,
+				prometheus.GaugeValue,
+				1,
+				alias, index, strconv.FormatBool(aliasResponse.IsWriteIndex),
+			)
+		}
+	}
+}
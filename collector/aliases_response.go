@@ -0,0 +1,17 @@
+package collector
+
+// aliasesResponse is a representation of the Elasticsearch _alias endpoint, keyed by index
+type aliasesResponse map[string]indexAliasesResponse
+
+// indexAliasesResponse defines the aliases of a single index
+type indexAliasesResponse struct {
+	Aliases map[string]aliasResponse `json:"aliases"`
+}
+
+// aliasResponse defines a single alias of an index
+type aliasResponse struct {
+	IsWriteIndex  bool   `json:"is_write_index"`
+	IsHidden      bool   `json:"is_hidden"`
+	IndexRouting  string `json:"index_routing"`
+	SearchRouting string `json:"search_routing"`
+}
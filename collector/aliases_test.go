@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestAliases(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/logs-000001 -H "Content-Type: application/json" -d '{"aliases":{"logs":{"is_write_index":false}}}'
+	//  curl -XPUT http://localhost:9200/logs-000002 -H "Content-Type: application/json" -d '{"aliases":{"logs":{"is_write_index":true},"logs-search":{}}}'
+	//  curl -XPUT http://localhost:9200/twitter
+	//  curl http://localhost:9200/_alias
+	tcs := map[string]string{
+		"5.4.2":  `{"twitter":{"aliases":{}},"logs-000001":{"aliases":{"logs":{}}}}`,
+		"7.10.0": `{"twitter":{"aliases":{}},"logs-000001":{"aliases":{"logs":{"is_write_index":false}}},"logs-000002":{"aliases":{"logs":{"is_write_index":true},"logs-search":{}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		a := NewAliases(log.NewNopLogger(), http.DefaultClient, u)
+		ar, err := a.fetchAndDecodeAliases()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode aliases: %s", err)
+		}
+		t.Logf("[%s] Aliases Response: %+v", ver, ar)
+		if len(ar["twitter"].Aliases) != 0 {
+			t.Errorf("Index twitter should not have aliases")
+		}
+		if _, ok := ar["logs-000001"].Aliases["logs"]; !ok {
+			t.Errorf("Alias logs is missing on logs-000001")
+		}
+		if ver == "7.10.0" {
+			if ar["logs-000001"].Aliases["logs"].IsWriteIndex || !ar["logs-000002"].Aliases["logs"].IsWriteIndex {
+				t.Errorf("Wrong write index for alias logs")
+			}
+			if len(ar["logs-000002"].Aliases) != 2 {
+				t.Errorf("Wrong number of aliases on logs-000002")
+			}
+		}
+	}
+}
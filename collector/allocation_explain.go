@@ -0,0 +1,296 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxAllocationExplanations limits the number of unassigned shards which are explained per scrape
+const maxAllocationExplanations = 50
+
+var defaultAllocationExplainLabels = []string{"index", "shard", "primary"}
+
+// unassignedShardExplanation is the allocation explanation shared by all unassigned copies of a shard
+type unassignedShardExplanation struct {
+	allocationExplainResponse
+	copies int64
+}
+
+// AllocationExplain information struct
+type AllocationExplain struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	unassignedShard          *prometheus.Desc
+	unassignedCopies         *prometheus.Desc
+	deciderNo                *prometheus.Desc
+	failedAllocationAttempts *prometheus.Desc
+	maxRetriesExceeded       *prometheus.Desc
+}
+
+func init() {
+	registerCollector("allocation_explain", defaultDisabled, "Export allocation explanations for unassigned shards.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewAllocationExplain(logger, client, url)
+	})
+}
+
+// NewAllocationExplain defines Allocation Explain Prometheus metrics
+func NewAllocationExplain(logger log.Logger, client *http.Client, url *url.URL) *AllocationExplain {
+	subsystem := "allocation_explain"
+
+	return &AllocationExplain{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch allocation explain endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch allocation explain scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		unassignedShard: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "unassigned_shard"),
+			"Unassigned shard with the reason it became unassigned and whether it can be allocated",
+			append(defaultAllocationExplainLabels, "reason", "can_allocate"), nil,
+		),
+		unassignedCopies: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "unassigned_copies"),
+			"Number of unassigned copies of the shard, all replicas of a shard share a single explanation",
+			defaultAllocationExplainLabels, nil,
+		),
+		deciderNo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "decider_no"),
+			"Number of nodes on which the allocation decider prevents the allocation of the unassigned shard",
+			append(defaultAllocationExplainLabels, "decider"), nil,
+		),
+		failedAllocationAttempts: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "failed_allocation_attempts"),
+			"Number of consecutive failed attempts to allocate the unassigned shard",
+			defaultAllocationExplainLabels, nil,
+		),
+		maxRetriesExceeded: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "max_retries_exceeded_shards"),
+			"Number of explained unassigned shards which exceeded index.allocation.max_retries and need a reroute with retry_failed",
+			nil, nil,
+		),
+	}
+}
+
+// Describe add Allocation Explain metrics descriptions
+func (ae *AllocationExplain) Describe(ch chan<- *prometheus.Desc) {
+	ch <- ae.unassignedShard
+	ch <- ae.unassignedCopies
+	ch <- ae.deciderNo
+	ch <- ae.failedAllocationAttempts
+	ch <- ae.maxRetriesExceeded
+	ch <- ae.up.Desc()
+	ch <- ae.totalScrapes.Desc()
+	ch <- ae.jsonParseFailures.Desc()
+}
+
+func (ae *AllocationExplain) getAndParseURL(u *url.URL, data interface{}) error {
+	res, err := ae.client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(ae.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		ae.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+func (ae *AllocationExplain) postAndParseURL(u *url.URL, body, data interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	res, err := ae.client.Post(u.String(), "application/json", bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("failed to post to %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(ae.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		ae.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+// fetchAndDecodeAllocationExplanations explains every unassigned shard once, the replicas of a shard are
+// indistinguishable for the allocation explain API and counted as copies of the first explanation
+func (ae *AllocationExplain) fetchAndDecodeAllocationExplanations() ([]unassignedShardExplanation, error) {
+	u := *ae.url
+	u.Path = path.Join(u.Path, "/_cat/shards")
+	u.RawQuery = "format=json&h=index,shard,prirep,state"
+	var csr catShardsResponse
+	if err := ae.getAndParseURL(&u, &csr); err != nil {
+		return nil, err
+	}
+
+	var explanations []unassignedShardExplanation
+	// index of the explanation of a shard, -1 if the shard could not be explained
+	explained := make(map[allocationExplainRequest]int)
+	for _, shard := range csr {
+		if shard.State != "UNASSIGNED" {
+			continue
+		}
+		id, err := strconv.ParseInt(shard.Shard, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		req := allocationExplainRequest{Index: shard.Index, Shard: id, Primary: shard.Prirep == "p"}
+		if i, ok := explained[req]; ok {
+			if i >= 0 {
+				explanations[i].copies++
+			}
+			continue
+		}
+		if len(explanations) >= maxAllocationExplanations {
+			_ = level.Warn(ae.logger).Log(
+				"msg", "too many unassigned shards, skipping allocation explanations",
+				"limit", maxAllocationExplanations,
+			)
+			break
+		}
+
+		u := *ae.url
+		u.Path = path.Join(u.Path, "/_cluster/allocation/explain")
+		var aer allocationExplainResponse
+		// the shard may have been allocated since it was listed, which fails the explanation
+		if err := ae.postAndParseURL(&u, req, &aer); err != nil {
+			_ = level.Warn(ae.logger).Log(
+				"msg", "failed to explain unassigned shard",
+				"index", shard.Index,
+				"shard", shard.Shard,
+				"err", err,
+			)
+			explained[req] = -1
+			continue
+		}
+		explained[req] = len(explanations)
+		explanations = append(explanations, unassignedShardExplanation{allocationExplainResponse: aer, copies: 1})
+	}
+	return explanations, nil
+}
+
+// Collect gets Allocation Explain metric values
+func (ae *AllocationExplain) Collect(ch chan<- prometheus.Metric) {
+	ae.totalScrapes.Inc()
+	defer func() {
+		ch <- ae.up
+		ch <- ae.totalScrapes
+		ch <- ae.jsonParseFailures
+	}()
+
+	explanations, err := ae.fetchAndDecodeAllocationExplanations()
+	if err != nil {
+		ae.up.Set(0)
+		_ = level.Warn(ae.logger).Log(
+			"msg", "failed to fetch and decode allocation explain",
+			"err", err,
+		)
+		return
+	}
+	ae.up.Set(1)
+
+	var maxRetriesExceeded int
+	for _, explanation := range explanations {
+		shard := strconv.FormatInt(explanation.Shard, 10)
+		primary := strconv.FormatBool(explanation.Primary)
+		ch <- prometheus.MustNewConstMetric(
+			ae.unassignedShard,
+			prometheus.GaugeValue,
+			1,
+			explanation.Index, shard, primary, explanation.UnassignedInfo.Reason, explanation.CanAllocate,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			ae.unassignedCopies,
+			prometheus.GaugeValue,
+			float64(explanation.copies),
+			explanation.Index, shard, primary,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			ae.failedAllocationAttempts,
+			prometheus.GaugeValue,
+			float64(explanation.UnassignedInfo.FailedAllocationAttempts),
+			explanation.Index, shard, primary,
+		)
+		if explanation.MaxRetriesExceeded() {
+			maxRetriesExceeded++
+		}
+
+		deciders := make(map[string]int64)
+		for _, node := range explanation.NodeAllocationDecisions {
+			for _, decider := range node.Deciders {
+				if decider.Decision == "NO" {
+					deciders[decider.Decider]++
+				}
+			}
+		}
+		for decider, nodes := range deciders {
+			ch <- prometheus.MustNewConstMetric(
+				ae.deciderNo,
+				prometheus.GaugeValue,
+				float64(nodes),
+				explanation.Index, shard, primary, decider,
+			)
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(
+		ae.maxRetriesExceeded,
+		prometheus.GaugeValue,
+		float64(maxRetriesExceeded),
+	)
+}
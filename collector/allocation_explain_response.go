@@ -0,0 +1,50 @@
+package collector
+
+// allocationExplainRequest is the body of a request to the Elasticsearch _cluster/allocation/explain endpoint
+type allocationExplainRequest struct {
+	Index   string `json:"index"`
+	Shard   int64  `json:"shard"`
+	Primary bool   `json:"primary"`
+}
+
+// allocationExplainResponse is a representation of the Elasticsearch _cluster/allocation/explain endpoint
+type allocationExplainResponse struct {
+	Index          string `json:"index"`
+	Shard          int64  `json:"shard"`
+	Primary        bool   `json:"primary"`
+	CurrentState   string `json:"current_state"`
+	UnassignedInfo struct {
+		Reason                   string `json:"reason"`
+		At                       string `json:"at"`
+		FailedAllocationAttempts int64  `json:"failed_allocation_attempts"`
+		LastAllocationStatus     string `json:"last_allocation_status"`
+	} `json:"unassigned_info"`
+	CanAllocate             string                                  `json:"can_allocate"`
+	AllocateExplanation     string                                  `json:"allocate_explanation"`
+	NodeAllocationDecisions []allocationExplainNodeDecisionResponse `json:"node_allocation_decisions"`
+}
+
+// MaxRetriesExceeded returns whether the shard is not allocated anymore because it failed to
+// allocate index.allocation.max_retries times in a row and needs a reroute with retry_failed
+func (r allocationExplainResponse) MaxRetriesExceeded() bool {
+	for _, node := range r.NodeAllocationDecisions {
+		for _, decider := range node.Deciders {
+			if decider.Decider == "max_retry" && decider.Decision == "NO" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allocationExplainNodeDecisionResponse defines the allocation decision for a single node
+type allocationExplainNodeDecisionResponse struct {
+	NodeID       string `json:"node_id"`
+	NodeName     string `json:"node_name"`
+	NodeDecision string `json:"node_decision"`
+	Deciders     []struct {
+		Decider     string `json:"decider"`
+		Decision    string `json:"decision"`
+		Explanation string `json:"explanation"`
+	} `json:"deciders"`
+}
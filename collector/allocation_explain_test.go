@@ -0,0 +1,164 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestAllocationExplain(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/twitter -d '{"settings":{"number_of_shards":1,"number_of_replicas":1}}'
+	//  curl http://localhost:9200/_cat/shards?format=json&h=index,shard,prirep,state
+	//  curl -XPOST http://localhost:9200/_cluster/allocation/explain -d '{"index":"twitter","shard":0,"primary":false}'
+	tcs := map[string][]string{
+		"7.10.0": {
+			`[{"index":"twitter","shard":"0","prirep":"p","state":"STARTED"},{"index":"twitter","shard":"0","prirep":"r","state":"UNASSIGNED"}]`,
+			`{"index":"twitter","shard":0,"primary":false,"current_state":"unassigned","unassigned_info":{"reason":"INDEX_CREATED","at":"2020-11-18T20:56:23.303Z","last_allocation_status":"no_attempt"},"can_allocate":"no","allocate_explanation":"cannot allocate because allocation is not permitted to any of the nodes","node_allocation_decisions":[{"node_id":"Bu9Dc0qkQVmPSbRx9B0KjQ","node_name":"es01","transport_address":"172.17.0.2:9300","node_attributes":{},"node_decision":"no","weight_ranking":1,"deciders":[{"decider":"same_shard","decision":"NO","explanation":"a copy of this shard is already allocated to this node [[twitter][0], node[Bu9Dc0qkQVmPSbRx9B0KjQ], [P], s[STARTED], a[id=3Klo5dSQRbeYq3mwkByFOQ]]"},{"decider":"disk_threshold","decision":"NO","explanation":"the node is above the low watermark cluster setting [cluster.routing.allocation.disk.watermark.low=85%]"}]}]}`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/_cluster/allocation/explain" {
+				var req allocationExplainRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Index != "twitter" || req.Shard != 0 || req.Primary {
+					t.Errorf("Wrong allocation explain request: %+v", req)
+				}
+				fmt.Fprint(w, out[1])
+				return
+			}
+			fmt.Fprint(w, out[0])
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		ae := NewAllocationExplain(log.NewNopLogger(), http.DefaultClient, u)
+		explanations, err := ae.fetchAndDecodeAllocationExplanations()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode allocation explanations: %s", err)
+		}
+		t.Logf("[%s] Allocation Explain Response: %+v", ver, explanations)
+		if len(explanations) != 1 {
+			t.Fatalf("Wrong number of allocation explanations")
+		}
+		if explanations[0].UnassignedInfo.Reason != "INDEX_CREATED" || explanations[0].CanAllocate != "no" {
+			t.Errorf("Wrong allocation explanation")
+		}
+		if len(explanations[0].NodeAllocationDecisions) != 1 || len(explanations[0].NodeAllocationDecisions[0].Deciders) != 2 {
+			t.Errorf("Wrong node allocation decisions")
+		}
+		if explanations[0].MaxRetriesExceeded() {
+			t.Errorf("Shard should not have exceeded the maximum allocation retries")
+		}
+	}
+}
+
+func TestAllocationExplainMaxRetries(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/twitter -d '{"settings":{"number_of_shards":1,"number_of_replicas":0,"index.routing.allocation.require.box_type":"hot"}}'
+	//  # let the allocation of the shard fail five times, e.g. by a full disk on the hot node
+	//  curl -XPOST http://localhost:9200/_cluster/allocation/explain -d '{"index":"twitter","shard":0,"primary":true}'
+	out := `{"index":"twitter","shard":0,"primary":true,"current_state":"unassigned","unassigned_info":{"reason":"ALLOCATION_FAILED","at":"2020-11-18T21:14:02.511Z","failed_allocation_attempts":5,"details":"failed shard on node [tMTocMvQQgGCkj7QDHl3OA]: failed recovery, failure RecoveryFailedException","last_allocation_status":"no"},"can_allocate":"no","allocate_explanation":"cannot allocate because allocation is not permitted to any of the nodes","node_allocation_decisions":[{"node_id":"tMTocMvQQgGCkj7QDHl3OA","node_name":"es01","transport_address":"172.17.0.2:9300","node_decision":"no","deciders":[{"decider":"max_retry","decision":"NO","explanation":"shard has exceeded the maximum number of retries [5] on failed allocation attempts - manually call [/_cluster/reroute?retry_failed=true] to retry"}]}]}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_cluster/allocation/explain" {
+			fmt.Fprint(w, out)
+			return
+		}
+		fmt.Fprint(w, `[{"index":"twitter","shard":"0","prirep":"p","state":"UNASSIGNED"}]`)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	ae := NewAllocationExplain(log.NewNopLogger(), http.DefaultClient, u)
+	explanations, err := ae.fetchAndDecodeAllocationExplanations()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode allocation explanations: %s", err)
+	}
+	if len(explanations) != 1 {
+		t.Fatalf("Wrong number of allocation explanations")
+	}
+	if explanations[0].UnassignedInfo.FailedAllocationAttempts != 5 {
+		t.Errorf("Wrong number of failed allocation attempts")
+	}
+	if !explanations[0].MaxRetriesExceeded() {
+		t.Errorf("Shard should have exceeded the maximum allocation retries")
+	}
+}
+
+func TestAllocationExplainReplicas(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/twitter -d '{"settings":{"number_of_shards":2,"number_of_replicas":2}}'
+	//  # stop two of three nodes, shard 1 gets allocated between the listing and the explanation
+	//  curl http://localhost:9200/_cat/shards?format=json&h=index,shard,prirep,state
+	//  curl -XPOST http://localhost:9200/_cluster/allocation/explain -d '{"index":"twitter","shard":0,"primary":false}'
+	shards := `[{"index":"twitter","shard":"0","prirep":"p","state":"STARTED"},{"index":"twitter","shard":"0","prirep":"r","state":"UNASSIGNED"},{"index":"twitter","shard":"0","prirep":"r","state":"UNASSIGNED"},{"index":"twitter","shard":"1","prirep":"p","state":"STARTED"},{"index":"twitter","shard":"1","prirep":"r","state":"UNASSIGNED"}]`
+	out := `{"index":"twitter","shard":0,"primary":false,"current_state":"unassigned","unassigned_info":{"reason":"NODE_LEFT","at":"2020-11-18T21:32:45.106Z","details":"node_left [5vqSqiiqR02hd5omyJpCfA]","last_allocation_status":"no_attempt"},"can_allocate":"no","allocate_explanation":"cannot allocate because allocation is not permitted to any of the nodes","node_allocation_decisions":[{"node_id":"ls3K4bmFRn2UQG4l3Pd8IQ","node_name":"node-1","transport_address":"172.17.0.2:9300","node_attributes":{"ml.machine_memory":"2083229696","xpack.installed":"true","transform.node":"true","ml.max_open_jobs":"20"},"node_decision":"no","weight_ranking":1,"deciders":[{"decider":"same_shard","decision":"NO","explanation":"a copy of this shard is already allocated to this node [[twitter][0], node[ls3K4bmFRn2UQG4l3Pd8IQ], [P], s[STARTED], a[id=E6cQ8qd-Qp6TvI9ssvahxg]]"}]}]}`
+	already := `{"error":{"root_cause":[{"type":"illegal_argument_exception","reason":"unable to find any unassigned shards to explain [ShardId [[twitter][1]], primary=false]"}],"type":"illegal_argument_exception","reason":"unable to find any unassigned shards to explain [ShardId [[twitter][1]], primary=false]"},"status":400}`
+	explained := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_cluster/allocation/explain" {
+			explained++
+			var req allocationExplainRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Shard != 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, already)
+				return
+			}
+			fmt.Fprint(w, out)
+			return
+		}
+		fmt.Fprint(w, shards)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	ae := NewAllocationExplain(log.NewNopLogger(), http.DefaultClient, u)
+	explanations, err := ae.fetchAndDecodeAllocationExplanations()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode allocation explanations: %s", err)
+	}
+	if explained != 2 {
+		t.Errorf("Wrong number of allocation explain requests %d", explained)
+	}
+	if len(explanations) != 1 || explanations[0].copies != 2 {
+		t.Fatalf("Wrong allocation explanations %+v", explanations)
+	}
+
+	// the copies of a shard must not yield duplicate series
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(ae)
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %s", err)
+	}
+	for _, mf := range mfs {
+		switch mf.GetName() {
+		case "elasticsearch_allocation_explain_up":
+			if v := mf.GetMetric()[0].GetGauge().GetValue(); v != 1 {
+				t.Errorf("Failed explanation of a single shard should not fail the scrape")
+			}
+		case "elasticsearch_allocation_explain_unassigned_copies":
+			if len(mf.GetMetric()) != 1 || mf.GetMetric()[0].GetGauge().GetValue() != 2 {
+				t.Errorf("Wrong unassigned copies %+v", mf.GetMetric())
+			}
+		}
+	}
+}
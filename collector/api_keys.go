@@ -0,0 +1,192 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxAPIKeys is the default index.max_result_window of the security index, the keys are queried in a single page
+const maxAPIKeys = 10000
+
+// APIKeys information struct
+type APIKeys struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	active     *prometheus.Desc
+	expired    *prometheus.Desc
+	nextExpiry *prometheus.Desc
+}
+
+func init() {
+	registerCollector("api_keys", defaultDisabled, "Export the number of active API keys and their soonest expiry.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewAPIKeys(logger, client, url)
+	})
+}
+
+// NewAPIKeys defines API Keys Prometheus metrics
+func NewAPIKeys(logger log.Logger, client *http.Client, url *url.URL) *APIKeys {
+	subsystem := "api_keys"
+
+	return &APIKeys{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch api keys endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch api keys scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		active: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "active"),
+			"Number of API keys which are neither invalidated nor expired",
+			nil, nil,
+		),
+		expired: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "expired"),
+			"Number of API keys which are expired but not invalidated",
+			nil, nil,
+		),
+		nextExpiry: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "next_expiry_timestamp_seconds"),
+			"Soonest expiry of the active API keys as unix timestamp, only exported if an active key expires",
+			nil, nil,
+		),
+	}
+}
+
+// Describe add API Keys metrics descriptions
+func (ak *APIKeys) Describe(ch chan<- *prometheus.Desc) {
+	ch <- ak.active
+	ch <- ak.expired
+	ch <- ak.nextExpiry
+	ch <- ak.up.Desc()
+	ch <- ak.totalScrapes.Desc()
+	ch <- ak.jsonParseFailures.Desc()
+}
+
+func (ak *APIKeys) fetchAndDecodeAPIKeys() (apiKeysResponse, error) {
+	var akr apiKeysResponse
+
+	u := *ak.url
+	u.Path = path.Join(u.Path, "/_security/_query/api_key")
+
+	// invalidated keys are kept until they are removed after a week, they are never counted
+	b, err := json.Marshal(apiKeysQueryRequest{
+		Size:  maxAPIKeys,
+		Query: map[string]interface{}{"term": map[string]interface{}{"invalidated": false}},
+	})
+	if err != nil {
+		return akr, err
+	}
+	res, err := ak.client.Post(u.String(), "application/json", bytes.NewReader(b))
+	if err != nil {
+		return akr, fmt.Errorf("failed to post api keys query to %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(ak.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return akr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&akr); err != nil {
+		ak.jsonParseFailures.Inc()
+		return akr, err
+	}
+	return akr, nil
+}
+
+// Collect gets API Keys metric values
+func (ak *APIKeys) Collect(ch chan<- prometheus.Metric) {
+	ak.totalScrapes.Inc()
+	defer func() {
+		ch <- ak.up
+		ch <- ak.totalScrapes
+		ch <- ak.jsonParseFailures
+	}()
+
+	akr, err := ak.fetchAndDecodeAPIKeys()
+	if err != nil {
+		ak.up.Set(0)
+		_ = level.Warn(ak.logger).Log(
+			"msg", "failed to fetch and decode api keys",
+			"err", err,
+		)
+		return
+	}
+	ak.up.Set(1)
+
+	if akr.Total > akr.Count {
+		_ = level.Warn(ak.logger).Log(
+			"msg", "too many api keys, only the first page is counted",
+			"total", akr.Total,
+			"limit", maxAPIKeys,
+		)
+	}
+
+	var active, expired float64
+	var nextExpiry int64
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	for _, key := range akr.APIKeys {
+		if key.Invalidated {
+			continue
+		}
+		if key.Expiration != 0 && key.Expiration <= now {
+			expired++
+			continue
+		}
+		active++
+		if key.Expiration != 0 && (nextExpiry == 0 || key.Expiration < nextExpiry) {
+			nextExpiry = key.Expiration
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		ak.active,
+		prometheus.GaugeValue,
+		active,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		ak.expired,
+		prometheus.GaugeValue,
+		expired,
+	)
+	if nextExpiry != 0 {
+		ch <- prometheus.MustNewConstMetric(
+			ak.nextExpiry,
+			prometheus.GaugeValue,
+			float64(nextExpiry)/1000,
+		)
+	}
+}
@@ -0,0 +1,25 @@
+package collector
+
+// apiKeysQueryRequest is the body of a request to the Elasticsearch _security/_query/api_key endpoint
+type apiKeysQueryRequest struct {
+	Size  int                    `json:"size"`
+	Query map[string]interface{} `json:"query"`
+}
+
+// apiKeysResponse is a representation of the Elasticsearch _security/_query/api_key endpoint
+type apiKeysResponse struct {
+	Total   int64            `json:"total"`
+	Count   int64            `json:"count"`
+	APIKeys []apiKeyResponse `json:"api_keys"`
+}
+
+// apiKeyResponse defines a single API key, the expiration is omitted for keys which never expire
+type apiKeyResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Creation    int64  `json:"creation"`
+	Expiration  int64  `json:"expiration"`
+	Invalidated bool   `json:"invalidated"`
+	Username    string `json:"username"`
+	Realm       string `json:"realm"`
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestAPIKeys(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e xpack.security.enabled=true ... elasticsearch:VERSION
+	//  curl -u elastic:changeme -XPOST -H 'Content-Type: application/json' http://localhost:9200/_security/_query/api_key -d '{"size":10000,"query":{"term":{"invalidated":false}}}'
+	tcs := map[string]string{
+		"7.15.0": `{"total":2,"count":2,"api_keys":[{"id":"VuaCfGcBCdbkQm-e5aOx","name":"ci-deploy","creation":1634210480322,"expiration":1641986480322,"invalidated":false,"username":"elastic","realm":"reserved","metadata":{}},{"id":"H3_AhoIBA9hmeQJdg7ij","name":"beats","creation":1634210520127,"invalidated":false,"username":"elastic","realm":"reserved","metadata":{"application":"filebeat"}}]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req apiKeysQueryRequest
+			if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&req) != nil || req.Size != maxAPIKeys {
+				t.Errorf("Wrong api keys query %s %+v", r.Method, req)
+			}
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewAPIKeys(log.NewNopLogger(), http.DefaultClient, u)
+		akr, err := c.fetchAndDecodeAPIKeys()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode api keys: %s", err)
+		}
+		t.Logf("[%s] API Keys Response: %+v", ver, akr)
+		if akr.Total != 2 || len(akr.APIKeys) != 2 {
+			t.Fatalf("Wrong number of api keys")
+		}
+		if key := akr.APIKeys[0]; key.Name != "ci-deploy" || key.Expiration != 1641986480322 || key.Invalidated {
+			t.Errorf("Wrong api key %+v", key)
+		}
+		if key := akr.APIKeys[1]; key.Expiration != 0 {
+			t.Errorf("Wrong expiration of api key without expiry %+v", key)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// asyncSearchTypes maps the actions of long running searches to the type label
+	asyncSearchTypes = map[string]string{
+		"indices:data/read/async_search/submit": "async_search",
+		"indices:data/read/eql":                 "eql",
+	}
+)
+
+// AsyncSearch information struct
+type AsyncSearch struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	active               *prometheus.Desc
+	oldestRunningSeconds *prometheus.Desc
+}
+
+func init() {
+	registerCollector("async_search", defaultDisabled, "Export stats for running async searches and EQL searches.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewAsyncSearch(logger, client, url)
+	})
+}
+
+// NewAsyncSearch defines AsyncSearch Prometheus metrics
+func NewAsyncSearch(logger log.Logger, client *http.Client, url *url.URL) *AsyncSearch {
+	subsystem := "async_search"
+
+	return &AsyncSearch{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch async search tasks endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch async search tasks scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		active: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "active"),
+			"Number of async searches and EQL searches currently running",
+			[]string{"type"}, nil,
+		),
+		oldestRunningSeconds: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "oldest_running_time_seconds"),
+			"Running time of the longest running async search or EQL search in seconds",
+			[]string{"type"}, nil,
+		),
+	}
+}
+
+// Describe add AsyncSearch metrics descriptions
+func (a *AsyncSearch) Describe(ch chan<- *prometheus.Desc) {
+	ch <- a.active
+	ch <- a.oldestRunningSeconds
+	ch <- a.up.Desc()
+	ch <- a.totalScrapes.Desc()
+	ch <- a.jsonParseFailures.Desc()
+}
+
+func (a *AsyncSearch) fetchAndDecodeAsyncSearchTasks() (tasksResponse, error) {
+	var tr tasksResponse
+
+	u := *a.url
+	u.Path = path.Join(u.Path, "/_tasks")
+	u.RawQuery = "actions=indices:data/read/async_search*,indices:data/read/eql*"
+
+	res, err := a.client.Get(u.String())
+	if err != nil {
+		return tr, fmt.Errorf("failed to get async search tasks from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(a.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return tr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&tr); err != nil {
+		a.jsonParseFailures.Inc()
+		return tr, err
+	}
+	return tr, nil
+}
+
+// Collect gets AsyncSearch metric values
+func (a *AsyncSearch) Collect(ch chan<- prometheus.Metric) {
+	a.totalScrapes.Inc()
+	defer func() {
+		ch <- a.up
+		ch <- a.totalScrapes
+		ch <- a.jsonParseFailures
+	}()
+
+	tr, err := a.fetchAndDecodeAsyncSearchTasks()
+	if err != nil {
+		a.up.Set(0)
+		_ = level.Warn(a.logger).Log(
+			"msg", "failed to fetch and decode async search tasks",
+			"err", err,
+		)
+		return
+	}
+	a.up.Set(1)
+
+	active := make(map[string]float64, len(asyncSearchTypes))
+	oldest := make(map[string]float64, len(asyncSearchTypes))
+	for _, searchType := range asyncSearchTypes {
+		active[searchType] = 0
+		oldest[searchType] = 0
+	}
+	for _, node := range tr.Nodes {
+		for _, task := range node.Tasks {
+			searchType, ok := asyncSearchTypes[task.Action]
+			// child tasks run on behalf of the submitted search and are not counted separately
+			if !ok || task.ParentTaskID != "" {
+				continue
+			}
+			active[searchType]++
+			if runningSeconds := float64(task.RunningTimeInNanos) / 1e9; runningSeconds > oldest[searchType] {
+				oldest[searchType] = runningSeconds
+			}
+		}
+	}
+
+	for searchType, count := range active {
+		ch <- prometheus.MustNewConstMetric(
+			a.active,
+			prometheus.GaugeValue,
+			count,
+			searchType,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			a.oldestRunningSeconds,
+			prometheus.GaugeValue,
+			oldest[searchType],
+			searchType,
+		)
+	}
+}
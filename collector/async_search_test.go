@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestAsyncSearch(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPOST http://localhost:9200/foo_1/_async_search?wait_for_completion_timeout=0 -H "Content-Type: application/json" -d '{"query":{"match_all":{}}}'
+	//  curl -XPOST http://localhost:9200/foo_1/_eql/search?wait_for_completion_timeout=0 -H "Content-Type: application/json" -d '{"query":"process where true"}'
+	//  curl http://localhost:9200/_tasks?actions=indices:data/read/async_search*,indices:data/read/eql*
+	tcs := map[string]string{
+		"7.10.0": `{"nodes":{"tMTocMvQQgGCkj7QDHl3OA":{"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"tasks":{"tMTocMvQQgGCkj7QDHl3OA:2048":{"node":"tMTocMvQQgGCkj7QDHl3OA","id":2048,"type":"transport","action":"indices:data/read/async_search/submit","start_time_in_millis":1610120231047,"running_time_in_nanos":62841000000,"cancellable":true,"headers":{}},"tMTocMvQQgGCkj7QDHl3OA:2049":{"node":"tMTocMvQQgGCkj7QDHl3OA","id":2049,"type":"transport","action":"indices:data/read/async_search/submit","start_time_in_millis":1610120281047,"running_time_in_nanos":12841000000,"cancellable":true,"headers":{}},"tMTocMvQQgGCkj7QDHl3OA:2101":{"node":"tMTocMvQQgGCkj7QDHl3OA","id":2101,"type":"transport","action":"indices:data/read/eql","start_time_in_millis":1610120290112,"running_time_in_nanos":3120000000,"cancellable":true,"headers":{}}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewAsyncSearch(log.NewNopLogger(), http.DefaultClient, u)
+		tr, err := c.fetchAndDecodeAsyncSearchTasks()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode async search tasks: %s", err)
+		}
+		t.Logf("[%s] Async Search Tasks Response: %+v", ver, tr)
+		counts := map[string]int{}
+		for _, task := range tr.Nodes["tMTocMvQQgGCkj7QDHl3OA"].Tasks {
+			counts[asyncSearchTypes[task.Action]]++
+		}
+		if counts["async_search"] != 2 || counts["eql"] != 1 {
+			t.Errorf("Wrong number of async searches %v", counts)
+		}
+		if tr.Nodes["tMTocMvQQgGCkj7QDHl3OA"].Tasks["tMTocMvQQgGCkj7QDHl3OA:2048"].RunningTimeInNanos != 62841000000 {
+			t.Errorf("Wrong async search running time")
+		}
+	}
+}
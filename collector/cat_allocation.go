@@ -0,0 +1,211 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type catAllocationMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(node catAllocationNodeResponse) string
+}
+
+// CatAllocation information struct
+type CatAllocation struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	metrics []*catAllocationMetric
+}
+
+func init() {
+	registerCollector("cat_allocation", defaultDisabled, "Export per node shard count and disk usage.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewCatAllocation(logger, client, url)
+	})
+}
+
+// NewCatAllocation defines Cat Allocation Prometheus metrics
+func NewCatAllocation(logger log.Logger, client *http.Client, url *url.URL) *CatAllocation {
+	subsystem := "allocation"
+
+	return &CatAllocation{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch cat allocation endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch cat allocation scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		metrics: []*catAllocationMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "shards"),
+					"Number of shards allocated to the node, unassigned shards are reported for node UNASSIGNED",
+					[]string{"node"}, nil,
+				),
+				Value: func(node catAllocationNodeResponse) string {
+					return node.Shards
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "disk_indices_bytes"),
+					"Disk space used by the shards of the node in bytes",
+					[]string{"node"}, nil,
+				),
+				Value: func(node catAllocationNodeResponse) string {
+					return node.DiskIndices
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "disk_used_bytes"),
+					"Total disk space used on the node in bytes",
+					[]string{"node"}, nil,
+				),
+				Value: func(node catAllocationNodeResponse) string {
+					return node.DiskUsed
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "disk_available_bytes"),
+					"Free disk space available to Elasticsearch on the node in bytes",
+					[]string{"node"}, nil,
+				),
+				Value: func(node catAllocationNodeResponse) string {
+					return node.DiskAvail
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "disk_total_bytes"),
+					"Total disk space of the node in bytes",
+					[]string{"node"}, nil,
+				),
+				Value: func(node catAllocationNodeResponse) string {
+					return node.DiskTotal
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "disk_percent"),
+					"Percentage of the disk space used on the node",
+					[]string{"node"}, nil,
+				),
+				Value: func(node catAllocationNodeResponse) string {
+					return node.DiskPercent
+				},
+			},
+		},
+	}
+}
+
+// Describe add Cat Allocation metrics descriptions
+func (ca *CatAllocation) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range ca.metrics {
+		ch <- metric.Desc
+	}
+	ch <- ca.up.Desc()
+	ch <- ca.totalScrapes.Desc()
+	ch <- ca.jsonParseFailures.Desc()
+}
+
+func (ca *CatAllocation) fetchAndDecodeCatAllocation() (catAllocationResponse, error) {
+	var car catAllocationResponse
+
+	u := *ca.url
+	u.Path = path.Join(u.Path, "/_cat/allocation")
+	u.RawQuery = "format=json&bytes=b"
+
+	res, err := ca.client.Get(u.String())
+	if err != nil {
+		return car, fmt.Errorf("failed to get cat allocation from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(ca.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return car, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&car); err != nil {
+		ca.jsonParseFailures.Inc()
+		return car, err
+	}
+	return car, nil
+}
+
+// Collect gets Cat Allocation metric values
+func (ca *CatAllocation) Collect(ch chan<- prometheus.Metric) {
+	ca.totalScrapes.Inc()
+	defer func() {
+		ch <- ca.up
+		ch <- ca.totalScrapes
+		ch <- ca.jsonParseFailures
+	}()
+
+	car, err := ca.fetchAndDecodeCatAllocation()
+	if err != nil {
+		ca.up.Set(0)
+		_ = level.Warn(ca.logger).Log(
+			"msg", "failed to fetch and decode cat allocation",
+			"err", err,
+		)
+		return
+	}
+	ca.up.Set(1)
+
+	for _, node := range car {
+		for _, metric := range ca.metrics {
+			// the UNASSIGNED row has no disk values
+			value, err := strconv.ParseFloat(metric.Value(node), 64)
+			if err != nil {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				value,
+				node.Node,
+			)
+		}
+	}
+}
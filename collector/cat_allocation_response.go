@@ -0,0 +1,17 @@
+package collector
+
+// catAllocationResponse is a representation of the Elasticsearch _cat/allocation endpoint
+type catAllocationResponse []catAllocationNodeResponse
+
+// catAllocationNodeResponse defines the disk allocation of a single node
+type catAllocationNodeResponse struct {
+	Shards      string `json:"shards"`
+	DiskIndices string `json:"disk.indices"`
+	DiskUsed    string `json:"disk.used"`
+	DiskAvail   string `json:"disk.avail"`
+	DiskTotal   string `json:"disk.total"`
+	DiskPercent string `json:"disk.percent"`
+	Host        string `json:"host"`
+	IP          string `json:"ip"`
+	Node        string `json:"node"`
+}
@@ -0,0 +1,48 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestCatAllocation(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/twitter -d '{"settings":{"number_of_shards":5,"number_of_replicas":1}}'
+	//  curl http://localhost:9200/_cat/allocation?format=json&bytes=b
+	tcs := map[string]string{
+		"6.8.0":  `[{"shards":"5","disk.indices":"1305","disk.used":"26939068416","disk.avail":"36364914688","disk.total":"63303983104","disk.percent":"42","host":"172.17.0.2","ip":"172.17.0.2","node":"es01"},{"shards":"5","disk.indices":null,"disk.used":null,"disk.avail":null,"disk.total":null,"disk.percent":null,"host":null,"ip":null,"node":"UNASSIGNED"}]`,
+		"7.10.0": `[{"shards":"5","disk.indices":"1305","disk.used":"26939068416","disk.avail":"36364914688","disk.total":"63303983104","disk.percent":"42","host":"172.17.0.2","ip":"172.17.0.2","node":"es01"},{"shards":"5","disk.indices":null,"disk.used":null,"disk.avail":null,"disk.total":null,"disk.percent":null,"host":null,"ip":null,"node":"UNASSIGNED"}]`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		ca := NewCatAllocation(log.NewNopLogger(), http.DefaultClient, u)
+		car, err := ca.fetchAndDecodeCatAllocation()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode cat allocation: %s", err)
+		}
+		t.Logf("[%s] Cat Allocation Response: %+v", ver, car)
+		if len(car) != 2 {
+			t.Fatalf("Wrong number of nodes")
+		}
+		if car[0].Node != "es01" || car[0].Shards != "5" || car[0].DiskAvail != "36364914688" || car[0].DiskPercent != "42" {
+			t.Errorf("Wrong node allocation")
+		}
+		if car[1].Node != "UNASSIGNED" || car[1].DiskUsed != "" {
+			t.Errorf("Wrong unassigned allocation")
+		}
+	}
+}
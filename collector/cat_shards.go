@@ -0,0 +1,325 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	defaultCatShardLabels = []string{"index", "shard", "prirep", "node"}
+
+	catShardStates = []string{"STARTED", "RELOCATING", "INITIALIZING", "UNASSIGNED"}
+)
+
+// catShardKey identifies a shard copy by its labels
+type catShardKey struct {
+	index, shard, prirep, node string
+}
+
+// key returns the labels of the shard copy. Relocating shards report their node as
+// "source -> target_ip target_id target", only the source node is kept
+func (s catShardResponse) key() catShardKey {
+	node := s.Node
+	if i := strings.Index(node, " -> "); i >= 0 {
+		node = node[:i]
+	}
+	return catShardKey{index: s.Index, shard: s.Shard, prirep: s.Prirep, node: node}
+}
+
+// relocationTarget returns the name of the node a relocating shard copy moves to
+func (s catShardResponse) relocationTarget() string {
+	i := strings.Index(s.Node, " -> ")
+	if i < 0 {
+		return ""
+	}
+	fields := strings.Fields(s.Node[i+len(" -> "):])
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
+
+// catShardRelocationKey identifies the source and target node of relocating shards
+type catShardRelocationKey struct {
+	source, target string
+}
+
+// shardSkew returns the skew of every index, the most shard copies of the index on a single node minus the mean
+// per node, and the same difference for the shard copies of all indices. Only nodes holding shard copies are counted
+func shardSkew(csr catShardsResponse) (map[string]float64, float64) {
+	nodes := make(map[string]int64)
+	indices := make(map[string]map[string]int64)
+	for _, shard := range csr {
+		if shard.State == "UNASSIGNED" {
+			continue
+		}
+		key := shard.key()
+		nodes[key.node]++
+		if _, ok := indices[key.index]; !ok {
+			indices[key.index] = make(map[string]int64)
+		}
+		indices[key.index][key.node]++
+	}
+
+	skew := func(counts map[string]int64) float64 {
+		var max, total int64
+		for _, count := range counts {
+			total += count
+			if count > max {
+				max = count
+			}
+		}
+		return float64(max) - float64(total)/float64(len(nodes))
+	}
+
+	indexSkew := make(map[string]float64, len(indices))
+	for index, counts := range indices {
+		indexSkew[index] = skew(counts)
+	}
+	if len(nodes) == 0 {
+		return indexSkew, 0
+	}
+	return indexSkew, skew(nodes)
+}
+
+// CatShards information struct
+type CatShards struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	docs         *prometheus.Desc
+	storeSize    *prometheus.Desc
+	state        *prometheus.Desc
+	relocating   *prometheus.Desc
+	initializing *prometheus.Desc
+	indexSkew    *prometheus.Desc
+	imbalance    *prometheus.Desc
+}
+
+func init() {
+	registerCollector("cat_shards", defaultDisabled, "Export per shard copy state, docs and store size (high cardinality).", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewCatShards(logger, client, url)
+	})
+}
+
+// NewCatShards defines Cat Shards Prometheus metrics
+func NewCatShards(logger log.Logger, client *http.Client, url *url.URL) *CatShards {
+	subsystem := "shard"
+
+	return &CatShards{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch cat shards endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch cat shards scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		docs: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "docs"),
+			"Number of documents in the shard copy",
+			defaultCatShardLabels, nil,
+		),
+		storeSize: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "store_size_bytes"),
+			"Store size of the shard copy in bytes",
+			defaultCatShardLabels, nil,
+		),
+		state: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "state"),
+			"Number of shard copies in the state, unassigned copies have an empty node label",
+			append(defaultCatShardLabels, "state"), nil,
+		),
+		relocating: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "relocating"),
+			"Number of shard copies relocating from the source node to the target node",
+			[]string{"source_node", "target_node"}, nil,
+		),
+		initializing: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "initializing"),
+			"Number of shard copies initializing on the node",
+			[]string{"node"}, nil,
+		),
+		indexSkew: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "index_skew"),
+			"Most shard copies of the index on a single node minus the mean number of copies per node holding shards",
+			[]string{"index"}, nil,
+		),
+		imbalance: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "node_imbalance"),
+			"Most shard copies on a single node minus the mean number of copies per node holding shards",
+			nil, nil,
+		),
+	}
+}
+
+// Describe add Cat Shards metrics descriptions
+func (cs *CatShards) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cs.docs
+	ch <- cs.storeSize
+	ch <- cs.state
+	ch <- cs.relocating
+	ch <- cs.initializing
+	ch <- cs.indexSkew
+	ch <- cs.imbalance
+	ch <- cs.up.Desc()
+	ch <- cs.totalScrapes.Desc()
+	ch <- cs.jsonParseFailures.Desc()
+}
+
+func (cs *CatShards) fetchAndDecodeCatShards() (catShardsResponse, error) {
+	var csr catShardsResponse
+
+	u := *cs.url
+	u.Path = path.Join(u.Path, "/_cat/shards")
+	u.RawQuery = "format=json&bytes=b"
+
+	res, err := cs.client.Get(u.String())
+	if err != nil {
+		return csr, fmt.Errorf("failed to get cat shards from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(cs.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return csr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&csr); err != nil {
+		cs.jsonParseFailures.Inc()
+		return csr, err
+	}
+	return csr, nil
+}
+
+// Collect gets Cat Shards metric values
+func (cs *CatShards) Collect(ch chan<- prometheus.Metric) {
+	cs.totalScrapes.Inc()
+	defer func() {
+		ch <- cs.up
+		ch <- cs.totalScrapes
+		ch <- cs.jsonParseFailures
+	}()
+
+	csr, err := cs.fetchAndDecodeCatShards()
+	if err != nil {
+		cs.up.Set(0)
+		_ = level.Warn(cs.logger).Log(
+			"msg", "failed to fetch and decode cat shards",
+			"err", err,
+		)
+		return
+	}
+	cs.up.Set(1)
+
+	states := make(map[catShardKey]map[string]int64)
+	relocating := make(map[catShardRelocationKey]int64)
+	initializing := make(map[string]int64)
+	for _, shard := range csr {
+		key := shard.key()
+		if _, ok := states[key]; !ok {
+			states[key] = make(map[string]int64, len(catShardStates))
+		}
+		states[key][shard.State]++
+		switch shard.State {
+		case "RELOCATING":
+			relocating[catShardRelocationKey{source: key.node, target: shard.relocationTarget()}]++
+		case "INITIALIZING":
+			initializing[key.node]++
+		}
+
+		// unassigned replicas of the same shard share their labels and have no data
+		if shard.State == "UNASSIGNED" {
+			continue
+		}
+		if docs, err := strconv.ParseFloat(shard.Docs, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(
+				cs.docs,
+				prometheus.GaugeValue,
+				docs,
+				key.index, key.shard, key.prirep, key.node,
+			)
+		}
+		if store, err := strconv.ParseFloat(shard.Store, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(
+				cs.storeSize,
+				prometheus.GaugeValue,
+				store,
+				key.index, key.shard, key.prirep, key.node,
+			)
+		}
+	}
+
+	for key, counts := range states {
+		for _, state := range catShardStates {
+			ch <- prometheus.MustNewConstMetric(
+				cs.state,
+				prometheus.GaugeValue,
+				float64(counts[state]),
+				key.index, key.shard, key.prirep, key.node, state,
+			)
+		}
+	}
+
+	for key, count := range relocating {
+		ch <- prometheus.MustNewConstMetric(
+			cs.relocating,
+			prometheus.GaugeValue,
+			float64(count),
+			key.source, key.target,
+		)
+	}
+	for node, count := range initializing {
+		ch <- prometheus.MustNewConstMetric(
+			cs.initializing,
+			prometheus.GaugeValue,
+			float64(count),
+			node,
+		)
+	}
+
+	indexSkew, imbalance := shardSkew(csr)
+	for index, skew := range indexSkew {
+		ch <- prometheus.MustNewConstMetric(
+			cs.indexSkew,
+			prometheus.GaugeValue,
+			skew,
+			index,
+		)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		cs.imbalance,
+		prometheus.GaugeValue,
+		imbalance,
+	)
+}
@@ -0,0 +1,16 @@
+package collector
+
+// catShardsResponse is a representation of the Elasticsearch _cat/shards endpoint
+type catShardsResponse []catShardResponse
+
+// catShardResponse defines a single shard copy of the _cat/shards endpoint
+type catShardResponse struct {
+	Index  string `json:"index"`
+	Shard  string `json:"shard"`
+	Prirep string `json:"prirep"`
+	State  string `json:"state"`
+	Docs   string `json:"docs"`
+	Store  string `json:"store"`
+	IP     string `json:"ip"`
+	Node   string `json:"node"`
+}
@@ -0,0 +1,84 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestCatShards(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/twitter -d '{"settings":{"number_of_shards":2,"number_of_replicas":2}}'
+	//  curl http://localhost:9200/_cat/shards?format=json&bytes=b
+	tcs := map[string]string{
+		"7.10.0": `[{"index":"twitter","shard":"0","prirep":"p","state":"STARTED","docs":"12","store":"10240","ip":"172.17.0.2","node":"es01"},{"index":"twitter","shard":"0","prirep":"r","state":"UNASSIGNED","docs":null,"store":null,"ip":null,"node":null},{"index":"twitter","shard":"0","prirep":"r","state":"UNASSIGNED","docs":null,"store":null,"ip":null,"node":null},{"index":"twitter","shard":"1","prirep":"p","state":"RELOCATING","docs":"7","store":"8192","ip":"172.17.0.2","node":"es01 -> 172.17.0.3 Bu9Dc0qkQVmPSbRx9B0KjQ es02"}]`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		cs := NewCatShards(log.NewNopLogger(), http.DefaultClient, u)
+		csr, err := cs.fetchAndDecodeCatShards()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode cat shards: %s", err)
+		}
+		t.Logf("[%s] Cat Shards Response: %+v", ver, csr)
+		if len(csr) != 4 {
+			t.Fatalf("Wrong number of shards")
+		}
+		if csr[0].State != "STARTED" || csr[0].Docs != "12" || csr[0].Store != "10240" {
+			t.Errorf("Wrong started shard")
+		}
+		if csr[1].State != "UNASSIGNED" || csr[1].key() != csr[2].key() {
+			t.Errorf("Unassigned replicas should share their labels")
+		}
+		if csr[3].key().node != "es01" {
+			t.Errorf("Relocating shard should be reported on its source node")
+		}
+		if target := csr[3].relocationTarget(); target != "es02" {
+			t.Errorf("Wrong relocation target %s", target)
+		}
+		if target := csr[0].relocationTarget(); target != "" {
+			t.Errorf("Started shard should not have a relocation target, got %s", target)
+		}
+	}
+}
+
+func TestShardSkew(t *testing.T) {
+	csr := catShardsResponse{
+		{Index: "twitter", Shard: "0", Prirep: "p", State: "STARTED", Node: "es01"},
+		{Index: "twitter", Shard: "1", Prirep: "p", State: "STARTED", Node: "es01"},
+		{Index: "twitter", Shard: "2", Prirep: "p", State: "RELOCATING", Node: "es01 -> 172.17.0.3 kW0o1ULGRgOoPa8RyrOcZA es02"},
+		{Index: "twitter", Shard: "0", Prirep: "r", State: "UNASSIGNED"},
+		{Index: "logs", Shard: "0", Prirep: "p", State: "STARTED", Node: "es02"},
+		{Index: "logs", Shard: "0", Prirep: "r", State: "STARTED", Node: "es03"},
+		{Index: "logs", Shard: "1", Prirep: "p", State: "STARTED", Node: "es01"},
+	}
+	indexSkew, imbalance := shardSkew(csr)
+	t.Logf("Shard Skew: %+v %f", indexSkew, imbalance)
+	// twitter has 3 copies on es01 and a mean of 1 copy per node
+	if indexSkew["twitter"] != 2 {
+		t.Errorf("Wrong skew of index twitter %f", indexSkew["twitter"])
+	}
+	if indexSkew["logs"] != 0 {
+		t.Errorf("Wrong skew of index logs %f", indexSkew["logs"])
+	}
+	// es01 holds 4 of 6 copies
+	if imbalance != 2 {
+		t.Errorf("Wrong shard imbalance %f", imbalance)
+	}
+	if _, imbalance := shardSkew(nil); imbalance != 0 {
+		t.Errorf("Cluster without shards should not be imbalanced")
+	}
+}
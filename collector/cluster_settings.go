@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"path"
 	"strconv"
+	"strings"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
@@ -24,6 +25,63 @@ type ClusterSettings struct {
 	shardAllocationEnabled          prometheus.Gauge
 	maxShardsPerNode                prometheus.Gauge
 	totalScrapes, jsonParseFailures prometheus.Counter
+
+	watermarkRatio *prometheus.Desc
+	watermarkBytes *prometheus.Desc
+
+	allocationEnable    *prometheus.Desc
+	awarenessAttributes *prometheus.Desc
+	allocationFilters   *prometheus.Desc
+
+	shardLimit         *prometheus.Desc
+	shardLimitHeadroom *prometheus.Desc
+}
+
+var shardAllocationModes = []string{"all", "primaries", "new_primaries", "none"}
+
+// byteSizeUnits are the units of Elasticsearch byte size values, the single letter units are
+// checked after the two letter units ending with them
+var byteSizeUnits = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"pb", 1 << 50},
+	{"p", 1 << 50},
+	{"tb", 1 << 40},
+	{"t", 1 << 40},
+	{"gb", 1 << 30},
+	{"g", 1 << 30},
+	{"mb", 1 << 20},
+	{"m", 1 << 20},
+	{"kb", 1 << 10},
+	{"k", 1 << 10},
+	{"b", 1},
+}
+
+// parseWatermark parses a disk watermark setting. Watermarks are either a used disk
+// ratio (e.g. "85%" or "0.85") or an absolute amount of free disk space (e.g. "500mb")
+func parseWatermark(watermark string) (value float64, isRatio bool, err error) {
+	w := strings.ToLower(strings.TrimSpace(watermark))
+	if strings.HasSuffix(w, "%") {
+		value, err = strconv.ParseFloat(strings.TrimSuffix(w, "%"), 64)
+		return value / 100, true, err
+	}
+	if value, err = strconv.ParseFloat(w, 64); err == nil {
+		return value, true, nil
+	}
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(w, unit.suffix) {
+			value, err = strconv.ParseFloat(strings.TrimSuffix(w, unit.suffix), 64)
+			return value * unit.multiplier, false, err
+		}
+	}
+	return 0, false, fmt.Errorf("invalid watermark %q", watermark)
+}
+
+func init() {
+	registerCollector("cluster_settings", defaultDisabled, "Export stats for cluster settings.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewClusterSettings(logger, client, url)
+	})
 }
 
 // NewClusterSettings defines Cluster Settings Prometheus metrics
@@ -53,9 +111,52 @@ func NewClusterSettings(logger log.Logger, client *http.Client, url *url.URL) *C
 			Name: prometheus.BuildFQName(namespace, "clustersettings_stats", "json_parse_failures"),
 			Help: "Number of errors while parsing JSON.",
 		}),
+		watermarkRatio: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "clustersettings_stats", "disk_watermark_ratio"),
+			"Disk watermark setting as ratio of used disk space, if configured as percentage or ratio",
+			[]string{"watermark"}, nil,
+		),
+		watermarkBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "clustersettings_stats", "disk_watermark_free_bytes"),
+			"Disk watermark setting as free disk space in bytes, if configured as byte size",
+			[]string{"watermark"}, nil,
+		),
+		allocationEnable: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "clustersettings_stats", "shard_allocation_enable"),
+			"Whether the mode is the current cluster wide shard routing allocation mode",
+			[]string{"mode"}, nil,
+		),
+		awarenessAttributes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "clustersettings_stats", "allocation_awareness_attribute"),
+			"Node attribute used for shard allocation awareness",
+			[]string{"attribute"}, nil,
+		),
+		allocationFilters: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "clustersettings_stats", "allocation_filter"),
+			"Cluster level shard allocation filter (include, exclude or require) on a node attribute",
+			[]string{"filter", "attribute", "value"}, nil,
+		),
+		shardLimit: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "clustersettings_stats", "shard_limit"),
+			"Maximum number of shards in the cluster, cluster.max_shards_per_node times the number of data nodes",
+			nil, nil,
+		),
+		shardLimitHeadroom: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "clustersettings_stats", "shard_limit_headroom"),
+			"Number of shards which can be created before the shard limit of the cluster is reached",
+			nil, nil,
+		),
 	}
 }
 
+// shardLimit returns the shard limit of the cluster and the number of shards counted against it,
+// which are all copies of the shards including unassigned replicas
+func shardLimit(maxShardsPerNode int64, health clusterHealthResponse) (limit, shards float64) {
+	limit = float64(maxShardsPerNode) * float64(health.NumberOfDataNodes)
+	shards = float64(health.ActiveShards + health.InitializingShards + health.UnassignedShards)
+	return limit, shards
+}
+
 // Describe add Snapshots metrics descriptions
 func (cs *ClusterSettings) Describe(ch chan<- *prometheus.Desc) {
 	ch <- cs.up.Desc()
@@ -63,6 +164,13 @@ func (cs *ClusterSettings) Describe(ch chan<- *prometheus.Desc) {
 	ch <- cs.shardAllocationEnabled.Desc()
 	ch <- cs.maxShardsPerNode.Desc()
 	ch <- cs.jsonParseFailures.Desc()
+	ch <- cs.watermarkRatio
+	ch <- cs.watermarkBytes
+	ch <- cs.allocationEnable
+	ch <- cs.awarenessAttributes
+	ch <- cs.allocationFilters
+	ch <- cs.shardLimit
+	ch <- cs.shardLimitHeadroom
 }
 
 func (cs *ClusterSettings) getAndParseURL(u *url.URL, data interface{}) error {
@@ -120,6 +228,15 @@ func (cs *ClusterSettings) fetchAndDecodeClusterSettingsStats() (ClusterSettings
 	return csr, err
 }
 
+func (cs *ClusterSettings) fetchAndDecodeClusterHealth() (clusterHealthResponse, error) {
+	var chr clusterHealthResponse
+
+	u := *cs.url
+	u.Path = path.Join(u.Path, "/_cluster/health")
+	err := cs.getAndParseURL(&u, &chr)
+	return chr, err
+}
+
 // Collect gets cluster settings  metric values
 func (cs *ClusterSettings) Collect(ch chan<- prometheus.Metric) {
 
@@ -153,8 +270,73 @@ func (cs *ClusterSettings) Collect(ch chan<- prometheus.Metric) {
 
 	cs.shardAllocationEnabled.Set(float64(shardAllocationMap[csr.Cluster.Routing.Allocation.Enabled]))
 
+	for _, mode := range shardAllocationModes {
+		var value float64
+		if strings.ToLower(csr.Cluster.Routing.Allocation.Enabled) == mode {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(cs.allocationEnable, prometheus.GaugeValue, value, mode)
+	}
+
+	for _, attribute := range csr.Cluster.Routing.Allocation.Awareness.Attributes {
+		ch <- prometheus.MustNewConstMetric(cs.awarenessAttributes, prometheus.GaugeValue, 1, attribute)
+	}
+
+	filters := map[string]map[string]string{
+		"include": csr.Cluster.Routing.Allocation.Include,
+		"exclude": csr.Cluster.Routing.Allocation.Exclude,
+		"require": csr.Cluster.Routing.Allocation.Require,
+	}
+	for filter, attributes := range filters {
+		for attribute, value := range attributes {
+			// removed filters are reset to an empty value
+			if value == "" {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(cs.allocationFilters, prometheus.GaugeValue, 1, filter, attribute, value)
+		}
+	}
+
 	maxShardsPerNode, err := strconv.ParseInt(csr.Cluster.MaxShardsPerNode, 10, 64)
 	if err == nil {
 		cs.maxShardsPerNode.Set(float64(maxShardsPerNode))
+
+		health, err := cs.fetchAndDecodeClusterHealth()
+		if err != nil {
+			_ = level.Warn(cs.logger).Log(
+				"msg", "failed to fetch and decode cluster health for the shard limit",
+				"err", err,
+			)
+		} else {
+			limit, shards := shardLimit(maxShardsPerNode, health)
+			ch <- prometheus.MustNewConstMetric(cs.shardLimit, prometheus.GaugeValue, limit)
+			ch <- prometheus.MustNewConstMetric(cs.shardLimitHeadroom, prometheus.GaugeValue, limit-shards)
+		}
+	}
+
+	watermarks := map[string]string{
+		"low":         csr.Cluster.Routing.Allocation.Disk.Watermark.Low,
+		"high":        csr.Cluster.Routing.Allocation.Disk.Watermark.High,
+		"flood_stage": csr.Cluster.Routing.Allocation.Disk.Watermark.FloodStage,
+	}
+	for name, watermark := range watermarks {
+		// flood_stage does not exist before 6.0
+		if watermark == "" {
+			continue
+		}
+		value, isRatio, err := parseWatermark(watermark)
+		if err != nil {
+			_ = level.Warn(cs.logger).Log(
+				"msg", "failed to parse disk watermark",
+				"watermark", name,
+				"err", err,
+			)
+			continue
+		}
+		desc := cs.watermarkBytes
+		if isRatio {
+			desc = cs.watermarkRatio
+		}
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, name)
 	}
 }
@@ -1,5 +1,10 @@
 package collector
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 // ClusterSettingsFullResponse is a representation of a Elasticsearch Cluster Settings
 type ClusterSettingsFullResponse struct {
 	Defaults   ClusterSettingsResponse `json:"defaults"`
@@ -25,5 +30,52 @@ type Routing struct {
 
 // Allocation is a representation of a Elasticsearch Cluster shard routing allocation settings
 type Allocation struct {
-	Enabled string `json:"enable"`
+	Enabled   string            `json:"enable"`
+	Disk      Disk              `json:"disk"`
+	Awareness Awareness         `json:"awareness"`
+	Exclude   map[string]string `json:"exclude"`
+	Include   map[string]string `json:"include"`
+	Require   map[string]string `json:"require"`
+}
+
+// Awareness is a representation of a Elasticsearch Cluster shard allocation awareness settings
+type Awareness struct {
+	Attributes SettingsList `json:"attributes"`
+}
+
+// SettingsList is a list setting, which is rendered as a comma separated string if it has been set
+// and as a JSON array in the defaults of newer releases
+type SettingsList []string
+
+// UnmarshalJSON implements json.Unmarshaler for SettingsList
+func (l *SettingsList) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*l = list
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*l = nil
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// Disk is a representation of a Elasticsearch Cluster disk based shard allocation settings
+type Disk struct {
+	ThresholdEnabled string    `json:"threshold_enabled"`
+	Watermark        Watermark `json:"watermark"`
+}
+
+// Watermark is a representation of a Elasticsearch Cluster disk watermark settings
+type Watermark struct {
+	Low        string `json:"low"`
+	High       string `json:"high"`
+	FloodStage string `json:"flood_stage"`
 }
@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -78,3 +79,134 @@ func TestClusterMaxShardsPerNode(t *testing.T) {
 		}
 	}
 }
+
+func TestClusterSettingsDiskWatermarks(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION-alpine
+	//  curl -XPUT http://localhost:9200/_cluster/settings -d '{"persistent":{"cluster.routing.allocation.disk.watermark.low":"0.8","cluster.routing.allocation.disk.watermark.high":"50gb","cluster.routing.allocation.disk.watermark.flood_stage":"10GB"},"transient":{"cluster.routing.allocation.disk.watermark.low":"75%"}}'
+	//  curl http://localhost:9200/_cluster/settings/?include_defaults=true
+	tcs := map[string]Watermark{
+		"../fixtures/settings-7.3.0.json":            {Low: "85%", High: "90%", FloodStage: "95%"},
+		"../fixtures/settings-watermark-7.10.0.json": {Low: "75%", High: "50gb", FloodStage: "10GB"},
+	}
+	for filename, expected := range tcs {
+		f, _ := os.Open(filename)
+		defer f.Close()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(w, f)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewClusterSettings(log.NewNopLogger(), http.DefaultClient, u)
+		nsr, err := c.fetchAndDecodeClusterSettingsStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode cluster settings stats: %s", err)
+		}
+		t.Logf("[%s] Cluster Settings Stats Response: %+v", filename, nsr)
+		if nsr.Cluster.Routing.Allocation.Disk.Watermark != expected {
+			t.Errorf("Wrong disk watermarks %+v", nsr.Cluster.Routing.Allocation.Disk.Watermark)
+		}
+	}
+}
+
+func TestParseWatermark(t *testing.T) {
+	tcs := []struct {
+		watermark string
+		value     float64
+		isRatio   bool
+	}{
+		{"85%", 0.85, true},
+		{"0.9", 0.9, true},
+		{"500mb", 500 * 1024 * 1024, false},
+		{"10GB", 10 * 1024 * 1024 * 1024, false},
+		{"1024b", 1024, false},
+		{"500m", 500 * 1024 * 1024, false},
+		{"2k", 2 * 1024, false},
+		{"10G", 10 * 1024 * 1024 * 1024, false},
+		{"1t", 1024 * 1024 * 1024 * 1024, false},
+		{"1p", 1024 * 1024 * 1024 * 1024 * 1024, false},
+	}
+	for _, tc := range tcs {
+		value, isRatio, err := parseWatermark(tc.watermark)
+		if err != nil {
+			t.Fatalf("Failed to parse watermark %s: %s", tc.watermark, err)
+		}
+		if value != tc.value || isRatio != tc.isRatio {
+			t.Errorf("Wrong value for watermark %s: %f (ratio %t)", tc.watermark, value, isRatio)
+		}
+	}
+	if _, _, err := parseWatermark("lots"); err == nil {
+		t.Errorf("Invalid watermark should fail to parse")
+	}
+}
+
+func TestClusterSettingsShardLimit(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_cluster/health
+	out := `{"cluster_name":"elasticsearch","status":"yellow","timed_out":false,"number_of_nodes":4,"number_of_data_nodes":3,"active_primary_shards":1200,"active_shards":2390,"relocating_shards":2,"initializing_shards":4,"unassigned_shards":6,"delayed_unassigned_shards":0,"number_of_pending_tasks":0,"number_of_in_flight_fetch":0,"task_max_waiting_in_queue_millis":0,"active_shards_percent_as_number":99.58}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, out)
+	}))
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	c := NewClusterSettings(log.NewNopLogger(), http.DefaultClient, u)
+	health, err := c.fetchAndDecodeClusterHealth()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode cluster health: %s", err)
+	}
+	limit, shards := shardLimit(1000, health)
+	if limit != 3000 || shards != 2400 {
+		t.Errorf("Wrong shard limit %f or number of shards %f", limit, shards)
+	}
+}
+
+func TestClusterSettingsAllocationFiltering(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION-alpine
+	//  curl -XPUT http://localhost:9200/_cluster/settings -d '{"persistent":{"cluster.routing.allocation.awareness.attributes":"zone,rack","cluster.routing.allocation.exclude._name":"es03","cluster.routing.allocation.exclude._ip":""},"transient":{"cluster.routing.allocation.enable":"primaries","cluster.routing.allocation.require.zone":"zone-a"}}'
+	//  curl http://localhost:9200/_cluster/settings/?include_defaults=true
+	files := []string{"../fixtures/settings-5.4.2.json", "../fixtures/settings-7.3.0.json", "../fixtures/settings-allocation-filtering-7.10.0.json"}
+	for _, filename := range files {
+		f, _ := os.Open(filename)
+		defer f.Close()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(w, f)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewClusterSettings(log.NewNopLogger(), http.DefaultClient, u)
+		nsr, err := c.fetchAndDecodeClusterSettingsStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode cluster settings stats: %s", err)
+		}
+		t.Logf("[%s] Cluster Settings Stats Response: %+v", filename, nsr)
+		allocation := nsr.Cluster.Routing.Allocation
+		if filename != "../fixtures/settings-allocation-filtering-7.10.0.json" {
+			if len(allocation.Awareness.Attributes) != 0 || len(allocation.Exclude) != 0 {
+				t.Errorf("There should be no allocation awareness or filtering")
+			}
+			continue
+		}
+		if allocation.Enabled != "primaries" {
+			t.Errorf("Wrong setting for cluster routing allocation enabled")
+		}
+		if len(allocation.Awareness.Attributes) != 2 || allocation.Awareness.Attributes[0] != "zone" || allocation.Awareness.Attributes[1] != "rack" {
+			t.Errorf("Wrong allocation awareness attributes %v", allocation.Awareness.Attributes)
+		}
+		if allocation.Exclude["_name"] != "es03" || allocation.Require["zone"] != "zone-a" {
+			t.Errorf("Wrong allocation filters")
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// clusterState combines the cluster state metadata, the mapping stats of the cluster and the roles of the local node
+type clusterState struct {
+	State     clusterStateResponse
+	Stats     clusterStatsResponse
+	LocalNode nodesInfoResponse
+}
+
+// masterEligible returns whether the node queried by the exporter has the master role
+func (state clusterState) masterEligible() bool {
+	for _, node := range state.LocalNode.Nodes {
+		for _, role := range node.Roles {
+			if role == "master" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type clusterStateMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(state clusterState) float64
+}
+
+type clusterStateFieldTypeMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(fieldType clusterStatsFieldTypeResponse) float64
+}
+
+// ClusterState information struct
+type ClusterState struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	metrics                 []*clusterStateMetric
+	fieldTypeMetrics        []*clusterStateFieldTypeMetric
+	runtimeFieldTypeMetrics []*clusterStateFieldTypeMetric
+}
+
+func init() {
+	registerCollector("cluster_state", defaultDisabled, "Export stats for the cluster state.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewClusterState(logger, client, url)
+	})
+}
+
+// NewClusterState defines ClusterState Prometheus metrics
+func NewClusterState(logger log.Logger, client *http.Client, url *url.URL) *ClusterState {
+	subsystem := "cluster_state"
+
+	return &ClusterState{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch cluster state endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch cluster state scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		metrics: []*clusterStateMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "version"),
+					"Version of the cluster state, incremented with every published cluster state",
+					nil, nil,
+				),
+				Value: func(state clusterState) float64 {
+					return float64(state.State.Version)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "indices"),
+					"Number of indices in the cluster state metadata",
+					nil, nil,
+				),
+				Value: func(state clusterState) float64 {
+					return float64(len(state.State.Metadata.Indices))
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "mapping_fields"),
+					"Number of fields in the mappings of all indices",
+					nil, nil,
+				),
+				Value: func(state clusterState) float64 {
+					return float64(state.Stats.Indices.Mappings.TotalFieldCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deduplicated_mapping_fields"),
+					"Number of fields in the distinct mappings of the cluster state",
+					nil, nil,
+				),
+				Value: func(state clusterState) float64 {
+					return float64(state.Stats.Indices.Mappings.TotalDeduplicatedFieldCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deduplicated_mappings"),
+					"Number of distinct mappings in the cluster state",
+					nil, nil,
+				),
+				Value: func(state clusterState) float64 {
+					return float64(state.Stats.Indices.Mappings.TotalDeduplicatedMappingCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deduplicated_mapping_size_bytes"),
+					"Size of the distinct mappings in the cluster state in bytes",
+					nil, nil,
+				),
+				Value: func(state clusterState) float64 {
+					return float64(state.Stats.Indices.Mappings.TotalDeduplicatedMappingSizeInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "voting_config_nodes"),
+					"Number of master-eligible nodes in the last committed voting configuration, a majority of them is required for a quorum",
+					nil, nil,
+				),
+				Value: func(state clusterState) float64 {
+					return float64(len(state.State.Metadata.ClusterCoordination.LastCommittedConfig))
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "voting_config_exclusions"),
+					"Number of nodes excluded from the voting configuration",
+					nil, nil,
+				),
+				Value: func(state clusterState) float64 {
+					return float64(len(state.State.Metadata.ClusterCoordination.VotingConfigExclusions))
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "local_node_master_eligible"),
+					"Whether the node queried by the exporter is master-eligible",
+					nil, nil,
+				),
+				Value: func(state clusterState) float64 {
+					return bool2Float(state.masterEligible())
+				},
+			},
+		},
+		fieldTypeMetrics: []*clusterStateFieldTypeMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "mapping_field_type_fields"),
+					"Number of mapped fields of the type in the mappings of all indices",
+					[]string{"type"}, nil,
+				),
+				Value: func(fieldType clusterStatsFieldTypeResponse) float64 {
+					return float64(fieldType.Count)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "mapping_field_type_indices"),
+					"Number of indices with mapped fields of the type in their mappings",
+					[]string{"type"}, nil,
+				),
+				Value: func(fieldType clusterStatsFieldTypeResponse) float64 {
+					return float64(fieldType.IndexCount)
+				},
+			},
+		},
+		runtimeFieldTypeMetrics: []*clusterStateFieldTypeMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "mapping_runtime_field_type_fields"),
+					"Number of runtime fields of the type in the mappings of all indices",
+					[]string{"type"}, nil,
+				),
+				Value: func(fieldType clusterStatsFieldTypeResponse) float64 {
+					return float64(fieldType.Count)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "mapping_runtime_field_type_indices"),
+					"Number of indices with runtime fields of the type in their mappings",
+					[]string{"type"}, nil,
+				),
+				Value: func(fieldType clusterStatsFieldTypeResponse) float64 {
+					return float64(fieldType.IndexCount)
+				},
+			},
+		},
+	}
+}
+
+// Describe add ClusterState metrics descriptions
+func (c *ClusterState) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range c.metrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range c.fieldTypeMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range c.runtimeFieldTypeMetrics {
+		ch <- metric.Desc
+	}
+	ch <- c.up.Desc()
+	ch <- c.totalScrapes.Desc()
+	ch <- c.jsonParseFailures.Desc()
+}
+
+func (c *ClusterState) getAndParseURL(u *url.URL, data interface{}) error {
+	res, err := c.client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(c.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		c.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+func (c *ClusterState) fetchAndDecodeClusterState() (clusterState, error) {
+	var state clusterState
+
+	// only the state of each index is requested to keep the response small for large cluster states
+	u := *c.url
+	u.Path = path.Join(u.Path, "/_cluster/state/version,metadata")
+	u.RawQuery = "filter_path=cluster_name,cluster_uuid,version,state_uuid,metadata.indices.*.state,metadata.cluster_coordination"
+	if err := c.getAndParseURL(&u, &state.State); err != nil {
+		return state, err
+	}
+
+	u = *c.url
+	u.Path = path.Join(u.Path, "/_cluster/stats")
+	u.RawQuery = "filter_path=indices.mappings.total_*,indices.mappings.field_types,indices.mappings.runtime_field_types"
+	if err := c.getAndParseURL(&u, &state.Stats); err != nil {
+		return state, err
+	}
+
+	u = *c.url
+	u.Path = path.Join(u.Path, "/_nodes/_local")
+	u.RawQuery = "filter_path=nodes.*.roles"
+	if err := c.getAndParseURL(&u, &state.LocalNode); err != nil {
+		return state, err
+	}
+
+	return state, nil
+}
+
+// Collect gets ClusterState metric values
+func (c *ClusterState) Collect(ch chan<- prometheus.Metric) {
+	c.totalScrapes.Inc()
+	defer func() {
+		ch <- c.up
+		ch <- c.totalScrapes
+		ch <- c.jsonParseFailures
+	}()
+
+	state, err := c.fetchAndDecodeClusterState()
+	if err != nil {
+		c.up.Set(0)
+		_ = level.Warn(c.logger).Log(
+			"msg", "failed to fetch and decode cluster state",
+			"err", err,
+		)
+		return
+	}
+	c.up.Set(1)
+
+	for _, metric := range c.metrics {
+		ch <- prometheus.MustNewConstMetric(
+			metric.Desc,
+			metric.Type,
+			metric.Value(state),
+		)
+	}
+	for _, fieldType := range state.Stats.Indices.Mappings.FieldTypes {
+		for _, metric := range c.fieldTypeMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(fieldType),
+				fieldType.Name,
+			)
+		}
+	}
+	for _, fieldType := range state.Stats.Indices.Mappings.RuntimeFieldTypes {
+		for _, metric := range c.runtimeFieldTypeMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(fieldType),
+				fieldType.Name,
+			)
+		}
+	}
+}
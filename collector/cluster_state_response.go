@@ -0,0 +1,24 @@
+package collector
+
+// clusterStateResponse is a representation of the Elasticsearch _cluster/state endpoint
+type clusterStateResponse struct {
+	ClusterName string `json:"cluster_name"`
+	ClusterUUID string `json:"cluster_uuid"`
+	Version     int64  `json:"version"`
+	StateUUID   string `json:"state_uuid"`
+	Metadata    struct {
+		Indices map[string]struct {
+			State  string `json:"state"`
+			System bool   `json:"system"`
+		} `json:"indices"`
+		ClusterCoordination struct {
+			Term                   int64    `json:"term"`
+			LastCommittedConfig    []string `json:"last_committed_config"`
+			LastAcceptedConfig     []string `json:"last_accepted_config"`
+			VotingConfigExclusions []struct {
+				NodeID   string `json:"node_id"`
+				NodeName string `json:"node_name"`
+			} `json:"voting_config_exclusions"`
+		} `json:"cluster_coordination"`
+	} `json:"metadata"`
+}
@@ -0,0 +1,78 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestClusterState(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1
+	//  curl -XPUT http://localhost:9200/foo_2 -H "Content-Type: application/json" -d '{"mappings":{"properties":{"title":{"type":"keyword"}}}}'
+	//  curl -XPOST http://localhost:9200/foo_2/_close
+	//  curl -XPOST http://localhost:9200/_cluster/voting_config_exclusions?node_names=es03
+	//  curl http://localhost:9200/_cluster/state/version,metadata?filter_path=cluster_name,cluster_uuid,version,state_uuid,metadata.indices.*.state,metadata.cluster_coordination
+	//  curl -XPUT http://localhost:9200/foo_1/_mapping -H "Content-Type: application/json" -d '{"runtime":{"day":{"type":"keyword","script":"emit(doc[\'@timestamp\'].value.dayOfWeekEnum.toString())"}}}'
+	//  curl http://localhost:9200/_cluster/stats?filter_path=indices.mappings.total_*,indices.mappings.field_types,indices.mappings.runtime_field_types
+	//  curl http://localhost:9200/_nodes/_local?filter_path=nodes.*.roles
+	tcs := map[string][]string{
+		"8.5.0": {
+			`{"cluster_name":"docker-cluster","cluster_uuid":"00Dl3H5mTa2glx1dLEuJxg","version":1321,"state_uuid":"Q9DUd6CvSBCEbQ3RGVFX3Q","metadata":{"cluster_coordination":{"term":4,"last_committed_config":["Bu9Dc0qkQVmPSbRx9B0KjQ","kW0o1ULGRgOoPa8RyrOcZA","{bootstrap-placeholder}-es03"],"last_accepted_config":["Bu9Dc0qkQVmPSbRx9B0KjQ","kW0o1ULGRgOoPa8RyrOcZA","{bootstrap-placeholder}-es03"],"voting_config_exclusions":[{"node_id":"YzZ1jFb1SIeyMfyc7QUfRw","node_name":"es03"}]},"indices":{"foo_1":{"state":"open"},"foo_2":{"state":"close"}}}}`,
+			`{"indices":{"mappings":{"total_field_count":12,"total_deduplicated_field_count":9,"total_deduplicated_mapping_count":2,"total_deduplicated_mapping_size_in_bytes":1536,"field_types":[{"name":"date","count":1,"index_count":1,"script_count":0},{"name":"keyword","count":6,"index_count":2,"script_count":0},{"name":"text","count":5,"index_count":2,"script_count":0}],"runtime_field_types":[{"name":"keyword","count":1,"index_count":1,"scriptless_count":0,"shadowed_count":0,"lang":["painless"],"lines_max":1,"lines_total":1,"chars_max":52,"chars_total":52,"source_max":1,"source_total":1,"doc_max":1,"doc_total":1}]}}}`,
+			`{"nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"roles":["data","ingest","master","ml","remote_cluster_client","transform"]}}}`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/_cluster/state/version,metadata":
+				fmt.Fprint(w, out[0])
+			case "/_cluster/stats":
+				fmt.Fprint(w, out[1])
+			case "/_nodes/_local":
+				fmt.Fprint(w, out[2])
+			default:
+				http.Error(w, "unexpected path", http.StatusNotFound)
+			}
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewClusterState(log.NewNopLogger(), http.DefaultClient, u)
+		state, err := c.fetchAndDecodeClusterState()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode cluster state: %s", err)
+		}
+		t.Logf("[%s] Cluster State: %+v", ver, state)
+		if state.State.Version != 1321 || state.State.StateUUID != "Q9DUd6CvSBCEbQ3RGVFX3Q" {
+			t.Errorf("Wrong cluster state version")
+		}
+		if len(state.State.Metadata.Indices) != 2 || state.State.Metadata.Indices["foo_2"].State != "close" {
+			t.Errorf("Wrong cluster state indices")
+		}
+		if m := state.Stats.Indices.Mappings; m.TotalFieldCount != 12 || m.TotalDeduplicatedMappingCount != 2 || m.TotalDeduplicatedMappingSizeInBytes != 1536 {
+			t.Errorf("Wrong mapping stats %+v", m)
+		}
+		if ft := state.Stats.Indices.Mappings.FieldTypes; len(ft) != 3 || ft[1].Name != "keyword" || ft[1].Count != 6 || ft[1].IndexCount != 2 {
+			t.Errorf("Wrong mapped field types %+v", ft)
+		}
+		if rft := state.Stats.Indices.Mappings.RuntimeFieldTypes; len(rft) != 1 || rft[0].Name != "keyword" || rft[0].Count != 1 {
+			t.Errorf("Wrong runtime field types %+v", rft)
+		}
+		if cc := state.State.Metadata.ClusterCoordination; len(cc.LastCommittedConfig) != 3 || len(cc.VotingConfigExclusions) != 1 {
+			t.Errorf("Wrong voting configuration %+v", cc)
+		}
+		if !state.masterEligible() {
+			t.Errorf("Local node should be master-eligible")
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package collector
+
+// clusterStatsResponse is a representation of the Elasticsearch _cluster/stats endpoint
+type clusterStatsResponse struct {
+	ClusterName string                      `json:"cluster_name"`
+	Indices     clusterStatsIndicesResponse `json:"indices"`
+}
+
+// clusterStatsIndicesResponse defines the index usage of the cluster
+type clusterStatsIndicesResponse struct {
+	Mappings struct {
+		TotalFieldCount                     int64                           `json:"total_field_count"`
+		TotalDeduplicatedFieldCount         int64                           `json:"total_deduplicated_field_count"`
+		TotalDeduplicatedMappingCount       int64                           `json:"total_deduplicated_mapping_count"`
+		TotalDeduplicatedMappingSizeInBytes int64                           `json:"total_deduplicated_mapping_size_in_bytes"`
+		FieldTypes                          []clusterStatsFieldTypeResponse `json:"field_types"`
+		// runtime field types only share the name, count and index count with mapped field types
+		RuntimeFieldTypes []clusterStatsFieldTypeResponse `json:"runtime_field_types"`
+	} `json:"mappings"`
+	Search clusterStatsSearchResponse `json:"search"`
+}
+
+// clusterStatsFieldTypeResponse defines the usage of a single field type in the mappings of the cluster
+type clusterStatsFieldTypeResponse struct {
+	Name                 string           `json:"name"`
+	Count                int64            `json:"count"`
+	IndexCount           int64            `json:"index_count"`
+	IndexedVectorCount   int64            `json:"indexed_vector_count"`
+	IndexedVectorDimMin  int64            `json:"indexed_vector_dim_min"`
+	IndexedVectorDimMax  int64            `json:"indexed_vector_dim_max"`
+	VectorIndexTypeCount map[string]int64 `json:"vector_index_type_count"`
+}
+
+// clusterStatsSearchResponse defines the search usage of the cluster by query type and request section
+type clusterStatsSearchResponse struct {
+	Total    int64            `json:"total"`
+	Queries  map[string]int64 `json:"queries"`
+	Sections map[string]int64 `json:"sections"`
+}
@@ -0,0 +1,110 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	defaultEnabled  = true
+	defaultDisabled = false
+)
+
+// Factory creates a collector for the cluster behind url
+type Factory func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector
+
+var (
+	factories      = make(map[string]Factory)
+	collectorState = make(map[string]*bool)
+	legacyState    = make(map[string]*bool)
+
+	// supersededBy maps collectors to the collector exporting a superset of their metrics, only the latter is created
+	supersededBy = map[string]string{"indices": "shards"}
+)
+
+// Register adds a custom collector to the registry. Like the collectors of the exporter it is disabled by default,
+// enabled with --collector.<name> and can be enabled per cluster in the configuration file. Register has to be called
+// before the flags are parsed, usually from an init function, and panics if the name is already registered
+func Register(name string, f Factory) {
+	register(name, defaultDisabled, fmt.Sprintf("Enable the %s collector.", name), f)
+}
+
+// registerCollector adds a collector of the exporter to the registry. Besides --collector.<name> the former
+// --es.<name> flag keeps working
+func registerCollector(name string, isDefaultEnabled bool, help string, f Factory) {
+	register(name, isDefaultEnabled, help, f)
+	legacyState[name] = kingpin.Flag("es."+name, help).
+		Default("false").Hidden().Bool()
+}
+
+// registerRenamedCollector adds a collector of the exporter whose former flag was not named --es.<name>. Besides
+// --collector.<name> the former flag and its environment variable keep working
+func registerRenamedCollector(name, legacyFlag, legacyEnvar string, isDefaultEnabled bool, help string, f Factory) {
+	register(name, isDefaultEnabled, help, f)
+	legacyState[name] = kingpin.Flag(legacyFlag, help).
+		Default("false").Envar(legacyEnvar).Hidden().Bool()
+}
+
+// register adds a collector which is enabled with --collector.<name> and disabled with --no-collector.<name>
+func register(name string, isDefaultEnabled bool, help string, f Factory) {
+	if _, ok := factories[name]; ok {
+		panic(fmt.Sprintf("collector %s is already registered", name))
+	}
+
+	defaultValue := "false"
+	if isDefaultEnabled {
+		defaultValue = "true"
+	}
+	collectorState[name] = kingpin.Flag("collector."+name, help).
+		Default(defaultValue).Envar("ES_" + strings.ToUpper(name)).Bool()
+	factories[name] = f
+}
+
+// CollectorNames returns the names of all registered collectors in alphabetical order
+func CollectorNames() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewCollectors creates the registered collectors enabled by their flags. If names are passed, exactly
+// these collectors are created regardless of their flags. Collectors implementing Run(ctx context.Context)
+// have to be started by the caller
+func NewCollectors(logger log.Logger, client *http.Client, url *url.URL, names ...string) ([]prometheus.Collector, error) {
+	if len(names) == 0 {
+		for _, name := range CollectorNames() {
+			legacy, ok := legacyState[name]
+			if *collectorState[name] || ok && *legacy {
+				names = append(names, name)
+			}
+		}
+	}
+
+	enabled := make(map[string]bool, len(names))
+	for _, name := range names {
+		enabled[name] = true
+	}
+
+	collectors := make([]prometheus.Collector, 0, len(names))
+	for _, name := range names {
+		if enabled[supersededBy[name]] {
+			continue
+		}
+		f, ok := factories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown collector %s", name)
+		}
+		collectors = append(collectors, f(logger, client, url))
+	}
+	return collectors, nil
+}
@@ -0,0 +1,105 @@
+package collector
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestNewCollectors(t *testing.T) {
+	u, err := url.Parse("http://localhost:9200")
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+
+	// no collector is enabled by default
+	collectors, err := NewCollectors(log.NewNopLogger(), http.DefaultClient, u)
+	if err != nil {
+		t.Fatalf("Failed to create collectors: %s", err)
+	}
+	if len(collectors) != 0 {
+		t.Errorf("Wrong number of default collectors %d", len(collectors))
+	}
+
+	collectors, err = NewCollectors(log.NewNopLogger(), http.DefaultClient, u, "snapshots", "sql")
+	if err != nil {
+		t.Fatalf("Failed to create collectors: %s", err)
+	}
+	if len(collectors) != 2 {
+		t.Fatalf("Wrong number of collectors %d", len(collectors))
+	}
+	if _, ok := collectors[0].(*Snapshots); !ok {
+		t.Errorf("Wrong collector %T for snapshots", collectors[0])
+	}
+
+	// shards exports the metrics of indices as well
+	collectors, err = NewCollectors(log.NewNopLogger(), http.DefaultClient, u, "indices", "shards")
+	if err != nil {
+		t.Fatalf("Failed to create collectors: %s", err)
+	}
+	if len(collectors) != 1 {
+		t.Fatalf("Wrong number of collectors %d for indices and shards", len(collectors))
+	}
+	if c, ok := collectors[0].(*Indices); !ok || !c.shards {
+		t.Errorf("Wrong collector %T for shards", collectors[0])
+	}
+
+	if _, err := NewCollectors(log.NewNopLogger(), http.DefaultClient, u, "unknown"); err == nil {
+		t.Errorf("Unknown collector should fail")
+	}
+
+	names := CollectorNames()
+	for i := 1; i < len(names); i++ {
+		if names[i-1] >= names[i] {
+			t.Errorf("Collector names not sorted: %s, %s", names[i-1], names[i])
+		}
+	}
+}
+
+type customCollector struct {
+	url *url.URL
+}
+
+func (c *customCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+func (c *customCollector) Collect(ch chan<- prometheus.Metric) {}
+
+func TestRegister(t *testing.T) {
+	// the registry is global, the collector is registered only once when the test is repeated
+	if _, ok := factories["custom_plugin"]; !ok {
+		Register("custom_plugin", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+			return &customCollector{url: url}
+		})
+	}
+
+	u, err := url.Parse("http://localhost:9200")
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	collectors, err := NewCollectors(log.NewNopLogger(), http.DefaultClient, u)
+	if err != nil {
+		t.Fatalf("Failed to create collectors: %s", err)
+	}
+	if len(collectors) != 0 {
+		t.Errorf("Custom collector should be disabled by default")
+	}
+	collectors, err = NewCollectors(log.NewNopLogger(), http.DefaultClient, u, "custom_plugin")
+	if err != nil {
+		t.Fatalf("Failed to create custom collector: %s", err)
+	}
+	if c, ok := collectors[0].(*customCollector); !ok || c.url != u {
+		t.Errorf("Wrong custom collector %+v", collectors[0])
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Registering a collector twice should panic")
+		}
+	}()
+	Register("snapshots", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return &customCollector{url: url}
+	})
+}
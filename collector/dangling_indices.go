@@ -0,0 +1,143 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DanglingIndices information struct
+type DanglingIndices struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	count *prometheus.Desc
+	info  *prometheus.Desc
+}
+
+func init() {
+	registerCollector("dangling_indices", defaultDisabled, "Export dangling indices found on the nodes.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewDanglingIndices(logger, client, url)
+	})
+}
+
+// NewDanglingIndices defines Dangling Indices Prometheus metrics
+func NewDanglingIndices(logger log.Logger, client *http.Client, url *url.URL) *DanglingIndices {
+	subsystem := "dangling_indices"
+
+	return &DanglingIndices{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch dangling indices endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch dangling indices scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		count: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "count"),
+			"Number of dangling indices found on the nodes of the cluster",
+			nil, nil,
+		),
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "dangling_index", "info"),
+			"Dangling index, with the number of nodes holding a copy of it as value",
+			[]string{"index", "index_uuid"}, nil,
+		),
+	}
+}
+
+// Describe add Dangling Indices metrics descriptions
+func (d *DanglingIndices) Describe(ch chan<- *prometheus.Desc) {
+	ch <- d.count
+	ch <- d.info
+	ch <- d.up.Desc()
+	ch <- d.totalScrapes.Desc()
+	ch <- d.jsonParseFailures.Desc()
+}
+
+func (d *DanglingIndices) fetchAndDecodeDanglingIndices() (danglingIndicesResponse, error) {
+	var dir danglingIndicesResponse
+
+	u := *d.url
+	u.Path = path.Join(u.Path, "/_dangling")
+
+	res, err := d.client.Get(u.String())
+	if err != nil {
+		return dir, fmt.Errorf("failed to get dangling indices from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(d.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return dir, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&dir); err != nil {
+		d.jsonParseFailures.Inc()
+		return dir, err
+	}
+	return dir, nil
+}
+
+// Collect gets Dangling Indices metric values
+func (d *DanglingIndices) Collect(ch chan<- prometheus.Metric) {
+	d.totalScrapes.Inc()
+	defer func() {
+		ch <- d.up
+		ch <- d.totalScrapes
+		ch <- d.jsonParseFailures
+	}()
+
+	dir, err := d.fetchAndDecodeDanglingIndices()
+	if err != nil {
+		d.up.Set(0)
+		_ = level.Warn(d.logger).Log(
+			"msg", "failed to fetch and decode dangling indices",
+			"err", err,
+		)
+		return
+	}
+	d.up.Set(1)
+
+	ch <- prometheus.MustNewConstMetric(
+		d.count,
+		prometheus.GaugeValue,
+		float64(len(dir.DanglingIndices)),
+	)
+	for _, index := range dir.DanglingIndices {
+		ch <- prometheus.MustNewConstMetric(
+			d.info,
+			prometheus.GaugeValue,
+			float64(len(index.NodeIDs)),
+			index.IndexName, index.IndexUUID,
+		)
+	}
+}
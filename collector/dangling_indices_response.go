@@ -0,0 +1,15 @@
+package collector
+
+// danglingIndicesResponse is a representation of the Elasticsearch _dangling endpoint
+type danglingIndicesResponse struct {
+	ClusterName     string                  `json:"cluster_name"`
+	DanglingIndices []danglingIndexResponse `json:"dangling_indices"`
+}
+
+// danglingIndexResponse defines a single index found on disk which is not part of the cluster state
+type danglingIndexResponse struct {
+	IndexName          string   `json:"index_name"`
+	IndexUUID          string   `json:"index_uuid"`
+	CreationDateMillis int64    `json:"creation_date_millis"`
+	NodeIDs            []string `json:"node_ids"`
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestDanglingIndices(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  # copy the data path of a deleted index back into the data directory of a stopped node and restart it
+	//  curl http://localhost:9200/_dangling
+	tcs := map[string]string{
+		"7.9.0":  `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"docker-cluster","dangling_indices":[]}`,
+		"7.10.0": `{"_nodes":{"total":2,"successful":2,"failed":0},"cluster_name":"docker-cluster","dangling_indices":[{"index_name":"foo_1","index_uuid":"zmM4e0JtBkeUjiHD-MihPQ","creation_date_millis":1589414451372,"node_ids":["pL47UN3dAb2d5RCWP6lQ3e","tMTocMvQQgGCkj7QDHl3OA"]}]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		d := NewDanglingIndices(log.NewNopLogger(), http.DefaultClient, u)
+		dir, err := d.fetchAndDecodeDanglingIndices()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode dangling indices: %s", err)
+		}
+		t.Logf("[%s] Dangling Indices Response: %+v", ver, dir)
+		if ver == "7.9.0" && len(dir.DanglingIndices) != 0 {
+			t.Errorf("There should be no dangling indices")
+		}
+		if ver == "7.10.0" {
+			if len(dir.DanglingIndices) != 1 {
+				t.Fatalf("Wrong number of dangling indices")
+			}
+			index := dir.DanglingIndices[0]
+			if index.IndexName != "foo_1" || index.IndexUUID != "zmM4e0JtBkeUjiHD-MihPQ" || len(index.NodeIDs) != 2 {
+				t.Errorf("Wrong dangling index %+v", index)
+			}
+		}
+	}
+}
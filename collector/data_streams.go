@@ -0,0 +1,249 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	defaultDataStreamLabels = []string{"data_stream"}
+
+	// dataStreamManagers maps the managed_by values of Elasticsearch to the managed_by label
+	dataStreamManagers = map[string]string{
+		"Index Lifecycle Management": "ilm",
+		"Data stream lifecycle":      "dsl",
+		"Unmanaged":                  "unmanaged",
+	}
+)
+
+// dataStream combines the definition and the stats of a data stream
+type dataStream struct {
+	dataStreamResponse
+	Stats dataStreamStatsResponse
+}
+
+// ManagedBy returns which lifecycle manages the next generation of the data stream
+func (d dataStream) ManagedBy() string {
+	if d.NextGenerationManagedBy != "" {
+		if manager, ok := dataStreamManagers[d.NextGenerationManagedBy]; ok {
+			return manager
+		}
+		return d.NextGenerationManagedBy
+	}
+	// versions before 8.11 do not report the lifecycle, but always manage with ILM if a policy is set
+	if d.ILMPolicy != "" {
+		return "ilm"
+	}
+	return "unmanaged"
+}
+
+type dataStreamMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(dataStream dataStream) float64
+}
+
+// DataStreams information struct
+type DataStreams struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	dataStreamMetrics []*dataStreamMetric
+	managed           *prometheus.Desc
+}
+
+func init() {
+	registerCollector("data_stream", defaultDisabled, "Export stats for Data Streams.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewDataStreams(logger, client, url)
+	})
+}
+
+// NewDataStreams defines Data Streams Prometheus metrics
+func NewDataStreams(logger log.Logger, client *http.Client, url *url.URL) *DataStreams {
+	subsystem := "data_stream"
+
+	return &DataStreams{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch data streams endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch data streams scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		dataStreamMetrics: []*dataStreamMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "backing_indices"),
+					"Number of backing indices of the data stream",
+					defaultDataStreamLabels, nil,
+				),
+				Value: func(dataStream dataStream) float64 {
+					return float64(len(dataStream.Indices))
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "generation"),
+					"Current generation of the data stream",
+					defaultDataStreamLabels, nil,
+				),
+				Value: func(dataStream dataStream) float64 {
+					return float64(dataStream.Generation)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "store_size_bytes"),
+					"Store size of all backing indices of the data stream in bytes",
+					defaultDataStreamLabels, nil,
+				),
+				Value: func(dataStream dataStream) float64 {
+					return float64(dataStream.Stats.StoreSizeBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "maximum_timestamp_seconds"),
+					"Highest @timestamp of the data stream",
+					defaultDataStreamLabels, nil,
+				),
+				Value: func(dataStream dataStream) float64 {
+					return float64(dataStream.Stats.MaximumTimestamp) / 1000
+				},
+			},
+		},
+		managed: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "managed"),
+			"Lifecycle which manages the data stream (ilm, dsl or unmanaged)",
+			append(defaultDataStreamLabels, "managed_by"), nil,
+		),
+	}
+}
+
+// Describe add Data Streams metrics descriptions
+func (ds *DataStreams) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range ds.dataStreamMetrics {
+		ch <- metric.Desc
+	}
+	ch <- ds.managed
+	ch <- ds.up.Desc()
+	ch <- ds.totalScrapes.Desc()
+	ch <- ds.jsonParseFailures.Desc()
+}
+
+func (ds *DataStreams) getAndParseURL(u *url.URL, data interface{}) error {
+	res, err := ds.client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(ds.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		ds.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+func (ds *DataStreams) fetchAndDecodeDataStreams() ([]dataStream, error) {
+	u := *ds.url
+	u.Path = path.Join(u.Path, "/_data_stream")
+	var dsr dataStreamsResponse
+	if err := ds.getAndParseURL(&u, &dsr); err != nil {
+		return nil, err
+	}
+
+	u = *ds.url
+	u.Path = path.Join(u.Path, "/_data_stream/_stats")
+	var dssr dataStreamsStatsResponse
+	if err := ds.getAndParseURL(&u, &dssr); err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]dataStreamStatsResponse, len(dssr.DataStreams))
+	for _, s := range dssr.DataStreams {
+		stats[s.DataStream] = s
+	}
+
+	streams := make([]dataStream, 0, len(dsr.DataStreams))
+	for _, d := range dsr.DataStreams {
+		streams = append(streams, dataStream{dataStreamResponse: d, Stats: stats[d.Name]})
+	}
+	return streams, nil
+}
+
+// Collect gets Data Streams metric values
+func (ds *DataStreams) Collect(ch chan<- prometheus.Metric) {
+	ds.totalScrapes.Inc()
+	defer func() {
+		ch <- ds.up
+		ch <- ds.totalScrapes
+		ch <- ds.jsonParseFailures
+	}()
+
+	streams, err := ds.fetchAndDecodeDataStreams()
+	if err != nil {
+		ds.up.Set(0)
+		_ = level.Warn(ds.logger).Log(
+			"msg", "failed to fetch and decode data streams",
+			"err", err,
+		)
+		return
+	}
+	ds.up.Set(1)
+
+	for _, dataStream := range streams {
+		for _, metric := range ds.dataStreamMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(dataStream),
+				dataStream.Name,
+			)
+		}
+		ch <- prometheus.MustNewConstMetric(
+			ds.managed,
+			prometheus.GaugeValue,
+			1,
+			dataStream.Name, dataStream.ManagedBy(),
+		)
+	}
+}
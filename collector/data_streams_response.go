@@ -0,0 +1,37 @@
+package collector
+
+// dataStreamsResponse is a representation of the Elasticsearch _data_stream endpoint
+type dataStreamsResponse struct {
+	DataStreams []dataStreamResponse `json:"data_streams"`
+}
+
+// dataStreamResponse defines a single data stream
+type dataStreamResponse struct {
+	Name                    string                    `json:"name"`
+	Indices                 []dataStreamIndexResponse `json:"indices"`
+	Generation              int64                     `json:"generation"`
+	Status                  string                    `json:"status"`
+	Template                string                    `json:"template"`
+	ILMPolicy               string                    `json:"ilm_policy"`
+	NextGenerationManagedBy string                    `json:"next_generation_managed_by"`
+}
+
+// dataStreamIndexResponse defines a backing index of a data stream
+type dataStreamIndexResponse struct {
+	IndexName string `json:"index_name"`
+	IndexUUID string `json:"index_uuid"`
+	ManagedBy string `json:"managed_by"`
+}
+
+// dataStreamsStatsResponse is a representation of the Elasticsearch _data_stream/_stats endpoint
+type dataStreamsStatsResponse struct {
+	DataStreams []dataStreamStatsResponse `json:"data_streams"`
+}
+
+// dataStreamStatsResponse defines the stats of a single data stream
+type dataStreamStatsResponse struct {
+	DataStream       string `json:"data_stream"`
+	BackingIndices   int64  `json:"backing_indices"`
+	StoreSizeBytes   int64  `json:"store_size_bytes"`
+	MaximumTimestamp int64  `json:"maximum_timestamp"`
+}
@@ -0,0 +1,67 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestDataStreams(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_index_template/logs-test -d '{"index_patterns":["logs-test*"],"data_stream":{}}'
+	//  curl -XPOST http://localhost:9200/logs-test/_doc -d '{"@timestamp":"2020-08-18T12:00:00Z"}'
+	//  curl -XPOST http://localhost:9200/logs-test/_rollover
+	//  curl http://localhost:9200/_data_stream
+	//  curl http://localhost:9200/_data_stream/_stats
+	tcs := map[string][]string{
+		"7.9.0": {
+			`{"data_streams":[{"name":"logs-test","timestamp_field":{"name":"@timestamp"},"indices":[{"index_name":".ds-logs-test-000001","index_uuid":"fE5hPn2JQGuA2SPwXg5Yqw"},{"index_name":".ds-logs-test-000002","index_uuid":"x5dUAzL5RIqnXbcqLpMcjA"}],"generation":2,"status":"GREEN","template":"logs-test"}]}`,
+			`{"_shards":{"total":4,"successful":2,"failed":0},"data_stream_count":1,"backing_indices":2,"total_store_size_bytes":7420,"data_streams":[{"data_stream":"logs-test","backing_indices":2,"store_size_bytes":7420,"maximum_timestamp":1597752000000}]}`,
+		},
+		"8.11.0": {
+			`{"data_streams":[{"name":"logs-test","timestamp_field":{"name":"@timestamp"},"indices":[{"index_name":".ds-logs-test-2023.11.20-000001","index_uuid":"fE5hPn2JQGuA2SPwXg5Yqw","prefer_ilm":true,"ilm_policy":"logs","managed_by":"Index Lifecycle Management"},{"index_name":".ds-logs-test-2023.11.20-000002","index_uuid":"x5dUAzL5RIqnXbcqLpMcjA","prefer_ilm":true,"ilm_policy":"logs","managed_by":"Index Lifecycle Management"}],"generation":2,"status":"GREEN","template":"logs-test","ilm_policy":"logs","next_generation_managed_by":"Index Lifecycle Management","prefer_ilm":true,"hidden":false,"system":false,"allow_custom_routing":false,"replicated":false}]}`,
+			`{"_shards":{"total":4,"successful":2,"failed":0},"data_stream_count":1,"backing_indices":2,"total_store_size_bytes":7420,"data_streams":[{"data_stream":"logs-test","backing_indices":2,"store_size_bytes":7420,"maximum_timestamp":1597752000000}]}`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.RequestURI == "/_data_stream/_stats" {
+				fmt.Fprint(w, out[1])
+				return
+			}
+			fmt.Fprint(w, out[0])
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		ds := NewDataStreams(log.NewNopLogger(), http.DefaultClient, u)
+		streams, err := ds.fetchAndDecodeDataStreams()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode data streams: %s", err)
+		}
+		t.Logf("[%s] Data Streams Response: %+v", ver, streams)
+		if len(streams) != 1 {
+			t.Fatalf("Wrong number of data streams")
+		}
+		if streams[0].Name != "logs-test" || len(streams[0].Indices) != 2 || streams[0].Generation != 2 {
+			t.Errorf("Wrong data stream")
+		}
+		if streams[0].Stats.StoreSizeBytes != 7420 {
+			t.Errorf("Wrong data stream store size")
+		}
+		if ver == "7.9.0" && streams[0].ManagedBy() != "unmanaged" {
+			t.Errorf("Data stream should be unmanaged")
+		}
+		if ver == "8.11.0" && streams[0].ManagedBy() != "ilm" {
+			t.Errorf("Data stream should be managed by ILM")
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var deprecationLevels = []string{"info", "warning", "critical"}
+
+// Deprecations information struct
+type Deprecations struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	deprecations *prometheus.Desc
+}
+
+func init() {
+	registerCollector("deprecations", defaultDisabled, "Export deprecated settings and features in use.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewDeprecations(logger, client, url)
+	})
+}
+
+// NewDeprecations defines Deprecations Prometheus metrics
+func NewDeprecations(logger log.Logger, client *http.Client, url *url.URL) *Deprecations {
+	subsystem := "deprecations"
+
+	return &Deprecations{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch deprecations endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch deprecations scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		deprecations: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "count"),
+			"Number of deprecated settings and features in use by area and level",
+			[]string{"area", "level"}, nil,
+		),
+	}
+}
+
+// Describe add Deprecations metrics descriptions
+func (d *Deprecations) Describe(ch chan<- *prometheus.Desc) {
+	ch <- d.deprecations
+	ch <- d.up.Desc()
+	ch <- d.totalScrapes.Desc()
+	ch <- d.jsonParseFailures.Desc()
+}
+
+func (d *Deprecations) fetchAndDecodeDeprecations() (deprecationsResponse, error) {
+	var dr deprecationsResponse
+
+	u := *d.url
+	u.Path = path.Join(u.Path, "/_migration/deprecations")
+
+	res, err := d.client.Get(u.String())
+	if err != nil {
+		return dr, fmt.Errorf("failed to get deprecations from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(d.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return dr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&dr); err != nil {
+		d.jsonParseFailures.Inc()
+		return dr, err
+	}
+	return dr, nil
+}
+
+// Collect gets Deprecations metric values
+func (d *Deprecations) Collect(ch chan<- prometheus.Metric) {
+	d.totalScrapes.Inc()
+	defer func() {
+		ch <- d.up
+		ch <- d.totalScrapes
+		ch <- d.jsonParseFailures
+	}()
+
+	dr, err := d.fetchAndDecodeDeprecations()
+	if err != nil {
+		d.up.Set(0)
+		_ = level.Warn(d.logger).Log(
+			"msg", "failed to fetch and decode deprecations",
+			"err", err,
+		)
+		return
+	}
+	d.up.Set(1)
+
+	for area, deprecations := range dr.Areas() {
+		counts := make(map[string]int, len(deprecationLevels))
+		for _, deprecation := range deprecations {
+			counts[deprecation.Level]++
+		}
+		for _, level := range deprecationLevels {
+			ch <- prometheus.MustNewConstMetric(
+				d.deprecations,
+				prometheus.GaugeValue,
+				float64(counts[level]),
+				area, level,
+			)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package collector
+
+// deprecationsResponse is a representation of the Elasticsearch _migration/deprecations endpoint
+type deprecationsResponse struct {
+	ClusterSettings []deprecationResponse            `json:"cluster_settings"`
+	NodeSettings    []deprecationResponse            `json:"node_settings"`
+	IndexSettings   map[string][]deprecationResponse `json:"index_settings"`
+	MLSettings      []deprecationResponse            `json:"ml_settings"`
+	// data streams, templates and ILM policies are only checked since 8.x
+	DataStreams map[string][]deprecationResponse `json:"data_streams"`
+	Templates   map[string][]deprecationResponse `json:"templates"`
+	ILMPolicies map[string][]deprecationResponse `json:"ilm_policies"`
+}
+
+// deprecationResponse defines a single deprecation warning
+type deprecationResponse struct {
+	Level                       string `json:"level"`
+	Message                     string `json:"message"`
+	URL                         string `json:"url"`
+	Details                     string `json:"details"`
+	ResolveDuringRollingUpgrade bool   `json:"resolve_during_rolling_upgrade"`
+}
+
+// Areas returns the deprecations grouped by the area they were found in
+func (r deprecationsResponse) Areas() map[string][]deprecationResponse {
+	flatten := func(deprecations map[string][]deprecationResponse) []deprecationResponse {
+		var all []deprecationResponse
+		for _, d := range deprecations {
+			all = append(all, d...)
+		}
+		return all
+	}
+	return map[string][]deprecationResponse{
+		"cluster":     r.ClusterSettings,
+		"node":        r.NodeSettings,
+		"index":       flatten(r.IndexSettings),
+		"ml":          r.MLSettings,
+		"data_stream": flatten(r.DataStreams),
+		"template":    flatten(r.Templates),
+		"ilm_policy":  flatten(r.ILMPolicies),
+	}
+}
@@ -0,0 +1,47 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestDeprecations(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_migration/deprecations
+	tcs := map[string]string{
+		"7.10.0": `{"cluster_settings":[],"node_settings":[{"level":"warning","message":"setting [node.data] is deprecated in favor of [node.roles]","url":"https://ela.st/es-deprecation-7-node-roles","details":"the setting [node.data] is currently set to [true], remove this setting"},{"level":"critical","message":"transport SSL is not enabled","url":"https://ela.st/es-deprecation-7-transport-ssl","details":"transport SSL is required in 8.0 when security is enabled"}],"index_settings":{"logs-2019":[{"level":"critical","message":"Index created before 7.0","url":"https://ela.st/es-deprecation-7-reindex","details":"This index was created using version: 6.8.13"}],"logs-2020":[{"level":"warning","message":"translog retention settings are ignored","url":"https://ela.st/es-deprecation-7-translog-retention","details":"translog retention settings [index.translog.retention.size] and [index.translog.retention.age] are ignored"}]},"ml_settings":[]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		d := NewDeprecations(log.NewNopLogger(), http.DefaultClient, u)
+		dr, err := d.fetchAndDecodeDeprecations()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode deprecations: %s", err)
+		}
+		t.Logf("[%s] Deprecations Response: %+v", ver, dr)
+		areas := dr.Areas()
+		if len(areas["cluster"]) != 0 || len(areas["ml"]) != 0 || len(areas["template"]) != 0 {
+			t.Errorf("There should be no cluster, ml or template deprecations")
+		}
+		if len(areas["node"]) != 2 || areas["node"][1].Level != "critical" {
+			t.Errorf("Wrong node deprecations")
+		}
+		if len(areas["index"]) != 2 {
+			t.Errorf("Wrong index deprecations")
+		}
+	}
+}
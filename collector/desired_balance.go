@@ -0,0 +1,256 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type desiredBalanceMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(stats desiredBalanceStatsResponse) float64
+}
+
+// DesiredBalance information struct
+type DesiredBalance struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	metrics []*desiredBalanceMetric
+}
+
+func init() {
+	registerCollector("desired_balance", defaultDisabled, "Export convergence stats of the desired balance allocator.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewDesiredBalance(logger, client, url)
+	})
+}
+
+// NewDesiredBalance defines Desired Balance Prometheus metrics
+func NewDesiredBalance(logger log.Logger, client *http.Client, url *url.URL) *DesiredBalance {
+	subsystem := "desired_balance"
+
+	return &DesiredBalance{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch desired balance endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch desired balance scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		metrics: []*desiredBalanceMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "computation_active"),
+					"Whether a desired balance computation is currently running",
+					nil, nil,
+				),
+				Value: func(stats desiredBalanceStatsResponse) float64 {
+					return bool2Float(stats.ComputationActive)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "computations_submitted_total"),
+					"Total number of desired balance computations submitted",
+					nil, nil,
+				),
+				Value: func(stats desiredBalanceStatsResponse) float64 {
+					return float64(stats.ComputationSubmitted)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "computations_executed_total"),
+					"Total number of desired balance computations executed",
+					nil, nil,
+				),
+				Value: func(stats desiredBalanceStatsResponse) float64 {
+					return float64(stats.ComputationExecuted)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "computations_converged_total"),
+					"Total number of desired balance computations which converged",
+					nil, nil,
+				),
+				Value: func(stats desiredBalanceStatsResponse) float64 {
+					return float64(stats.ComputationConverged)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "computation_iterations_total"),
+					"Total number of iterations of desired balance computations",
+					nil, nil,
+				),
+				Value: func(stats desiredBalanceStatsResponse) float64 {
+					return float64(stats.ComputationIterations)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "computed_shard_movements_total"),
+					"Total number of shard movements of all computed desired balances",
+					nil, nil,
+				),
+				Value: func(stats desiredBalanceStatsResponse) float64 {
+					return float64(stats.ComputedShardMovements)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "computation_time_seconds_total"),
+					"Total time spent computing the desired balance in seconds",
+					nil, nil,
+				),
+				Value: func(stats desiredBalanceStatsResponse) float64 {
+					return float64(stats.ComputationTimeInMillis) / 1000
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "reconciliation_time_seconds_total"),
+					"Total time spent reconciling the cluster with the desired balance in seconds",
+					nil, nil,
+				),
+				Value: func(stats desiredBalanceStatsResponse) float64 {
+					return float64(stats.ReconciliationTimeInMillis) / 1000
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "unassigned_shards"),
+					"Number of shards which are unassigned in the desired balance",
+					nil, nil,
+				),
+				Value: func(stats desiredBalanceStatsResponse) float64 {
+					return float64(stats.UnassignedShards)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "total_allocations"),
+					"Number of shard allocations in the desired balance",
+					nil, nil,
+				),
+				Value: func(stats desiredBalanceStatsResponse) float64 {
+					return float64(stats.TotalAllocations)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "undesired_allocations"),
+					"Number of shard allocations which do not match the desired balance",
+					nil, nil,
+				),
+				Value: func(stats desiredBalanceStatsResponse) float64 {
+					return float64(stats.UndesiredAllocations)
+				},
+			},
+		},
+	}
+}
+
+// Describe add Desired Balance metrics descriptions
+func (d *DesiredBalance) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range d.metrics {
+		ch <- metric.Desc
+	}
+	ch <- d.up.Desc()
+	ch <- d.totalScrapes.Desc()
+	ch <- d.jsonParseFailures.Desc()
+}
+
+func (d *DesiredBalance) fetchAndDecodeDesiredBalance() (desiredBalanceResponse, error) {
+	var dbr desiredBalanceResponse
+
+	u := *d.url
+	u.Path = path.Join(u.Path, "/_internal/desired_balance")
+
+	res, err := d.client.Get(u.String())
+	if err != nil {
+		return dbr, fmt.Errorf("failed to get desired balance from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(d.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return dbr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&dbr); err != nil {
+		d.jsonParseFailures.Inc()
+		return dbr, err
+	}
+	return dbr, nil
+}
+
+// Collect gets Desired Balance metric values
+func (d *DesiredBalance) Collect(ch chan<- prometheus.Metric) {
+	d.totalScrapes.Inc()
+	defer func() {
+		ch <- d.up
+		ch <- d.totalScrapes
+		ch <- d.jsonParseFailures
+	}()
+
+	dbr, err := d.fetchAndDecodeDesiredBalance()
+	if err != nil {
+		d.up.Set(0)
+		_ = level.Warn(d.logger).Log(
+			"msg", "failed to fetch and decode desired balance",
+			"err", err,
+		)
+		return
+	}
+	d.up.Set(1)
+
+	for _, metric := range d.metrics {
+		ch <- prometheus.MustNewConstMetric(
+			metric.Desc,
+			metric.Type,
+			metric.Value(dbr.Stats),
+		)
+	}
+}
@@ -0,0 +1,22 @@
+package collector
+
+// desiredBalanceResponse is a representation of the Elasticsearch _internal/desired_balance endpoint
+type desiredBalanceResponse struct {
+	Stats desiredBalanceStatsResponse `json:"stats"`
+}
+
+// desiredBalanceStatsResponse defines the convergence stats of the desired balance shards allocator
+type desiredBalanceStatsResponse struct {
+	ComputationActive          bool  `json:"computation_active"`
+	ComputationSubmitted       int64 `json:"computation_submitted"`
+	ComputationExecuted        int64 `json:"computation_executed"`
+	ComputationConverged       int64 `json:"computation_converged"`
+	ComputationIterations      int64 `json:"computation_iterations"`
+	ComputedShardMovements     int64 `json:"computed_shard_movements"`
+	ComputationTimeInMillis    int64 `json:"computation_time_in_millis"`
+	ReconciliationTimeInMillis int64 `json:"reconciliation_time_in_millis"`
+	// the allocation counters are only reported since 8.8
+	UnassignedShards     int64 `json:"unassigned_shards"`
+	TotalAllocations     int64 `json:"total_allocations"`
+	UndesiredAllocations int64 `json:"undesired_allocations"`
+}
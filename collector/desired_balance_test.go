@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestDesiredBalance(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_internal/desired_balance
+	tcs := map[string]string{
+		"8.6.0": `{"stats":{"computation_active":false,"computation_submitted":12,"computation_executed":12,"computation_converged":11,"computation_iterations":340,"computed_shard_movements":25,"computation_time_in_millis":1480,"reconciliation_time_in_millis":310},"routing_table":{}}`,
+		"8.8.0": `{"stats":{"computation_converged_index":28,"computation_active":true,"computation_submitted":29,"computation_executed":28,"computation_converged":28,"computation_iterations":812,"computed_shard_movements":6,"computation_time_in_millis":2210,"reconciliation_time_in_millis":102,"unassigned_shards":2,"total_allocations":60,"undesired_allocations":3,"undesired_allocations_ratio":0.05},"cluster_balance_stats":{"tiers":{},"nodes":{}},"routing_table":{}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		d := NewDesiredBalance(log.NewNopLogger(), http.DefaultClient, u)
+		dbr, err := d.fetchAndDecodeDesiredBalance()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode desired balance: %s", err)
+		}
+		t.Logf("[%s] Desired Balance Response: %+v", ver, dbr)
+		switch ver {
+		case "8.6.0":
+			if dbr.Stats.ComputationConverged != 11 || dbr.Stats.ComputationTimeInMillis != 1480 {
+				t.Errorf("Wrong desired balance computation stats")
+			}
+			if dbr.Stats.UndesiredAllocations != 0 {
+				t.Errorf("There should be no undesired allocations")
+			}
+		case "8.8.0":
+			if !dbr.Stats.ComputationActive || dbr.Stats.ComputationSubmitted != 29 {
+				t.Errorf("Wrong desired balance computation stats")
+			}
+			if dbr.Stats.UnassignedShards != 2 || dbr.Stats.TotalAllocations != 60 || dbr.Stats.UndesiredAllocations != 3 {
+				t.Errorf("Wrong desired balance allocation stats")
+			}
+		}
+	}
+}
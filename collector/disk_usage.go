@@ -0,0 +1,345 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var defaultDiskUsageFieldLabels = []string{"index", "field"}
+
+type diskUsageFieldMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(field diskUsageFieldResponse) float64
+}
+
+// DiskUsage periodically analyzes the disk usage of the configured indices and exports the
+// store size of every field. The analysis reads all data of the indices, so the interval should be long
+type DiskUsage struct {
+	logger   log.Logger
+	client   *http.Client
+	url      *url.URL
+	indices  []string
+	interval time.Duration
+
+	mutex  sync.RWMutex
+	usages map[string]diskUsageIndexResponse
+	ts     time.Time
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	lastRun        *prometheus.Desc
+	indexStoreSize *prometheus.Desc
+	fieldMetrics   []*diskUsageFieldMetric
+}
+
+var (
+	diskUsageInterval = kingpin.Flag("es.disk_usage.interval",
+		"Disk usage analysis interval").
+		Default("24h").Envar("ES_DISK_USAGE_INTERVAL").Duration()
+	diskUsageIndices = kingpin.Flag("es.disk_usage.indices",
+		"Comma-separated list of index patterns whose disk usage is analyzed.").
+		Default("").Envar("ES_DISK_USAGE_INDICES").String()
+)
+
+func init() {
+	registerCollector("disk_usage", defaultDisabled, "Periodically analyze the disk usage of the configured indices per field.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewDiskUsage(logger, client, url, strings.Split(*diskUsageIndices, ","), *diskUsageInterval)
+	})
+}
+
+// NewDiskUsage defines Disk Usage Prometheus metrics
+func NewDiskUsage(logger log.Logger, client *http.Client, url *url.URL, indices []string, interval time.Duration) *DiskUsage {
+	subsystem := "disk_usage"
+
+	return &DiskUsage{
+		logger:   logger,
+		client:   client,
+		url:      url,
+		indices:  indices,
+		interval: interval,
+		usages:   make(map[string]diskUsageIndexResponse),
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Were all analyses of the last ElasticSearch disk usage run successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch disk usage runs.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		lastRun: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "last_run_timestamp_seconds"),
+			"Timestamp of the last disk usage analysis",
+			nil, nil,
+		),
+		indexStoreSize: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "index_store_size_bytes"),
+			"Store size of the primary shards of the index in the last disk usage analysis in bytes",
+			[]string{"index"}, nil,
+		),
+		fieldMetrics: []*diskUsageFieldMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "field_size_bytes"),
+					"Disk usage of the field in the primary shards of the index in bytes",
+					defaultDiskUsageFieldLabels, nil,
+				),
+				Value: func(field diskUsageFieldResponse) float64 {
+					return float64(field.TotalInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "field_inverted_index_bytes"),
+					"Disk usage of the inverted index of the field in bytes",
+					defaultDiskUsageFieldLabels, nil,
+				),
+				Value: func(field diskUsageFieldResponse) float64 {
+					return float64(field.InvertedIndex.TotalInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "field_stored_fields_bytes"),
+					"Disk usage of the stored values of the field in bytes",
+					defaultDiskUsageFieldLabels, nil,
+				),
+				Value: func(field diskUsageFieldResponse) float64 {
+					return float64(field.StoredFieldsInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "field_doc_values_bytes"),
+					"Disk usage of the doc values of the field in bytes",
+					defaultDiskUsageFieldLabels, nil,
+				),
+				Value: func(field diskUsageFieldResponse) float64 {
+					return float64(field.DocValuesInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "field_points_bytes"),
+					"Disk usage of the points of the field in bytes",
+					defaultDiskUsageFieldLabels, nil,
+				),
+				Value: func(field diskUsageFieldResponse) float64 {
+					return float64(field.PointsInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "field_norms_bytes"),
+					"Disk usage of the norms of the field in bytes",
+					defaultDiskUsageFieldLabels, nil,
+				),
+				Value: func(field diskUsageFieldResponse) float64 {
+					return float64(field.NormsInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "field_term_vectors_bytes"),
+					"Disk usage of the term vectors of the field in bytes",
+					defaultDiskUsageFieldLabels, nil,
+				),
+				Value: func(field diskUsageFieldResponse) float64 {
+					return float64(field.TermVectorsInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "field_knn_vectors_bytes"),
+					"Disk usage of the knn vectors of the field in bytes",
+					defaultDiskUsageFieldLabels, nil,
+				),
+				Value: func(field diskUsageFieldResponse) float64 {
+					return float64(field.KnnVectorsInBytes)
+				},
+			},
+		},
+	}
+}
+
+// Describe add Disk Usage metrics descriptions
+func (d *DiskUsage) Describe(ch chan<- *prometheus.Desc) {
+	ch <- d.lastRun
+	ch <- d.indexStoreSize
+	for _, metric := range d.fieldMetrics {
+		ch <- metric.Desc
+	}
+	ch <- d.up.Desc()
+	ch <- d.totalScrapes.Desc()
+	ch <- d.jsonParseFailures.Desc()
+}
+
+// analyze runs the disk usage analysis of a single index pattern and returns the results by index
+func (d *DiskUsage) analyze(index string) (map[string]diskUsageIndexResponse, error) {
+	u := *d.url
+	u.Path = path.Join(u.Path, index, "/_disk_usage")
+	u.RawQuery = "run_expensive_tasks=true"
+
+	res, err := d.client.Post(u.String(), "application/json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze disk usage from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(d.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	var dur map[string]json.RawMessage
+	if err := json.NewDecoder(res.Body).Decode(&dur); err != nil {
+		d.jsonParseFailures.Inc()
+		return nil, err
+	}
+	usages := make(map[string]diskUsageIndexResponse, len(dur))
+	for name, raw := range dur {
+		if name == "_shards" {
+			continue
+		}
+		var usage diskUsageIndexResponse
+		if err := json.Unmarshal(raw, &usage); err != nil {
+			d.jsonParseFailures.Inc()
+			return nil, err
+		}
+		usages[name] = usage
+	}
+	return usages, nil
+}
+
+// sample analyzes the disk usage of every configured index pattern once and stores the results
+func (d *DiskUsage) sample() {
+	d.totalScrapes.Inc()
+
+	up := 1.0
+	start := time.Now()
+	usages := make(map[string]diskUsageIndexResponse)
+	for _, index := range d.indices {
+		// an empty pattern would analyze all indices of the cluster
+		if index == "" {
+			continue
+		}
+		indexUsages, err := d.analyze(index)
+		if err != nil {
+			up = 0
+			_ = level.Warn(d.logger).Log(
+				"msg", "failed to analyze disk usage",
+				"index", index,
+				"err", err,
+			)
+			continue
+		}
+		for name, usage := range indexUsages {
+			usages[name] = usage
+		}
+	}
+	d.up.Set(up)
+
+	d.mutex.Lock()
+	d.usages = usages
+	d.ts = start
+	d.mutex.Unlock()
+}
+
+// Run starts the analysis loop. The loop is terminated upon ctx cancellation, without a positive interval
+// the indices are analyzed once
+func (d *DiskUsage) Run(ctx context.Context) {
+	go func() {
+		d.sample()
+		if d.interval <= 0 {
+			_ = level.Info(d.logger).Log(
+				"msg", "no periodic disk usage analysis requested",
+			)
+			return
+		}
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				_ = level.Info(d.logger).Log(
+					"msg", "context cancelled, exiting disk usage loop",
+					"err", ctx.Err(),
+				)
+				return
+			case <-ticker.C:
+			}
+			d.sample()
+		}
+	}()
+}
+
+// Collect gets Disk Usage metric values
+func (d *DiskUsage) Collect(ch chan<- prometheus.Metric) {
+	ch <- d.up
+	ch <- d.totalScrapes
+	ch <- d.jsonParseFailures
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	if d.ts.IsZero() {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		d.lastRun,
+		prometheus.GaugeValue,
+		float64(d.ts.Unix()),
+	)
+	for index, usage := range d.usages {
+		ch <- prometheus.MustNewConstMetric(
+			d.indexStoreSize,
+			prometheus.GaugeValue,
+			float64(usage.StoreSizeInBytes),
+			index,
+		)
+		for field, fieldUsage := range usage.Fields {
+			for _, metric := range d.fieldMetrics {
+				ch <- prometheus.MustNewConstMetric(
+					metric.Desc,
+					metric.Type,
+					metric.Value(fieldUsage),
+					index, field,
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package collector
+
+// diskUsageIndexResponse is a representation of the analysis of a single index in the Elasticsearch _disk_usage response,
+// the response is keyed by index next to the _shards header
+type diskUsageIndexResponse struct {
+	StoreSizeInBytes int64                             `json:"store_size_in_bytes"`
+	AllFields        diskUsageFieldResponse            `json:"all_fields"`
+	Fields           map[string]diskUsageFieldResponse `json:"fields"`
+}
+
+// diskUsageFieldResponse defines the disk usage of a single field broken down by data structure
+type diskUsageFieldResponse struct {
+	TotalInBytes  int64 `json:"total_in_bytes"`
+	InvertedIndex struct {
+		TotalInBytes int64 `json:"total_in_bytes"`
+	} `json:"inverted_index"`
+	StoredFieldsInBytes int64 `json:"stored_fields_in_bytes"`
+	DocValuesInBytes    int64 `json:"doc_values_in_bytes"`
+	PointsInBytes       int64 `json:"points_in_bytes"`
+	NormsInBytes        int64 `json:"norms_in_bytes"`
+	TermVectorsInBytes  int64 `json:"term_vectors_in_bytes"`
+	KnnVectorsInBytes   int64 `json:"knn_vectors_in_bytes"`
+}
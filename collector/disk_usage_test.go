@@ -0,0 +1,56 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestDiskUsage(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1/_doc/1 -H "Content-Type: application/json" -d '{"title":"abc","content":"hello"}'
+	//  curl -XPOST http://localhost:9200/foo_1/_disk_usage?run_expensive_tasks=true
+	tcs := map[string]string{
+		"7.15.0": `{"_shards":{"total":1,"successful":1,"failed":0},"foo_1":{"store_size":"5.3kb","store_size_in_bytes":5452,"all_fields":{"total":"658b","total_in_bytes":658,"inverted_index":{"total":"201b","total_in_bytes":201},"stored_fields":"199b","stored_fields_in_bytes":199,"doc_values":"86b","doc_values_in_bytes":86,"points":"0b","points_in_bytes":0,"norms":"172b","norms_in_bytes":172,"term_vectors":"0b","term_vectors_in_bytes":0},"fields":{"_id":{"total":"116b","total_in_bytes":116,"inverted_index":{"total":"61b","total_in_bytes":61},"stored_fields":"55b","stored_fields_in_bytes":55,"doc_values":"0b","doc_values_in_bytes":0,"points":"0b","points_in_bytes":0,"norms":"0b","norms_in_bytes":0,"term_vectors":"0b","term_vectors_in_bytes":0},"content":{"total":"112b","total_in_bytes":112,"inverted_index":{"total":"26b","total_in_bytes":26},"stored_fields":"0b","stored_fields_in_bytes":0,"doc_values":"0b","doc_values_in_bytes":0,"points":"0b","points_in_bytes":0,"norms":"86b","norms_in_bytes":86,"term_vectors":"0b","term_vectors_in_bytes":0},"title.keyword":{"total":"68b","total_in_bytes":68,"inverted_index":{"total":"25b","total_in_bytes":25},"stored_fields":"0b","stored_fields_in_bytes":0,"doc_values":"43b","doc_values_in_bytes":43,"points":"0b","points_in_bytes":0,"norms":"0b","norms_in_bytes":0,"term_vectors":"0b","term_vectors_in_bytes":0}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/foo_1/_disk_usage" {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		d := NewDiskUsage(log.NewNopLogger(), http.DefaultClient, u, []string{"foo_1", "bar_1"}, time.Hour)
+		d.sample()
+		t.Logf("[%s] Disk Usage: %+v", ver, d.usages)
+		if len(d.usages) != 1 {
+			t.Fatalf("Wrong number of analyzed indices %d", len(d.usages))
+		}
+		usage := d.usages["foo_1"]
+		if usage.StoreSizeInBytes != 5452 {
+			t.Errorf("Wrong store size %d", usage.StoreSizeInBytes)
+		}
+		if len(usage.Fields) != 3 {
+			t.Errorf("Wrong number of fields %d", len(usage.Fields))
+		}
+		if f := usage.Fields["title.keyword"]; f.TotalInBytes != 68 || f.InvertedIndex.TotalInBytes != 25 || f.DocValuesInBytes != 43 {
+			t.Errorf("Wrong disk usage of title.keyword %+v", f)
+		}
+		if f := usage.Fields["content"]; f.NormsInBytes != 86 {
+			t.Errorf("Wrong disk usage of content %+v", f)
+		}
+	}
+}
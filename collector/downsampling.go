@@ -0,0 +1,211 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// downsampleStatuses are the values of the index.downsample.status setting of downsampled indices
+	downsampleStatuses = []string{"unknown", "started", "success", "failed"}
+)
+
+// downsampleAction is the action of a downsample operation, the per shard tasks run as its children
+const downsampleAction = "indices:admin/xpack/downsample"
+
+// downsampling combines the downsample settings of all indices and the running downsample tasks
+type downsampling struct {
+	Indices indicesDownsampleSettingsResponse
+	Tasks   tasksResponse
+}
+
+// Downsampling information struct
+type Downsampling struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	indices              *prometheus.Desc
+	tasksRunning         *prometheus.Desc
+	oldestRunningSeconds *prometheus.Desc
+}
+
+func init() {
+	registerCollector("downsampling", defaultDisabled, "Export the status of downsampled indices and running downsample operations.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewDownsampling(logger, client, url)
+	})
+}
+
+// NewDownsampling defines Downsampling Prometheus metrics
+func NewDownsampling(logger log.Logger, client *http.Client, url *url.URL) *Downsampling {
+	subsystem := "downsample"
+
+	return &Downsampling{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch downsampling endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch downsampling scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		indices: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "indices"),
+			"Number of downsampled indices by downsample status",
+			[]string{"status"}, nil,
+		),
+		tasksRunning: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "tasks_running"),
+			"Number of downsample operations currently running",
+			nil, nil,
+		),
+		oldestRunningSeconds: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "oldest_running_time_seconds"),
+			"Running time of the longest running downsample operation in seconds",
+			nil, nil,
+		),
+	}
+}
+
+// Describe add Downsampling metrics descriptions
+func (d *Downsampling) Describe(ch chan<- *prometheus.Desc) {
+	ch <- d.indices
+	ch <- d.tasksRunning
+	ch <- d.oldestRunningSeconds
+	ch <- d.up.Desc()
+	ch <- d.totalScrapes.Desc()
+	ch <- d.jsonParseFailures.Desc()
+}
+
+func (d *Downsampling) getAndParseURL(u *url.URL, data interface{}) error {
+	res, err := d.client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(d.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		d.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+func (d *Downsampling) fetchAndDecodeDownsampling() (downsampling, error) {
+	var ds downsampling
+
+	// downsampled indices are hidden once they are added to a data stream
+	u := *d.url
+	u.Path = path.Join(u.Path, "/_all/_settings/index.downsample.*")
+	u.RawQuery = "expand_wildcards=all&flat_settings=true"
+	if err := d.getAndParseURL(&u, &ds.Indices); err != nil {
+		return ds, err
+	}
+
+	u = *d.url
+	u.Path = path.Join(u.Path, "/_tasks")
+	u.RawQuery = "actions=" + downsampleAction + "*"
+	if err := d.getAndParseURL(&u, &ds.Tasks); err != nil {
+		return ds, err
+	}
+
+	return ds, nil
+}
+
+// Collect gets Downsampling metric values
+func (d *Downsampling) Collect(ch chan<- prometheus.Metric) {
+	d.totalScrapes.Inc()
+	defer func() {
+		ch <- d.up
+		ch <- d.totalScrapes
+		ch <- d.jsonParseFailures
+	}()
+
+	ds, err := d.fetchAndDecodeDownsampling()
+	if err != nil {
+		d.up.Set(0)
+		_ = level.Warn(d.logger).Log(
+			"msg", "failed to fetch and decode downsampling",
+			"err", err,
+		)
+		return
+	}
+	d.up.Set(1)
+
+	indices := make(map[string]float64, len(downsampleStatuses))
+	for _, status := range downsampleStatuses {
+		indices[status] = 0
+	}
+	for _, index := range ds.Indices {
+		// only indices created by a downsample operation have a status
+		status := strings.ToLower(index.Settings.Status)
+		if status == "" {
+			continue
+		}
+		indices[status]++
+	}
+	for status, count := range indices {
+		ch <- prometheus.MustNewConstMetric(
+			d.indices,
+			prometheus.GaugeValue,
+			count,
+			status,
+		)
+	}
+
+	var running, oldest float64
+	for _, node := range ds.Tasks.Nodes {
+		for _, task := range node.Tasks {
+			if task.Action != downsampleAction || task.ParentTaskID != "" {
+				continue
+			}
+			running++
+			if runningSeconds := float64(task.RunningTimeInNanos) / 1e9; runningSeconds > oldest {
+				oldest = runningSeconds
+			}
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(
+		d.tasksRunning,
+		prometheus.GaugeValue,
+		running,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		d.oldestRunningSeconds,
+		prometheus.GaugeValue,
+		oldest,
+	)
+}
@@ -0,0 +1,12 @@
+package collector
+
+// indicesDownsampleSettingsResponse is a representation of the downsample settings of the Elasticsearch _settings endpoint
+type indicesDownsampleSettingsResponse map[string]indexDownsampleSettingsResponse
+
+// indexDownsampleSettingsResponse defines the flat downsample settings of a single index
+type indexDownsampleSettingsResponse struct {
+	Settings struct {
+		Status     string `json:"index.downsample.status"`
+		SourceName string `json:"index.downsample.source.name"`
+	} `json:"settings"`
+}
@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestDownsampling(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPOST http://localhost:9200/.ds-metrics-2023.11.18-000001/_downsample/downsample-1h -d '{"fixed_interval":"1h"}'
+	//  curl http://localhost:9200/_all/_settings/index.downsample.*?expand_wildcards=all&flat_settings=true
+	//  curl http://localhost:9200/_tasks?actions=indices:admin/xpack/downsample*
+	tcs := map[string][]string{
+		"8.11.0": {
+			`{"downsample-1h":{"settings":{"index.downsample.status":"success","index.downsample.source.name":".ds-metrics-2023.11.18-000001"}},"downsample-1d":{"settings":{"index.downsample.status":"started","index.downsample.source.name":"downsample-1h"}},".ds-metrics-2023.11.18-000001":{"settings":{}}}`,
+			`{"nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"name":"es01","tasks":{"Bu9Dc0qkQVmPSbRx9B0KjQ:2049":{"node":"Bu9Dc0qkQVmPSbRx9B0KjQ","id":2049,"type":"transport","action":"indices:admin/xpack/downsample","start_time_in_millis":1700344621124,"running_time_in_nanos":12500000000,"cancellable":false},"Bu9Dc0qkQVmPSbRx9B0KjQ:2051":{"node":"Bu9Dc0qkQVmPSbRx9B0KjQ","id":2051,"type":"transport","action":"indices:admin/xpack/downsample_indexer[s]","start_time_in_millis":1700344621187,"running_time_in_nanos":12400000000,"cancellable":false,"parent_task_id":"Bu9Dc0qkQVmPSbRx9B0KjQ:2049"}}}}}`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/_tasks") {
+				fmt.Fprint(w, out[1])
+				return
+			}
+			fmt.Fprint(w, out[0])
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		d := NewDownsampling(log.NewNopLogger(), http.DefaultClient, u)
+		ds, err := d.fetchAndDecodeDownsampling()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode downsampling: %s", err)
+		}
+		t.Logf("[%s] Downsampling Response: %+v", ver, ds)
+		if len(ds.Indices) != 3 {
+			t.Fatalf("Wrong number of indices")
+		}
+		if index := ds.Indices["downsample-1h"].Settings; index.Status != "success" || index.SourceName != ".ds-metrics-2023.11.18-000001" {
+			t.Errorf("Wrong downsample settings %+v", index)
+		}
+		if ds.Indices[".ds-metrics-2023.11.18-000001"].Settings.Status != "" {
+			t.Errorf("Source index should not have a downsample status")
+		}
+		tasks := ds.Tasks.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"].Tasks
+		if task := tasks["Bu9Dc0qkQVmPSbRx9B0KjQ:2049"]; task.Action != downsampleAction || task.RunningTimeInNanos != 12500000000 {
+			t.Errorf("Wrong downsample task %+v", task)
+		}
+		if tasks["Bu9Dc0qkQVmPSbRx9B0KjQ:2051"].ParentTaskID == "" {
+			t.Errorf("Downsample shard task should have a parent")
+		}
+	}
+}
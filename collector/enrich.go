@@ -0,0 +1,260 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type enrichCoordinatorMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(stats enrichCoordinatorStatsResponse) float64
+}
+
+type enrichCacheMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(stats enrichCacheStatsResponse) float64
+}
+
+// Enrich information struct
+type Enrich struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	executingPolicies  *prometheus.Desc
+	coordinatorMetrics []*enrichCoordinatorMetric
+	cacheMetrics       []*enrichCacheMetric
+}
+
+func init() {
+	registerCollector("enrich", defaultDisabled, "Export stats for enrich policies.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewEnrich(logger, client, url)
+	})
+}
+
+// NewEnrich defines Enrich Prometheus metrics
+func NewEnrich(logger log.Logger, client *http.Client, url *url.URL) *Enrich {
+	subsystem := "enrich"
+
+	return &Enrich{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch enrich stats endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch enrich stats scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		executingPolicies: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "executing_policies"),
+			"Number of enrich policies which are currently executing",
+			nil, nil,
+		),
+		coordinatorMetrics: []*enrichCoordinatorMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "coordinator_queue_size"),
+					"Number of search requests in the enrich coordinator queue",
+					[]string{"node"}, nil,
+				),
+				Value: func(stats enrichCoordinatorStatsResponse) float64 {
+					return float64(stats.QueueSize)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "coordinator_remote_requests_current"),
+					"Current number of outstanding remote requests of the enrich coordinator",
+					[]string{"node"}, nil,
+				),
+				Value: func(stats enrichCoordinatorStatsResponse) float64 {
+					return float64(stats.RemoteRequestsCurrent)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "coordinator_remote_requests_total"),
+					"Total number of remote requests executed by the enrich coordinator",
+					[]string{"node"}, nil,
+				),
+				Value: func(stats enrichCoordinatorStatsResponse) float64 {
+					return float64(stats.RemoteRequestsTotal)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "coordinator_executed_searches_total"),
+					"Total number of search requests executed by the enrich coordinator",
+					[]string{"node"}, nil,
+				),
+				Value: func(stats enrichCoordinatorStatsResponse) float64 {
+					return float64(stats.ExecutedSearchesTotal)
+				},
+			},
+		},
+		cacheMetrics: []*enrichCacheMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "cache_count"),
+					"Number of cached entries in the enrich cache",
+					[]string{"node"}, nil,
+				),
+				Value: func(stats enrichCacheStatsResponse) float64 {
+					return float64(stats.Count)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "cache_hits_total"),
+					"Total number of enrich cache hits",
+					[]string{"node"}, nil,
+				),
+				Value: func(stats enrichCacheStatsResponse) float64 {
+					return float64(stats.Hits)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "cache_misses_total"),
+					"Total number of enrich cache misses",
+					[]string{"node"}, nil,
+				),
+				Value: func(stats enrichCacheStatsResponse) float64 {
+					return float64(stats.Misses)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "cache_evictions_total"),
+					"Total number of enrich cache evictions",
+					[]string{"node"}, nil,
+				),
+				Value: func(stats enrichCacheStatsResponse) float64 {
+					return float64(stats.Evictions)
+				},
+			},
+		},
+	}
+}
+
+// Describe add Enrich metrics descriptions
+func (e *Enrich) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.executingPolicies
+	for _, metric := range e.coordinatorMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range e.cacheMetrics {
+		ch <- metric.Desc
+	}
+	ch <- e.up.Desc()
+	ch <- e.totalScrapes.Desc()
+	ch <- e.jsonParseFailures.Desc()
+}
+
+func (e *Enrich) fetchAndDecodeEnrichStats() (enrichStatsResponse, error) {
+	var esr enrichStatsResponse
+
+	u := *e.url
+	u.Path = path.Join(u.Path, "/_enrich/_stats")
+
+	res, err := e.client.Get(u.String())
+	if err != nil {
+		return esr, fmt.Errorf("failed to get enrich stats from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(e.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return esr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&esr); err != nil {
+		e.jsonParseFailures.Inc()
+		return esr, err
+	}
+	return esr, nil
+}
+
+// Collect gets Enrich metric values
+func (e *Enrich) Collect(ch chan<- prometheus.Metric) {
+	e.totalScrapes.Inc()
+	defer func() {
+		ch <- e.up
+		ch <- e.totalScrapes
+		ch <- e.jsonParseFailures
+	}()
+
+	esr, err := e.fetchAndDecodeEnrichStats()
+	if err != nil {
+		e.up.Set(0)
+		_ = level.Warn(e.logger).Log(
+			"msg", "failed to fetch and decode enrich stats",
+			"err", err,
+		)
+		return
+	}
+	e.up.Set(1)
+
+	ch <- prometheus.MustNewConstMetric(
+		e.executingPolicies,
+		prometheus.GaugeValue,
+		float64(len(esr.ExecutingPolicies)),
+	)
+	for _, stats := range esr.CoordinatorStats {
+		for _, metric := range e.coordinatorMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(stats),
+				stats.NodeID,
+			)
+		}
+	}
+	for _, stats := range esr.CacheStats {
+		for _, metric := range e.cacheMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(stats),
+				stats.NodeID,
+			)
+		}
+	}
+}
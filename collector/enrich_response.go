@@ -0,0 +1,31 @@
+package collector
+
+// enrichStatsResponse is a representation of the Elasticsearch _enrich/_stats endpoint
+type enrichStatsResponse struct {
+	ExecutingPolicies []enrichExecutingPolicyResponse  `json:"executing_policies"`
+	CoordinatorStats  []enrichCoordinatorStatsResponse `json:"coordinator_stats"`
+	CacheStats        []enrichCacheStatsResponse       `json:"cache_stats"`
+}
+
+// enrichExecutingPolicyResponse defines a currently executing enrich policy
+type enrichExecutingPolicyResponse struct {
+	Name string `json:"name"`
+}
+
+// enrichCoordinatorStatsResponse defines the enrich coordinator stats of a node
+type enrichCoordinatorStatsResponse struct {
+	NodeID                string `json:"node_id"`
+	QueueSize             int64  `json:"queue_size"`
+	RemoteRequestsCurrent int64  `json:"remote_requests_current"`
+	RemoteRequestsTotal   int64  `json:"remote_requests_total"`
+	ExecutedSearchesTotal int64  `json:"executed_searches_total"`
+}
+
+// enrichCacheStatsResponse defines the enrich cache stats of a node
+type enrichCacheStatsResponse struct {
+	NodeID    string `json:"node_id"`
+	Count     int64  `json:"count"`
+	Hits      int64  `json:"hits"`
+	Misses    int64  `json:"misses"`
+	Evictions int64  `json:"evictions"`
+}
@@ -0,0 +1,50 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestEnrich(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/users/_doc/1 -d '{"email":"a@example.com","name":"a"}'
+	//  curl -XPUT http://localhost:9200/_enrich/policy/users-policy -d '{"match":{"indices":"users","match_field":"email","enrich_fields":["name"]}}'
+	//  curl -XPOST http://localhost:9200/_enrich/policy/users-policy/_execute?wait_for_completion=false
+	//  curl http://localhost:9200/_enrich/_stats
+	tcs := map[string]string{
+		"7.5.0":  `{"executing_policies":[{"name":"users-policy","task":{"node":"Bu9Dc0qkQVmPSbRx9B0KjQ","id":1125,"type":"enrich","action":"policy_execution","status":{"phase":"RUNNING"},"start_time_in_millis":1576238461601,"running_time_in_nanos":7055653,"cancellable":false}}],"coordinator_stats":[{"node_id":"Bu9Dc0qkQVmPSbRx9B0KjQ","queue_size":2,"remote_requests_current":1,"remote_requests_total":42,"executed_searches_total":84}]}`,
+		"7.16.0": `{"executing_policies":[{"name":"users-policy","task":{"node":"Bu9Dc0qkQVmPSbRx9B0KjQ","id":1125,"type":"enrich","action":"policy_execution","status":{"phase":"RUNNING"},"start_time_in_millis":1576238461601,"running_time_in_nanos":7055653,"cancellable":false}}],"coordinator_stats":[{"node_id":"Bu9Dc0qkQVmPSbRx9B0KjQ","queue_size":2,"remote_requests_current":1,"remote_requests_total":42,"executed_searches_total":84}],"cache_stats":[{"node_id":"Bu9Dc0qkQVmPSbRx9B0KjQ","count":7,"hits":1000,"misses":84,"evictions":0}]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		e := NewEnrich(log.NewNopLogger(), http.DefaultClient, u)
+		esr, err := e.fetchAndDecodeEnrichStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode enrich stats: %s", err)
+		}
+		t.Logf("[%s] Enrich Stats Response: %+v", ver, esr)
+		if len(esr.ExecutingPolicies) != 1 || esr.ExecutingPolicies[0].Name != "users-policy" {
+			t.Errorf("Wrong executing policies")
+		}
+		if len(esr.CoordinatorStats) != 1 || esr.CoordinatorStats[0].QueueSize != 2 || esr.CoordinatorStats[0].RemoteRequestsTotal != 42 {
+			t.Errorf("Wrong coordinator stats")
+		}
+		if ver == "7.16.0" && (len(esr.CacheStats) != 1 || esr.CacheStats[0].Hits != 1000) {
+			t.Errorf("Wrong cache stats")
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// fielddata combines the fielddata usage per index and field and per node and field
+type fielddata struct {
+	Indices fielddataStatsResponse
+	Nodes   catFielddataResponse
+}
+
+// Fielddata information struct
+type Fielddata struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	indexFieldMemory *prometheus.Desc
+	nodeFieldMemory  *prometheus.Desc
+}
+
+func init() {
+	registerCollector("fielddata", defaultDisabled, "Export fielddata memory usage per field.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewFielddata(logger, client, url)
+	})
+}
+
+// NewFielddata defines Fielddata Prometheus metrics
+func NewFielddata(logger log.Logger, client *http.Client, url *url.URL) *Fielddata {
+	subsystem := "fielddata"
+
+	return &Fielddata{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch fielddata endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch fielddata scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		indexFieldMemory: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "index_field_memory_bytes"),
+			"Fielddata memory usage of the field across all shards of the index in bytes",
+			[]string{"index", "field"}, nil,
+		),
+		nodeFieldMemory: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "node_field_memory_bytes"),
+			"Fielddata memory usage of the field on the node in bytes",
+			[]string{"node", "field"}, nil,
+		),
+	}
+}
+
+// Describe add Fielddata metrics descriptions
+func (f *Fielddata) Describe(ch chan<- *prometheus.Desc) {
+	ch <- f.indexFieldMemory
+	ch <- f.nodeFieldMemory
+	ch <- f.up.Desc()
+	ch <- f.totalScrapes.Desc()
+	ch <- f.jsonParseFailures.Desc()
+}
+
+func (f *Fielddata) getAndParseURL(u *url.URL, data interface{}) error {
+	res, err := f.client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(f.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		f.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+func (f *Fielddata) fetchAndDecodeFielddata() (fielddata, error) {
+	var fr fielddata
+
+	u := *f.url
+	u.Path = path.Join(u.Path, "/_stats/fielddata")
+	u.RawQuery = "fields=*"
+	if err := f.getAndParseURL(&u, &fr.Indices); err != nil {
+		return fr, err
+	}
+
+	u = *f.url
+	u.Path = path.Join(u.Path, "/_cat/fielddata")
+	u.RawQuery = "format=json&bytes=b"
+	if err := f.getAndParseURL(&u, &fr.Nodes); err != nil {
+		return fr, err
+	}
+
+	return fr, nil
+}
+
+// Collect gets Fielddata metric values
+func (f *Fielddata) Collect(ch chan<- prometheus.Metric) {
+	f.totalScrapes.Inc()
+	defer func() {
+		ch <- f.up
+		ch <- f.totalScrapes
+		ch <- f.jsonParseFailures
+	}()
+
+	fr, err := f.fetchAndDecodeFielddata()
+	if err != nil {
+		f.up.Set(0)
+		_ = level.Warn(f.logger).Log(
+			"msg", "failed to fetch and decode fielddata",
+			"err", err,
+		)
+		return
+	}
+	f.up.Set(1)
+
+	for index, stats := range fr.Indices.Indices {
+		for field, fieldStats := range stats.Total.Fielddata.Fields {
+			ch <- prometheus.MustNewConstMetric(
+				f.indexFieldMemory,
+				prometheus.GaugeValue,
+				float64(fieldStats.MemorySizeInBytes),
+				index, field,
+			)
+		}
+	}
+	for _, node := range fr.Nodes {
+		size, err := strconv.ParseFloat(node.Size, 64)
+		if err != nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			f.nodeFieldMemory,
+			prometheus.GaugeValue,
+			size,
+			node.Node, node.Field,
+		)
+	}
+}
@@ -0,0 +1,38 @@
+package collector
+
+// fielddataStatsResponse is a representation of the Elasticsearch _stats/fielddata endpoint
+type fielddataStatsResponse struct {
+	Indices map[string]fielddataIndexResponse `json:"indices"`
+}
+
+// fielddataIndexResponse defines the fielddata stats of a single index
+type fielddataIndexResponse struct {
+	Total struct {
+		Fielddata fielddataDetailResponse `json:"fielddata"`
+	} `json:"total"`
+}
+
+// fielddataDetailResponse defines the fielddata usage broken down by field
+type fielddataDetailResponse struct {
+	MemorySizeInBytes int64                             `json:"memory_size_in_bytes"`
+	Evictions         int64                             `json:"evictions"`
+	Fields            map[string]fielddataFieldResponse `json:"fields"`
+}
+
+// fielddataFieldResponse defines the fielddata usage of a single field
+type fielddataFieldResponse struct {
+	MemorySizeInBytes int64 `json:"memory_size_in_bytes"`
+}
+
+// catFielddataResponse is a representation of the Elasticsearch _cat/fielddata endpoint
+type catFielddataResponse []catFielddataFieldResponse
+
+// catFielddataFieldResponse defines the fielddata usage of a field on a single node
+type catFielddataFieldResponse struct {
+	ID    string `json:"id"`
+	Host  string `json:"host"`
+	IP    string `json:"ip"`
+	Node  string `json:"node"`
+	Field string `json:"field"`
+	Size  string `json:"size"`
+}
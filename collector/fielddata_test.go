@@ -0,0 +1,53 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestFielddata(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1 -H "Content-Type: application/json" -d '{"mappings":{"properties":{"title":{"type":"text","fielddata":true}}}}'
+	//  curl -XPUT http://localhost:9200/foo_1/_doc/1 -H "Content-Type: application/json" -d '{"title":"abc","content":"hello"}'
+	//  curl http://localhost:9200/foo_1/_search -H "Content-Type: application/json" -d '{"aggs":{"titles":{"terms":{"field":"title"}}}}'
+	//  curl http://localhost:9200/_stats/fielddata?fields=*
+	//  curl http://localhost:9200/_cat/fielddata?format=json&bytes=b
+	tcs := map[string]map[string]string{
+		"7.10.0": {
+			"/_stats/fielddata": `{"_shards":{"total":2,"successful":1,"failed":0},"_all":{"primaries":{"fielddata":{"memory_size_in_bytes":472,"evictions":0,"fields":{"title":{"memory_size_in_bytes":472}}}},"total":{"fielddata":{"memory_size_in_bytes":472,"evictions":0,"fields":{"title":{"memory_size_in_bytes":472}}}}},"indices":{"foo_1":{"uuid":"GNZ0jP9CQS2tQPfhLzdOYA","primaries":{"fielddata":{"memory_size_in_bytes":472,"evictions":0,"fields":{"title":{"memory_size_in_bytes":472}}}},"total":{"fielddata":{"memory_size_in_bytes":472,"evictions":0,"fields":{"title":{"memory_size_in_bytes":472}}}}},"foo_2":{"uuid":"7jz1vd9AQJCmWcNOx1Ed-g","primaries":{"fielddata":{"memory_size_in_bytes":0,"evictions":0}},"total":{"fielddata":{"memory_size_in_bytes":0,"evictions":0}}}}}`,
+			"/_cat/fielddata":   `[{"id":"tMTocMvQQgGCkj7QDHl3OA","host":"172.17.0.2","ip":"172.17.0.2","node":"es01","field":"title","size":"472"}]`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out[r.URL.Path])
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		f := NewFielddata(log.NewNopLogger(), http.DefaultClient, u)
+		fr, err := f.fetchAndDecodeFielddata()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode fielddata: %s", err)
+		}
+		t.Logf("[%s] Fielddata Response: %+v", ver, fr)
+		if fr.Indices.Indices["foo_1"].Total.Fielddata.Fields["title"].MemorySizeInBytes != 472 {
+			t.Errorf("Wrong fielddata memory of field title")
+		}
+		if len(fr.Indices.Indices["foo_2"].Total.Fielddata.Fields) != 0 {
+			t.Errorf("Index foo_2 should not use fielddata")
+		}
+		if len(fr.Nodes) != 1 || fr.Nodes[0].Node != "es01" || fr.Nodes[0].Field != "title" || fr.Nodes[0].Size != "472" {
+			t.Errorf("Wrong fielddata per node")
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type geoIPMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(stats geoIPDownloaderStatsResponse) float64
+}
+
+// GeoIP information struct
+type GeoIP struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	metrics       []*geoIPMetric
+	nodeDatabases *prometheus.Desc
+}
+
+func init() {
+	registerCollector("geoip", defaultDisabled, "Export stats for the GeoIP downloader.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewGeoIP(logger, client, url)
+	})
+}
+
+// NewGeoIP defines GeoIP Prometheus metrics
+func NewGeoIP(logger log.Logger, client *http.Client, url *url.URL) *GeoIP {
+	subsystem := "ingest_geoip"
+
+	return &GeoIP{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch GeoIP stats endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch GeoIP stats scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		metrics: []*geoIPMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "successful_downloads_total"),
+					"Total number of successful GeoIP database downloads",
+					nil, nil,
+				),
+				Value: func(stats geoIPDownloaderStatsResponse) float64 {
+					return float64(stats.SuccessfulDownloads)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "failed_downloads_total"),
+					"Total number of failed GeoIP database downloads",
+					nil, nil,
+				),
+				Value: func(stats geoIPDownloaderStatsResponse) float64 {
+					return float64(stats.FailedDownloads)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "download_time_seconds_total"),
+					"Total time spent downloading GeoIP databases in seconds",
+					nil, nil,
+				),
+				Value: func(stats geoIPDownloaderStatsResponse) float64 {
+					return float64(stats.TotalDownloadTime) / 1000
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "skipped_updates_total"),
+					"Total number of skipped GeoIP database updates",
+					nil, nil,
+				),
+				Value: func(stats geoIPDownloaderStatsResponse) float64 {
+					return float64(stats.SkippedUpdates)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "databases"),
+					"Number of available GeoIP databases",
+					nil, nil,
+				),
+				Value: func(stats geoIPDownloaderStatsResponse) float64 {
+					return float64(stats.DatabasesCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "expired_databases"),
+					"Number of GeoIP databases which have not been updated for 30 days or longer",
+					nil, nil,
+				),
+				Value: func(stats geoIPDownloaderStatsResponse) float64 {
+					return float64(stats.ExpiredDatabases)
+				},
+			},
+		},
+		nodeDatabases: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "node_databases"),
+			"Number of GeoIP databases loaded on the node",
+			[]string{"node"}, nil,
+		),
+	}
+}
+
+// Describe add GeoIP metrics descriptions
+func (g *GeoIP) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range g.metrics {
+		ch <- metric.Desc
+	}
+	ch <- g.nodeDatabases
+	ch <- g.up.Desc()
+	ch <- g.totalScrapes.Desc()
+	ch <- g.jsonParseFailures.Desc()
+}
+
+func (g *GeoIP) fetchAndDecodeGeoIPStats() (geoIPStatsResponse, error) {
+	var gsr geoIPStatsResponse
+
+	u := *g.url
+	u.Path = path.Join(u.Path, "/_ingest/geoip/stats")
+
+	res, err := g.client.Get(u.String())
+	if err != nil {
+		return gsr, fmt.Errorf("failed to get GeoIP stats from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(g.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return gsr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&gsr); err != nil {
+		g.jsonParseFailures.Inc()
+		return gsr, err
+	}
+	return gsr, nil
+}
+
+// Collect gets GeoIP metric values
+func (g *GeoIP) Collect(ch chan<- prometheus.Metric) {
+	g.totalScrapes.Inc()
+	defer func() {
+		ch <- g.up
+		ch <- g.totalScrapes
+		ch <- g.jsonParseFailures
+	}()
+
+	gsr, err := g.fetchAndDecodeGeoIPStats()
+	if err != nil {
+		g.up.Set(0)
+		_ = level.Warn(g.logger).Log(
+			"msg", "failed to fetch and decode GeoIP stats",
+			"err", err,
+		)
+		return
+	}
+	g.up.Set(1)
+
+	for _, metric := range g.metrics {
+		ch <- prometheus.MustNewConstMetric(
+			metric.Desc,
+			metric.Type,
+			metric.Value(gsr.Stats),
+		)
+	}
+	for node, stats := range gsr.Nodes {
+		ch <- prometheus.MustNewConstMetric(
+			g.nodeDatabases,
+			prometheus.GaugeValue,
+			float64(len(stats.Databases)),
+			node,
+		)
+	}
+}
@@ -0,0 +1,25 @@
+package collector
+
+// geoIPStatsResponse is a representation of the Elasticsearch _ingest/geoip/stats endpoint
+type geoIPStatsResponse struct {
+	Stats geoIPDownloaderStatsResponse      `json:"stats"`
+	Nodes map[string]geoIPNodeStatsResponse `json:"nodes"`
+}
+
+// geoIPDownloaderStatsResponse defines the cluster wide GeoIP downloader stats
+type geoIPDownloaderStatsResponse struct {
+	SuccessfulDownloads int64 `json:"successful_downloads"`
+	FailedDownloads     int64 `json:"failed_downloads"`
+	TotalDownloadTime   int64 `json:"total_download_time"`
+	DatabasesCount      int64 `json:"databases_count"`
+	SkippedUpdates      int64 `json:"skipped_updates"`
+	ExpiredDatabases    int64 `json:"expired_databases"`
+}
+
+// geoIPNodeStatsResponse defines the GeoIP databases loaded on a node
+type geoIPNodeStatsResponse struct {
+	Databases []struct {
+		Name string `json:"name"`
+	} `json:"databases"`
+	FilesInTemp []string `json:"files_in_temp"`
+}
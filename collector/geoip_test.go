@@ -0,0 +1,47 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestGeoIP(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_ingest/geoip/stats
+	tcs := map[string]string{
+		"7.14.0": `{"stats":{"successful_downloads":3,"failed_downloads":1,"total_download_time":5981,"databases_count":3,"skipped_updates":0},"nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"databases":[{"name":"GeoLite2-ASN.mmdb"},{"name":"GeoLite2-City.mmdb"},{"name":"GeoLite2-Country.mmdb"}],"files_in_temp":[]}}}`,
+		"8.5.0":  `{"stats":{"successful_downloads":3,"failed_downloads":1,"total_download_time":5981,"databases_count":3,"skipped_updates":0,"expired_databases":1},"nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"databases":[{"name":"GeoLite2-ASN.mmdb"},{"name":"GeoLite2-City.mmdb"},{"name":"GeoLite2-Country.mmdb"}],"files_in_temp":["GeoLite2-ASN.mmdb_COPYRIGHT.txt"]}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		g := NewGeoIP(log.NewNopLogger(), http.DefaultClient, u)
+		gsr, err := g.fetchAndDecodeGeoIPStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode GeoIP stats: %s", err)
+		}
+		t.Logf("[%s] GeoIP Stats Response: %+v", ver, gsr)
+		if gsr.Stats.SuccessfulDownloads != 3 || gsr.Stats.FailedDownloads != 1 {
+			t.Errorf("Wrong download stats")
+		}
+		if len(gsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"].Databases) != 3 {
+			t.Errorf("Wrong number of node databases")
+		}
+		if ver == "8.5.0" && (gsr.Stats.DatabasesCount != 3 || gsr.Stats.ExpiredDatabases != 1) {
+			t.Errorf("Wrong database stats")
+		}
+	}
+}
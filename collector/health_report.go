@@ -0,0 +1,156 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// healthReportStatuses maps the health report status to the value of the status metrics
+var healthReportStatuses = map[string]float64{
+	"green":   0,
+	"yellow":  1,
+	"red":     2,
+	"unknown": 3,
+}
+
+// HealthReport information struct
+type HealthReport struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	status          *prometheus.Desc
+	indicatorStatus *prometheus.Desc
+	diagnoses       *prometheus.Desc
+}
+
+func init() {
+	registerCollector("health_report", defaultDisabled, "Export the status of the health report indicators.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewHealthReport(logger, client, url)
+	})
+}
+
+// NewHealthReport defines Health Report Prometheus metrics
+func NewHealthReport(logger log.Logger, client *http.Client, url *url.URL) *HealthReport {
+	subsystem := "health_report"
+
+	return &HealthReport{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch health report endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch health report scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		status: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "status"),
+			"Overall health of the cluster (0 green, 1 yellow, 2 red, 3 unknown)",
+			nil, nil,
+		),
+		indicatorStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "indicator_status"),
+			"Health of the indicator (0 green, 1 yellow, 2 red, 3 unknown)",
+			[]string{"indicator"}, nil,
+		),
+		diagnoses: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "indicator_diagnoses"),
+			"Number of diagnoses reported by the indicator",
+			[]string{"indicator"}, nil,
+		),
+	}
+}
+
+// Describe add Health Report metrics descriptions
+func (h *HealthReport) Describe(ch chan<- *prometheus.Desc) {
+	ch <- h.status
+	ch <- h.indicatorStatus
+	ch <- h.diagnoses
+	ch <- h.up.Desc()
+	ch <- h.totalScrapes.Desc()
+	ch <- h.jsonParseFailures.Desc()
+}
+
+func (h *HealthReport) fetchAndDecodeHealthReport() (healthReportResponse, error) {
+	var hrr healthReportResponse
+
+	u := *h.url
+	u.Path = path.Join(u.Path, "/_health_report")
+
+	res, err := h.client.Get(u.String())
+	if err != nil {
+		return hrr, fmt.Errorf("failed to get health report from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(h.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return hrr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&hrr); err != nil {
+		h.jsonParseFailures.Inc()
+		return hrr, err
+	}
+	return hrr, nil
+}
+
+// Collect gets Health Report metric values
+func (h *HealthReport) Collect(ch chan<- prometheus.Metric) {
+	h.totalScrapes.Inc()
+	defer func() {
+		ch <- h.up
+		ch <- h.totalScrapes
+		ch <- h.jsonParseFailures
+	}()
+
+	hrr, err := h.fetchAndDecodeHealthReport()
+	if err != nil {
+		h.up.Set(0)
+		_ = level.Warn(h.logger).Log(
+			"msg", "failed to fetch and decode health report",
+			"err", err,
+		)
+		return
+	}
+	h.up.Set(1)
+
+	if status, ok := healthReportStatuses[hrr.Status]; ok {
+		ch <- prometheus.MustNewConstMetric(h.status, prometheus.GaugeValue, status)
+	}
+	for name, indicator := range hrr.Indicators {
+		status, ok := healthReportStatuses[indicator.Status]
+		if !ok {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(h.indicatorStatus, prometheus.GaugeValue, status, name)
+		ch <- prometheus.MustNewConstMetric(h.diagnoses, prometheus.GaugeValue, float64(len(indicator.Diagnosis)), name)
+	}
+}
@@ -0,0 +1,22 @@
+package collector
+
+// healthReportResponse is a representation of the Elasticsearch _health_report endpoint
+type healthReportResponse struct {
+	Status      string                                   `json:"status"`
+	ClusterName string                                   `json:"cluster_name"`
+	Indicators  map[string]healthReportIndicatorResponse `json:"indicators"`
+}
+
+// healthReportIndicatorResponse defines a single health indicator like master_is_stable or disk
+type healthReportIndicatorResponse struct {
+	Status    string                          `json:"status"`
+	Symptom   string                          `json:"symptom"`
+	Diagnosis []healthReportDiagnosisResponse `json:"diagnosis"`
+}
+
+// healthReportDiagnosisResponse defines a diagnosis with the cause and action of an unhealthy indicator
+type healthReportDiagnosisResponse struct {
+	ID     string `json:"id"`
+	Cause  string `json:"cause"`
+	Action string `json:"action"`
+}
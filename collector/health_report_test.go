@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestHealthReport(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1 -H "Content-Type: application/json" -d '{"settings":{"number_of_replicas":1}}'
+	//  curl http://localhost:9200/_health_report
+	tcs := map[string]string{
+		"8.7.0": `{"status":"yellow","cluster_name":"docker-cluster","indicators":{"master_is_stable":{"status":"green","symptom":"The cluster has a stable master node","details":{"current_master":{"node_id":"tMTocMvQQgGCkj7QDHl3OA","name":"es01"},"recent_masters":[{"node_id":"tMTocMvQQgGCkj7QDHl3OA","name":"es01"}]}},"repository_integrity":{"status":"green","symptom":"No snapshot repositories configured."},"shards_availability":{"status":"yellow","symptom":"This cluster has 1 unavailable replica shard.","details":{"unassigned_replicas":1},"impacts":[{"id":"elasticsearch:health:shards_availability:impact:replica_unassigned","severity":2,"description":"Searches might be slower than usual. Fewer redundant copies of the data exist on 1 index [foo_1].","impact_areas":["search"]}],"diagnosis":[{"id":"elasticsearch:health:shards_availability:diagnosis:increase_tier_capacity_for_allocations:tier:data_content","cause":"Elasticsearch isn't allowed to allocate some shards from these indices to any of the nodes in the desired data tier.","action":"Increase the number of nodes in this tier or decrease the number of replica shards in the affected indices.","help_url":"https://ela.st/tier-capacity","affected_resources":{"indices":["foo_1"]}}]},"disk":{"status":"green","symptom":"The cluster has enough available disk space."},"ilm":{"status":"green","symptom":"Index Lifecycle Management is running"},"slm":{"status":"green","symptom":"No Snapshot Lifecycle Management policies configured"}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		h := NewHealthReport(log.NewNopLogger(), http.DefaultClient, u)
+		hrr, err := h.fetchAndDecodeHealthReport()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode health report: %s", err)
+		}
+		t.Logf("[%s] Health Report Response: %+v", ver, hrr)
+		if healthReportStatuses[hrr.Status] != 1 {
+			t.Errorf("Cluster health should be yellow")
+		}
+		if len(hrr.Indicators) != 6 {
+			t.Errorf("Wrong number of health indicators")
+		}
+		shards := hrr.Indicators["shards_availability"]
+		if healthReportStatuses[shards.Status] != 1 || len(shards.Diagnosis) != 1 {
+			t.Errorf("Wrong shards availability indicator")
+		}
+		if master := hrr.Indicators["master_is_stable"]; healthReportStatuses[master.Status] != 0 || len(master.Diagnosis) != 0 {
+			t.Errorf("Wrong master stability indicator")
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package collector
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	defaultHotThreadLabels = []string{"node", "type", "thread_pool"}
+
+	// hotThreadTypes are the kinds of hot threads, sampled by cpu time, wait time and blocked time
+	hotThreadTypes = []string{"cpu", "wait", "block"}
+
+	// hotThreadRegexp matches the summary line of a single hot thread, e.g.
+	//  12.5% (62.5ms out of 500ms) cpu usage by thread 'elasticsearch[es01][write][T#3]'
+	// Since Elasticsearch 8.0 cpu usage is broken down after the percentage, e.g.
+	//  12.5% [cpu=12.5%, other=0.0%] (62.5ms out of 500ms) cpu usage by thread 'elasticsearch[es01][write][T#3]'
+	hotThreadRegexp = regexp.MustCompile(`^\s*([0-9.]+)%(?: \[[^\]]*\])? \(.*\) (cpu|wait|block) usage by thread '(.*)'`)
+
+	// hotThreadPoolRegexp extracts the thread pool from the name of an Elasticsearch thread
+	hotThreadPoolRegexp = regexp.MustCompile(`\]\[([^\[\]]+)\]\[T#[0-9]+\]$`)
+)
+
+// hotThreadKey groups the hot threads of a node by type and thread pool
+type hotThreadKey struct {
+	node, threadType, threadPool string
+}
+
+// hotThreadStats sums up the hot threads of a single group
+type hotThreadStats struct {
+	threads float64
+	usage   float64
+}
+
+type hotThreadMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(stats hotThreadStats) float64
+}
+
+// hotThreadPool returns the thread pool of a thread, threads outside of the thread pools are reported as other
+func hotThreadPool(thread string) string {
+	if match := hotThreadPoolRegexp.FindStringSubmatch(thread); match != nil {
+		return match[1]
+	}
+	return "other"
+}
+
+// parseHotThreads sums up the hot threads reported by the text output of the _nodes/hot_threads endpoint
+func parseHotThreads(r io.Reader) (map[hotThreadKey]hotThreadStats, error) {
+	threads := make(map[hotThreadKey]hotThreadStats)
+	var node string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// every node is introduced by a line like ::: {es01}{Bu9Dc0qkQVmPSbRx9B0KjQ}{...}
+		if strings.HasPrefix(line, ":::") {
+			node = strings.TrimSpace(strings.TrimPrefix(line, ":::"))
+			if strings.HasPrefix(node, "{") {
+				if i := strings.Index(node, "}"); i > 0 {
+					node = node[1:i]
+				}
+			}
+			continue
+		}
+		match := hotThreadRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		usage, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		key := hotThreadKey{node: node, threadType: match[2], threadPool: hotThreadPool(match[3])}
+		stats := threads[key]
+		stats.threads++
+		stats.usage += usage / 100
+		threads[key] = stats
+	}
+	return threads, scanner.Err()
+}
+
+// HotThreads periodically samples the hot threads of all nodes and exports the number of
+// hot threads per node, type and thread pool
+type HotThreads struct {
+	logger   log.Logger
+	client   *http.Client
+	url      *url.URL
+	interval time.Duration
+
+	mutex   sync.RWMutex
+	threads map[hotThreadKey]hotThreadStats
+	ts      time.Time
+
+	up           prometheus.Gauge
+	totalScrapes prometheus.Counter
+
+	lastRun *prometheus.Desc
+	metrics []*hotThreadMetric
+}
+
+var (
+	hotThreadsInterval = kingpin.Flag("es.hot_threads.interval",
+		"Hot threads sampling interval").
+		Default("5m").Envar("ES_HOT_THREADS_INTERVAL").Duration()
+)
+
+func init() {
+	registerCollector("hot_threads", defaultDisabled, "Periodically sample the hot threads of all nodes.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewHotThreads(logger, client, url, *hotThreadsInterval)
+	})
+}
+
+// NewHotThreads defines Hot Threads Prometheus metrics
+func NewHotThreads(logger log.Logger, client *http.Client, url *url.URL, interval time.Duration) *HotThreads {
+	subsystem := "hot_threads"
+
+	return &HotThreads{
+		logger:   logger,
+		client:   client,
+		url:      url,
+		interval: interval,
+		threads:  make(map[hotThreadKey]hotThreadStats),
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last sampling of the ElasticSearch hot threads endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch hot threads samplings.",
+		}),
+		lastRun: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "last_run_timestamp_seconds"),
+			"Timestamp of the last hot threads sampling",
+			nil, nil,
+		),
+		metrics: []*hotThreadMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "threads"),
+					"Number of hot threads of the thread pool in the last sampling",
+					defaultHotThreadLabels, nil,
+				),
+				Value: func(stats hotThreadStats) float64 {
+					return stats.threads
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "usage_ratio"),
+					"Sum of the cpu, wait or block time of the hot threads of the thread pool as ratio of the sampling interval",
+					defaultHotThreadLabels, nil,
+				),
+				Value: func(stats hotThreadStats) float64 {
+					return stats.usage
+				},
+			},
+		},
+	}
+}
+
+// Describe add Hot Threads metrics descriptions
+func (h *HotThreads) Describe(ch chan<- *prometheus.Desc) {
+	ch <- h.lastRun
+	for _, metric := range h.metrics {
+		ch <- metric.Desc
+	}
+	ch <- h.up.Desc()
+	ch <- h.totalScrapes.Desc()
+}
+
+func (h *HotThreads) fetchAndParseHotThreads(threadType string) (map[hotThreadKey]hotThreadStats, error) {
+	u := *h.url
+	u.Path = path.Join(u.Path, "/_nodes/hot_threads")
+	u.RawQuery = "threads=10&ignore_idle_threads=true&type=" + threadType
+
+	res, err := h.client.Get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hot threads from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(h.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	return parseHotThreads(res.Body)
+}
+
+// sample fetches the hot threads of every type once and stores the results
+func (h *HotThreads) sample() {
+	h.totalScrapes.Inc()
+
+	start := time.Now()
+	threads := make(map[hotThreadKey]hotThreadStats)
+	for _, threadType := range hotThreadTypes {
+		typeThreads, err := h.fetchAndParseHotThreads(threadType)
+		if err != nil {
+			h.up.Set(0)
+			_ = level.Warn(h.logger).Log(
+				"msg", "failed to fetch and parse hot threads",
+				"type", threadType,
+				"err", err,
+			)
+			return
+		}
+		for key, stats := range typeThreads {
+			threads[key] = stats
+		}
+	}
+	h.up.Set(1)
+
+	h.mutex.Lock()
+	h.threads = threads
+	h.ts = start
+	h.mutex.Unlock()
+}
+
+// Run starts the sampling loop. The loop is terminated upon ctx cancellation, without a positive interval
+// the hot threads are sampled once
+func (h *HotThreads) Run(ctx context.Context) {
+	go func() {
+		h.sample()
+		if h.interval <= 0 {
+			_ = level.Info(h.logger).Log(
+				"msg", "no periodic hot threads sampling requested",
+			)
+			return
+		}
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				_ = level.Info(h.logger).Log(
+					"msg", "context cancelled, exiting hot threads loop",
+					"err", ctx.Err(),
+				)
+				return
+			case <-ticker.C:
+			}
+			h.sample()
+		}
+	}()
+}
+
+// Collect gets Hot Threads metric values
+func (h *HotThreads) Collect(ch chan<- prometheus.Metric) {
+	ch <- h.up
+	ch <- h.totalScrapes
+
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	if h.ts.IsZero() {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		h.lastRun,
+		prometheus.GaugeValue,
+		float64(h.ts.Unix()),
+	)
+	for key, stats := range h.threads {
+		for _, metric := range h.metrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(stats),
+				key.node, key.threadType, key.threadPool,
+			)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestHotThreads(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/hot_threads?threads=10&ignore_idle_threads=true&type=cpu
+	tcs := map[string]map[string]string{
+		"7.10.0": {
+			"cpu": `::: {es01}{Bu9Dc0qkQVmPSbRx9B0KjQ}{kYV5V0yMQVKnE_dfZ4Sv3Q}{172.18.0.2}{172.18.0.2:9300}{cdhilmrstw}{ml.machine_memory=8235241472, xpack.installed=true, transform.node=true, ml.max_open_jobs=20}
+   Hot threads at 2020-11-20T10:12:32.527Z, interval=500ms, busiestThreads=10, ignoreIdleThreads=true:
+   
+   25.3% (126.5ms out of 500ms) cpu usage by thread 'elasticsearch[es01][write][T#3]'
+     2/10 snapshots sharing following 26 elements
+       app//org.elasticsearch.index.engine.InternalEngine.index(InternalEngine.java:935)
+   
+   12.1% (60.5ms out of 500ms) cpu usage by thread 'elasticsearch[es01][write][T#1]'
+     unique snapshot
+   
+    4.0% (20ms out of 500ms) cpu usage by thread 'ticker-schedule-trigger-engine'
+     10/10 snapshots sharing following 2 elements
+       java.base@15.0.1/java.lang.Thread.sleep(Native Method)
+
+::: {es02}{kW0o1ULGRgOoPa8RyrOcZA}{QpSYsU2MTuKy8gE2o2mTeQ}{172.18.0.3}{172.18.0.3:9300}{cdhilmrstw}{ml.machine_memory=8235241472, xpack.installed=true, transform.node=true, ml.max_open_jobs=20}
+   Hot threads at 2020-11-20T10:12:32.528Z, interval=500ms, busiestThreads=10, ignoreIdleThreads=true:
+   
+   50.0% (250ms out of 500ms) cpu usage by thread 'elasticsearch[es02][search][T#7]'
+     unique snapshot
+`,
+			"wait": `::: {es01}{Bu9Dc0qkQVmPSbRx9B0KjQ}{kYV5V0yMQVKnE_dfZ4Sv3Q}{172.18.0.2}{172.18.0.2:9300}{cdhilmrstw}{ml.machine_memory=8235241472, xpack.installed=true, transform.node=true, ml.max_open_jobs=20}
+   Hot threads at 2020-11-20T10:12:33.031Z, interval=500ms, busiestThreads=10, ignoreIdleThreads=true:
+
+::: {es02}{kW0o1ULGRgOoPa8RyrOcZA}{QpSYsU2MTuKy8gE2o2mTeQ}{172.18.0.3}{172.18.0.3:9300}{cdhilmrstw}{ml.machine_memory=8235241472, xpack.installed=true, transform.node=true, ml.max_open_jobs=20}
+   Hot threads at 2020-11-20T10:12:33.032Z, interval=500ms, busiestThreads=10, ignoreIdleThreads=true:
+`,
+			"block": `::: {es01}{Bu9Dc0qkQVmPSbRx9B0KjQ}{kYV5V0yMQVKnE_dfZ4Sv3Q}{172.18.0.2}{172.18.0.2:9300}{cdhilmrstw}{ml.machine_memory=8235241472, xpack.installed=true, transform.node=true, ml.max_open_jobs=20}
+   Hot threads at 2020-11-20T10:12:33.535Z, interval=500ms, busiestThreads=10, ignoreIdleThreads=true:
+   
+    0.2% (1ms out of 500ms) block usage by thread 'elasticsearch[es01][write][T#2]'
+     unique snapshot
+`,
+		},
+		"8.11.0": {
+			"cpu": `::: {es01}{Bu9Dc0qkQVmPSbRx9B0KjQ}{kYV5V0yMQVKnE_dfZ4Sv3Q}{es01}{172.18.0.2}{172.18.0.2:9300}{cdfhilmrstw}{8.11.0}{7000099-8500003}{ml.allocated_processors=8, ml.machine_memory=8235241472, xpack.installed=true, transform.config_version=10.0.0, ml.config_version=11.0.0, ml.max_jvm_size=4118806528, ml.allocated_processors_double=8.0}
+   Hot threads at 2023-11-20T10:12:32.527Z, interval=500ms, busiestThreads=10, ignoreIdleThreads=true:
+   
+   25.3% [cpu=25.3%, other=0.0%] (126.5ms out of 500ms) cpu usage by thread 'elasticsearch[es01][write][T#3]'
+     2/10 snapshots sharing following 26 elements
+       org.elasticsearch.server@8.11.0/org.elasticsearch.index.engine.InternalEngine.index(InternalEngine.java:1064)
+   
+   12.1% [cpu=12.1%, other=0.0%] (60.5ms out of 500ms) cpu usage by thread 'elasticsearch[es01][write][T#1]'
+     unique snapshot
+   
+    4.0% [cpu=0.0%, other=4.0%] (20ms out of 500ms) cpu usage by thread 'ticker-schedule-trigger-engine'
+     10/10 snapshots sharing following 2 elements
+       java.base@21.0.1/java.lang.Thread.sleep0(Native Method)
+
+::: {es02}{kW0o1ULGRgOoPa8RyrOcZA}{QpSYsU2MTuKy8gE2o2mTeQ}{es02}{172.18.0.3}{172.18.0.3:9300}{cdfhilmrstw}{8.11.0}{7000099-8500003}{ml.allocated_processors=8, ml.machine_memory=8235241472, xpack.installed=true, transform.config_version=10.0.0, ml.config_version=11.0.0, ml.max_jvm_size=4118806528, ml.allocated_processors_double=8.0}
+   Hot threads at 2023-11-20T10:12:32.528Z, interval=500ms, busiestThreads=10, ignoreIdleThreads=true:
+   
+   50.0% [cpu=50.0%, other=0.0%] (250ms out of 500ms) cpu usage by thread 'elasticsearch[es02][search][T#7]'
+     unique snapshot
+`,
+			"wait": `::: {es01}{Bu9Dc0qkQVmPSbRx9B0KjQ}{kYV5V0yMQVKnE_dfZ4Sv3Q}{es01}{172.18.0.2}{172.18.0.2:9300}{cdfhilmrstw}{8.11.0}{7000099-8500003}{ml.allocated_processors=8, ml.machine_memory=8235241472, xpack.installed=true, transform.config_version=10.0.0, ml.config_version=11.0.0, ml.max_jvm_size=4118806528, ml.allocated_processors_double=8.0}
+   Hot threads at 2023-11-20T10:12:33.031Z, interval=500ms, busiestThreads=10, ignoreIdleThreads=true:
+
+::: {es02}{kW0o1ULGRgOoPa8RyrOcZA}{QpSYsU2MTuKy8gE2o2mTeQ}{es02}{172.18.0.3}{172.18.0.3:9300}{cdfhilmrstw}{8.11.0}{7000099-8500003}{ml.allocated_processors=8, ml.machine_memory=8235241472, xpack.installed=true, transform.config_version=10.0.0, ml.config_version=11.0.0, ml.max_jvm_size=4118806528, ml.allocated_processors_double=8.0}
+   Hot threads at 2023-11-20T10:12:33.032Z, interval=500ms, busiestThreads=10, ignoreIdleThreads=true:
+`,
+			"block": `::: {es01}{Bu9Dc0qkQVmPSbRx9B0KjQ}{kYV5V0yMQVKnE_dfZ4Sv3Q}{es01}{172.18.0.2}{172.18.0.2:9300}{cdfhilmrstw}{8.11.0}{7000099-8500003}{ml.allocated_processors=8, ml.machine_memory=8235241472, xpack.installed=true, transform.config_version=10.0.0, ml.config_version=11.0.0, ml.max_jvm_size=4118806528, ml.allocated_processors_double=8.0}
+   Hot threads at 2023-11-20T10:12:33.535Z, interval=500ms, busiestThreads=10, ignoreIdleThreads=true:
+   
+    0.2% (1ms out of 500ms) block usage by thread 'elasticsearch[es01][write][T#2]'
+     unique snapshot
+`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/_nodes/hot_threads" {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprint(w, out[r.URL.Query().Get("type")])
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		h := NewHotThreads(log.NewNopLogger(), http.DefaultClient, u, time.Minute)
+		h.sample()
+		t.Logf("[%s] Hot Threads: %+v", ver, h.threads)
+		if h.ts.IsZero() {
+			t.Fatalf("Hot threads sampling should succeed")
+		}
+		if len(h.threads) != 4 {
+			t.Errorf("Wrong number of hot thread groups %d", len(h.threads))
+		}
+		if s := h.threads[hotThreadKey{"es01", "cpu", "write"}]; s.threads != 2 || s.usage < 0.374 || s.usage > 0.375 {
+			t.Errorf("Wrong cpu hot threads of the es01 write thread pool %+v", s)
+		}
+		if s := h.threads[hotThreadKey{"es01", "cpu", "other"}]; s.threads != 1 {
+			t.Errorf("Threads outside of thread pools should be reported as other")
+		}
+		if s := h.threads[hotThreadKey{"es02", "cpu", "search"}]; s.threads != 1 || s.usage != 0.5 {
+			t.Errorf("Wrong cpu hot threads of the es02 search thread pool %+v", s)
+		}
+		if s := h.threads[hotThreadKey{"es01", "block", "write"}]; s.threads != 1 {
+			t.Errorf("Wrong block hot threads of the es01 write thread pool %+v", s)
+		}
+	}
+}
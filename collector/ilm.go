@@ -0,0 +1,320 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ilmOperationModes = []string{"RUNNING", "STOPPING", "STOPPED"}
+)
+
+type ilmStatusMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(ilmStatus ilmStatusResponse, operationMode string) float64
+}
+
+type ilmIndexMetric struct {
+	Type   prometheus.ValueType
+	Desc   *prometheus.Desc
+	Value  func(index ilmIndexResponse, now time.Time) float64
+	Labels func(indexName string, index ilmIndexResponse) []string
+}
+
+// ilmErrorKey groups indices stuck in the ERROR step by policy and action
+type ilmErrorKey struct {
+	policy string
+	action string
+}
+
+// ILM information struct
+type ILM struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	statusMetric     *ilmStatusMetric
+	indicesErrors    *prometheus.Desc
+	policyIndices    *prometheus.Desc
+	unmanagedIndices *prometheus.Desc
+	ilmIndexMetrics  []*ilmIndexMetric
+}
+
+func init() {
+	registerCollector("ilm", defaultDisabled, "Export stats for index lifecycle management.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewILM(logger, client, url)
+	})
+}
+
+// NewILM defines ILM Prometheus metrics
+func NewILM(logger log.Logger, client *http.Client, url *url.URL) *ILM {
+	subsystem := "ilm"
+
+	return &ILM{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, "ilm_stats", "up"),
+			Help: "Was the last scrape of the ElasticSearch ILM endpoints successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, "ilm_stats", "total_scrapes"),
+			Help: "Current total ElasticSearch ILM scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, "ilm_stats", "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		statusMetric: &ilmStatusMetric{
+			Type: prometheus.GaugeValue,
+			Desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, subsystem, "status"),
+				"Current operation mode of ILM",
+				[]string{"operation_mode"}, nil,
+			),
+			Value: func(ilmStatus ilmStatusResponse, operationMode string) float64 {
+				if ilmStatus.OperationMode == operationMode {
+					return 1
+				}
+				return 0
+			},
+		},
+		indicesErrors: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "indices_error"),
+			"Number of indices in the ILM ERROR step",
+			[]string{"policy", "action"}, nil,
+		),
+		policyIndices: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "policy_indices"),
+			"Number of indices managed by the ILM policy",
+			[]string{"policy"}, nil,
+		),
+		unmanagedIndices: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "unmanaged_indices"),
+			"Number of indices not managed by any ILM policy",
+			nil, nil,
+		),
+		ilmIndexMetrics: []*ilmIndexMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_phase"),
+					"Current ILM phase of the index",
+					[]string{"index", "policy", "phase"}, nil,
+				),
+				Value: func(index ilmIndexResponse, now time.Time) float64 {
+					return 1
+				},
+				Labels: func(indexName string, index ilmIndexResponse) []string {
+					return []string{indexName, index.Policy, index.Phase}
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_phase_seconds"),
+					"Time the index has spent in its current ILM phase in seconds",
+					[]string{"index", "policy", "phase"}, nil,
+				),
+				Value: func(index ilmIndexResponse, now time.Time) float64 {
+					return millisSince(now, index.PhaseTimeMillis)
+				},
+				Labels: func(indexName string, index ilmIndexResponse) []string {
+					return []string{indexName, index.Policy, index.Phase}
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_step_seconds"),
+					"Time the index has spent in its current ILM step in seconds",
+					[]string{"index", "policy", "phase", "action", "step"}, nil,
+				),
+				Value: func(index ilmIndexResponse, now time.Time) float64 {
+					return millisSince(now, index.StepTimeMillis)
+				},
+				Labels: func(indexName string, index ilmIndexResponse) []string {
+					return []string{indexName, index.Policy, index.Phase, index.Action, index.Step}
+				},
+			},
+		},
+	}
+}
+
+// millisSince returns the seconds elapsed between a millisecond timestamp and now
+func millisSince(now time.Time, millis int64) float64 {
+	if millis == 0 {
+		return 0
+	}
+	return now.Sub(time.Unix(0, millis*int64(time.Millisecond))).Seconds()
+}
+
+// ilmPolicyIndices counts the managed indices per ILM policy and the unmanaged indices
+func ilmPolicyIndices(ier ilmExplainResponse) (map[string]int, int) {
+	policies := make(map[string]int)
+	unmanaged := 0
+	for _, index := range ier.Indices {
+		if !index.Managed {
+			unmanaged++
+			continue
+		}
+		policies[index.Policy]++
+	}
+	return policies, unmanaged
+}
+
+// Describe add ILM metrics descriptions
+func (i *ILM) Describe(ch chan<- *prometheus.Desc) {
+	ch <- i.statusMetric.Desc
+	ch <- i.indicesErrors
+	ch <- i.policyIndices
+	ch <- i.unmanagedIndices
+	for _, metric := range i.ilmIndexMetrics {
+		ch <- metric.Desc
+	}
+	ch <- i.up.Desc()
+	ch <- i.totalScrapes.Desc()
+	ch <- i.jsonParseFailures.Desc()
+}
+
+func (i *ILM) getAndParseURL(u *url.URL, data interface{}) error {
+	res, err := i.client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(i.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		i.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+func (i *ILM) fetchAndDecodeILMStatus() (ilmStatusResponse, error) {
+	var isr ilmStatusResponse
+
+	u := *i.url
+	u.Path = path.Join(u.Path, "/_ilm/status")
+	err := i.getAndParseURL(&u, &isr)
+	return isr, err
+}
+
+func (i *ILM) fetchAndDecodeILMExplain() (ilmExplainResponse, error) {
+	var ier ilmExplainResponse
+
+	u := *i.url
+	u.Path = path.Join(u.Path, "/_all/_ilm/explain")
+	err := i.getAndParseURL(&u, &ier)
+	return ier, err
+}
+
+// Collect gets ILM metric values
+func (i *ILM) Collect(ch chan<- prometheus.Metric) {
+	i.totalScrapes.Inc()
+	defer func() {
+		ch <- i.up
+		ch <- i.totalScrapes
+		ch <- i.jsonParseFailures
+	}()
+
+	ilmStatusResp, err := i.fetchAndDecodeILMStatus()
+	if err != nil {
+		i.up.Set(0)
+		_ = level.Warn(i.logger).Log(
+			"msg", "failed to fetch and decode ILM status",
+			"err", err,
+		)
+		return
+	}
+
+	ilmExplainResp, err := i.fetchAndDecodeILMExplain()
+	if err != nil {
+		i.up.Set(0)
+		_ = level.Warn(i.logger).Log(
+			"msg", "failed to fetch and decode ILM explain",
+			"err", err,
+		)
+		return
+	}
+	i.up.Set(1)
+
+	for _, operationMode := range ilmOperationModes {
+		ch <- prometheus.MustNewConstMetric(
+			i.statusMetric.Desc,
+			i.statusMetric.Type,
+			i.statusMetric.Value(ilmStatusResp, operationMode),
+			operationMode,
+		)
+	}
+
+	now := time.Now()
+	indicesErrors := make(map[ilmErrorKey]int)
+	for indexName, index := range ilmExplainResp.Indices {
+		if !index.Managed {
+			continue
+		}
+		for _, metric := range i.ilmIndexMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(index, now),
+				metric.Labels(indexName, index)...,
+			)
+		}
+		if index.Step == "ERROR" {
+			indicesErrors[ilmErrorKey{policy: index.Policy, action: index.Action}]++
+		}
+	}
+	for key, count := range indicesErrors {
+		ch <- prometheus.MustNewConstMetric(
+			i.indicesErrors,
+			prometheus.GaugeValue,
+			float64(count),
+			key.policy, key.action,
+		)
+	}
+
+	policyIndices, unmanagedIndices := ilmPolicyIndices(ilmExplainResp)
+	for policy, count := range policyIndices {
+		ch <- prometheus.MustNewConstMetric(
+			i.policyIndices,
+			prometheus.GaugeValue,
+			float64(count),
+			policy,
+		)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		i.unmanagedIndices,
+		prometheus.GaugeValue,
+		float64(unmanagedIndices),
+	)
+}
@@ -0,0 +1,25 @@
+package collector
+
+// ilmStatusResponse is a representation of the Elasticsearch _ilm/status endpoint
+type ilmStatusResponse struct {
+	OperationMode string `json:"operation_mode"`
+}
+
+// ilmExplainResponse is a representation of the Elasticsearch _ilm/explain endpoint
+type ilmExplainResponse struct {
+	Indices map[string]ilmIndexResponse `json:"indices"`
+}
+
+// ilmIndexResponse defines the ILM explain information of a single index
+type ilmIndexResponse struct {
+	Index            string `json:"index"`
+	Managed          bool   `json:"managed"`
+	Policy           string `json:"policy"`
+	Phase            string `json:"phase"`
+	PhaseTimeMillis  int64  `json:"phase_time_millis"`
+	Action           string `json:"action"`
+	ActionTimeMillis int64  `json:"action_time_millis"`
+	Step             string `json:"step"`
+	StepTimeMillis   int64  `json:"step_time_millis"`
+	FailedStep       string `json:"failed_step"`
+}
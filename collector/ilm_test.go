@@ -0,0 +1,77 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestILM(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_ilm/policy/my_policy -d '{"policy":{"phases":{"hot":{"actions":{"rollover":{"max_age":"1d"}}}}}}'
+	//  curl -XPUT http://localhost:9200/twitter -d '{"settings":{"index.lifecycle.name":"my_policy"}}'
+	//  curl -XPUT http://localhost:9200/facebook
+	//  curl http://localhost:9200/_ilm/status
+	//  curl http://localhost:9200/_all/_ilm/explain
+	tcs := map[string][]string{
+		"7.8.0": {
+			`{"operation_mode":"RUNNING"}`,
+			`{"indices":{"twitter":{"index":"twitter","managed":true,"policy":"my_policy","lifecycle_date_millis":1594632896607,"age":"2.1h","phase":"hot","phase_time_millis":1594632896768,"action":"rollover","action_time_millis":1594632897152,"step":"ERROR","step_time_millis":1594633497148,"failed_step":"check-rollover-ready","is_auto_retryable_error":true,"failed_step_retry_count":12,"step_info":{"type":"illegal_argument_exception","reason":"setting [index.lifecycle.rollover_alias] for index [twitter] is empty or not defined"}},"facebook":{"index":"facebook","managed":false}}}`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.RequestURI == "/_ilm/status" {
+				fmt.Fprint(w, out[0])
+				return
+			}
+			fmt.Fprint(w, out[1])
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		i := NewILM(log.NewNopLogger(), http.DefaultClient, u)
+		isr, err := i.fetchAndDecodeILMStatus()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode ILM status: %s", err)
+		}
+		t.Logf("[%s] ILM Status Response: %+v", ver, isr)
+		if isr.OperationMode != "RUNNING" {
+			t.Errorf("Wrong ILM operation mode")
+		}
+
+		ier, err := i.fetchAndDecodeILMExplain()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode ILM explain: %s", err)
+		}
+		t.Logf("[%s] ILM Explain Response: %+v", ver, ier)
+		if len(ier.Indices) != 2 {
+			t.Errorf("Wrong number of indices")
+		}
+		twitter := ier.Indices["twitter"]
+		if !twitter.Managed || twitter.Policy != "my_policy" {
+			t.Errorf("Wrong ILM policy for index twitter")
+		}
+		if twitter.Step != "ERROR" || twitter.Action != "rollover" {
+			t.Errorf("Wrong ILM step or action for index twitter")
+		}
+		if twitter.Phase != "hot" || twitter.StepTimeMillis != 1594633497148 {
+			t.Errorf("Wrong ILM phase or step time for index twitter")
+		}
+		if ier.Indices["facebook"].Managed {
+			t.Errorf("Index facebook should not be managed")
+		}
+		policies, unmanaged := ilmPolicyIndices(ier)
+		if len(policies) != 1 || policies["my_policy"] != 1 || unmanaged != 1 {
+			t.Errorf("Wrong ILM policy inventory %+v, %d unmanaged", policies, unmanaged)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	defaultIndexingCanaryLabels = []string{"index", "operation"}
+
+	indexingCanaryOperations = []string{"index", "delete"}
+)
+
+// indexingCanaryOperation is the result of the last run of a single canary operation
+type indexingCanaryOperation struct {
+	success  bool
+	duration time.Duration
+	ts       time.Time
+}
+
+type indexingCanaryMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(operation indexingCanaryOperation) float64
+}
+
+// IndexingCanary periodically indexes and deletes a tiny document in a dedicated index to
+// detect outages of the write path, e.g. caused by flood-stage index blocks
+type IndexingCanary struct {
+	logger   log.Logger
+	client   *http.Client
+	url      *url.URL
+	index    string
+	docID    string
+	interval time.Duration
+
+	mutex      sync.RWMutex
+	operations map[string]indexingCanaryOperation
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	metrics []*indexingCanaryMetric
+}
+
+var (
+	indexingCanaryInterval = kingpin.Flag("es.indexing_canary.interval",
+		"Indexing canary interval").
+		Default("1m").Envar("ES_INDEXING_CANARY_INTERVAL").Duration()
+	indexingCanaryIndex = kingpin.Flag("es.indexing_canary.index",
+		"Index the canary document is written to.").
+		Default("elasticsearch-exporter-canary").Envar("ES_INDEXING_CANARY_INDEX").String()
+)
+
+func init() {
+	registerCollector("indexing_canary", defaultDisabled, "Periodically index and delete a canary document in a dedicated index.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewIndexingCanary(logger, client, url, *indexingCanaryIndex, *indexingCanaryInterval)
+	})
+}
+
+// NewIndexingCanary defines Indexing Canary Prometheus metrics
+func NewIndexingCanary(logger log.Logger, client *http.Client, url *url.URL, index string, interval time.Duration) *IndexingCanary {
+	subsystem := "indexing_canary"
+
+	// every exporter uses its own document, so exporters sharing the canary index do not interfere
+	docID := "elasticsearch_exporter"
+	if hostname, err := os.Hostname(); err == nil {
+		docID = docID + "-" + hostname
+	}
+
+	return &IndexingCanary{
+		logger:     logger,
+		client:     client,
+		url:        url,
+		index:      index,
+		docID:      docID,
+		interval:   interval,
+		operations: make(map[string]indexingCanaryOperation),
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Were all operations of the last ElasticSearch indexing canary run successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch indexing canary runs.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		metrics: []*indexingCanaryMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "success"),
+					"Whether the last canary operation succeeded",
+					defaultIndexingCanaryLabels, nil,
+				),
+				Value: func(operation indexingCanaryOperation) float64 {
+					return bool2Float(operation.success)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "duration_seconds"),
+					"Duration of the last canary operation in seconds",
+					defaultIndexingCanaryLabels, nil,
+				),
+				Value: func(operation indexingCanaryOperation) float64 {
+					return operation.duration.Seconds()
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "last_run_timestamp_seconds"),
+					"Timestamp of the last canary operation",
+					defaultIndexingCanaryLabels, nil,
+				),
+				Value: func(operation indexingCanaryOperation) float64 {
+					return float64(operation.ts.Unix())
+				},
+			},
+		},
+	}
+}
+
+// Describe add Indexing Canary metrics descriptions
+func (ic *IndexingCanary) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range ic.metrics {
+		ch <- metric.Desc
+	}
+	ch <- ic.up.Desc()
+	ch <- ic.totalScrapes.Desc()
+	ch <- ic.jsonParseFailures.Desc()
+}
+
+func (ic *IndexingCanary) doAndParse(method string, u *url.URL, body string, data interface{}) error {
+	req, err := http.NewRequest(method, u.String(), strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := ic.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to %s %s://%s:%s%s: %s",
+			method, u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(ic.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		ic.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+// runCanary indexes and deletes the canary document once and stores the results
+func (ic *IndexingCanary) runCanary() {
+	ic.totalScrapes.Inc()
+
+	u := *ic.url
+	u.Path = path.Join(u.Path, ic.index, "/_doc", ic.docID)
+
+	up := 1.0
+	operations := make(map[string]indexingCanaryOperation, len(indexingCanaryOperations))
+	for _, operation := range indexingCanaryOperations {
+		method, body := http.MethodPut, fmt.Sprintf(`{"@timestamp":%d}`, time.Now().UnixNano()/int64(time.Millisecond))
+		if operation == "delete" {
+			method, body = http.MethodDelete, ""
+		}
+		var icr indexingCanaryResponse
+		start := time.Now()
+		err := ic.doAndParse(method, &u, body, &icr)
+		if err != nil {
+			up = 0
+			_ = level.Warn(ic.logger).Log(
+				"msg", "failed to run indexing canary",
+				"index", ic.index,
+				"operation", operation,
+				"err", err,
+			)
+		}
+		operations[operation] = indexingCanaryOperation{
+			success:  err == nil,
+			duration: time.Since(start),
+			ts:       start,
+		}
+	}
+	ic.up.Set(up)
+
+	ic.mutex.Lock()
+	ic.operations = operations
+	ic.mutex.Unlock()
+}
+
+// Run starts the canary loop. The loop is terminated upon ctx cancellation, without a positive interval
+// the canary runs once
+func (ic *IndexingCanary) Run(ctx context.Context) {
+	go func() {
+		ic.runCanary()
+		if ic.interval <= 0 {
+			_ = level.Info(ic.logger).Log(
+				"msg", "no periodic indexing canary requested",
+			)
+			return
+		}
+		ticker := time.NewTicker(ic.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				_ = level.Info(ic.logger).Log(
+					"msg", "context cancelled, exiting indexing canary loop",
+					"err", ctx.Err(),
+				)
+				return
+			case <-ticker.C:
+			}
+			ic.runCanary()
+		}
+	}()
+}
+
+// Collect gets Indexing Canary metric values
+func (ic *IndexingCanary) Collect(ch chan<- prometheus.Metric) {
+	ch <- ic.up
+	ch <- ic.totalScrapes
+	ch <- ic.jsonParseFailures
+
+	ic.mutex.RLock()
+	defer ic.mutex.RUnlock()
+	for name, operation := range ic.operations {
+		for _, metric := range ic.metrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(operation),
+				ic.index, name,
+			)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package collector
+
+// indexingCanaryResponse is a representation of the Elasticsearch document index and delete responses
+type indexingCanaryResponse struct {
+	Index   string `json:"_index"`
+	ID      string `json:"_id"`
+	Version int64  `json:"_version"`
+	Result  string `json:"result"`
+}
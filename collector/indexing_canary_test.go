@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestIndexingCanary(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/elasticsearch-exporter-canary/_doc/elasticsearch_exporter-host -H "Content-Type: application/json" -d '{"@timestamp":1610120231047}'
+	//  curl -XPUT http://localhost:9200/_all/_settings -H "Content-Type: application/json" -d '{"index.blocks.read_only_allow_delete":true}'
+	//  curl -XPUT http://localhost:9200/elasticsearch-exporter-canary/_doc/elasticsearch_exporter-host -H "Content-Type: application/json" -d '{"@timestamp":1610120231047}'
+	tcs := map[string][]string{
+		"7.10.0": {
+			`{"_index":"elasticsearch-exporter-canary","_type":"_doc","_id":"elasticsearch_exporter-host","_version":1,"result":"created","_shards":{"total":2,"successful":1,"failed":0},"_seq_no":0,"_primary_term":1}`,
+			`{"_index":"elasticsearch-exporter-canary","_type":"_doc","_id":"elasticsearch_exporter-host","_version":2,"result":"deleted","_shards":{"total":2,"successful":1,"failed":0},"_seq_no":1,"_primary_term":1}`,
+			`{"error":{"root_cause":[{"type":"cluster_block_exception","reason":"index [elasticsearch-exporter-canary] blocked by: [TOO_MANY_REQUESTS/12/disk usage exceeded flood-stage watermark, index has read-only-allow-delete block];"}],"type":"cluster_block_exception","reason":"index [elasticsearch-exporter-canary] blocked by: [TOO_MANY_REQUESTS/12/disk usage exceeded flood-stage watermark, index has read-only-allow-delete block];"},"status":429}`,
+		},
+	}
+	for ver, out := range tcs {
+		blocked := false
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/elasticsearch-exporter-canary/_doc/elasticsearch_exporter") {
+				t.Errorf("Wrong canary document %s", r.URL.Path)
+			}
+			switch {
+			case blocked && r.Method == http.MethodPut:
+				http.Error(w, out[2], http.StatusTooManyRequests)
+			case r.Method == http.MethodPut:
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, out[0])
+			case r.Method == http.MethodDelete:
+				fmt.Fprint(w, out[1])
+			default:
+				t.Errorf("Unexpected canary request method %s", r.Method)
+			}
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		ic := NewIndexingCanary(log.NewNopLogger(), http.DefaultClient, u, "elasticsearch-exporter-canary", time.Minute)
+		ic.runCanary()
+		t.Logf("[%s] Indexing Canary Operations: %+v", ver, ic.operations)
+		if !ic.operations["index"].success || !ic.operations["delete"].success {
+			t.Errorf("Indexing canary should succeed")
+		}
+
+		blocked = true
+		ic.runCanary()
+		if ic.operations["index"].success {
+			t.Errorf("Indexing canary should fail on a flood-stage block")
+		}
+		if !ic.operations["delete"].success {
+			t.Errorf("Deleting the canary document should succeed on a flood-stage block")
+		}
+	}
+}
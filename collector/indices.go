@@ -12,6 +12,7 @@ import (
 	"github.com/go-kit/kit/log/level"
 	"github.com/justwatchcom/elasticsearch_exporter/pkg/clusterinfo"
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 type labels struct {
@@ -39,6 +40,7 @@ type Indices struct {
 	client          *http.Client
 	url             *url.URL
 	shards          bool
+	hidden          bool
 	clusterInfoCh   chan *clusterinfo.Response
 	lastClusterInfo *clusterinfo.Response
 
@@ -46,12 +48,28 @@ type Indices struct {
 	totalScrapes      prometheus.Counter
 	jsonParseFailures prometheus.Counter
 
-	indexMetrics []*indexMetric
-	shardMetrics []*shardMetric
+	systemStoreSize *prometheus.Desc
+	indexMetrics    []*indexMetric
+	shardMetrics    []*shardMetric
 }
 
-// NewIndices defines Indices Prometheus metrics
-func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards bool) *Indices {
+var (
+	indicesHidden = kingpin.Flag("es.indices.hidden",
+		"Include hidden and system indices in the stats for indices and export the size of all system indices.").
+		Default("false").Envar("ES_INDICES_HIDDEN").Bool()
+)
+
+func init() {
+	registerCollector("indices", defaultDisabled, "Export stats for indices in the cluster.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewIndices(logger, client, url, false, *indicesHidden)
+	})
+	registerCollector("shards", defaultDisabled, "Export stats for shards in the cluster (implies --collector.indices).", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewIndices(logger, client, url, true, *indicesHidden)
+	})
+}
+
+// NewIndices defines Indices Prometheus metrics. If hidden is true, hidden and system indices are included
+func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards bool, hidden bool) *Indices {
 
 	indexLabels := labels{
 		keys: func(...string) []string {
@@ -86,6 +104,7 @@ func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards boo
 		client:        client,
 		url:           url,
 		shards:        shards,
+		hidden:        hidden,
 		clusterInfoCh: make(chan *clusterinfo.Response),
 		lastClusterInfo: &clusterinfo.Response{
 			ClusterName: "unknown_cluster",
@@ -104,6 +123,11 @@ func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards boo
 			Help: "Number of errors while parsing JSON.",
 		}),
 
+		systemStoreSize: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "indices", "system_store_size_bytes_total"),
+			"Current total size of stored data of all system indices in bytes with all shards on all nodes",
+			[]string{"cluster"}, nil,
+		),
 		indexMetrics: []*indexMetric{
 			{
 				Type: prometheus.GaugeValue,
@@ -153,6 +177,22 @@ func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards boo
 				},
 				Labels: indexLabels,
 			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indices", "deleted_docs_ratio"),
+					"Ratio of deleted documents to all documents with only primary shards, a high ratio suggests a force merge",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					docs := indexStats.Primaries.Docs.Count + indexStats.Primaries.Docs.Deleted
+					if docs == 0 {
+						return 0
+					}
+					return float64(indexStats.Primaries.Docs.Deleted) / float64(docs)
+				},
+				Labels: indexLabels,
+			},
 			{
 				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
@@ -729,6 +769,66 @@ func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards boo
 				},
 				Labels: indexLabels,
 			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index_stats", "merge_current"),
+					"Current number of merges",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					return float64(indexStats.Total.Merges.Current)
+				},
+				Labels: indexLabels,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index_stats", "merge_current_docs"),
+					"Number of documents in current merges",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					return float64(indexStats.Total.Merges.CurrentDocs)
+				},
+				Labels: indexLabels,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index_stats", "merge_current_size_bytes"),
+					"Size of current merges in bytes",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					return float64(indexStats.Total.Merges.CurrentSizeInBytes)
+				},
+				Labels: indexLabels,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index_stats", "merge_docs_total"),
+					"Total number of merged documents",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					return float64(indexStats.Total.Merges.TotalDocs)
+				},
+				Labels: indexLabels,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index_stats", "merge_size_bytes_total"),
+					"Total size of merged segments in bytes",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					return float64(indexStats.Total.Merges.TotalSizeInBytes)
+				},
+				Labels: indexLabels,
+			},
 			{
 				Type: prometheus.CounterValue,
 				Desc: prometheus.NewDesc(
@@ -753,6 +853,30 @@ func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards boo
 				},
 				Labels: indexLabels,
 			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index_stats", "refresh_external_time_seconds_total"),
+					"Total time of external refreshes, which make changes visible to searches, in seconds",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					return float64(indexStats.Total.Refresh.ExternalTotalTimeInMillis) / 1000
+				},
+				Labels: indexLabels,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index_stats", "refresh_external_total"),
+					"Total count of external refreshes, which make changes visible to searches",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					return float64(indexStats.Total.Refresh.ExternalTotal)
+				},
+				Labels: indexLabels,
+			},
 			{
 				Type: prometheus.CounterValue,
 				Desc: prometheus.NewDesc(
@@ -777,6 +901,18 @@ func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards boo
 				},
 				Labels: indexLabels,
 			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index_stats", "flush_periodic_total"),
+					"Total count of periodic flushes triggered by the translog size",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					return float64(indexStats.Total.Flush.Periodic)
+				},
+				Labels: indexLabels,
+			},
 			{
 				Type: prometheus.CounterValue,
 				Desc: prometheus.NewDesc(
@@ -802,9 +938,9 @@ func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards boo
 				Labels: indexLabels,
 			},
 			{
-				Type: prometheus.CounterValue,
+				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "index_stats", "query_cache_memory_bytes_total"),
+					prometheus.BuildFQName(namespace, "index_stats", "query_cache_memory_bytes"),
 					"Total query cache memory bytes",
 					indexLabels.keys(), nil,
 				),
@@ -813,6 +949,19 @@ func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards boo
 				},
 				Labels: indexLabels,
 			},
+			{
+				// the former name of query_cache_memory_bytes, kept for existing dashboards
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index_stats", "query_cache_memory_bytes_total"),
+					"Deprecated, use elasticsearch_index_stats_query_cache_memory_bytes. Total query cache memory bytes",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					return float64(indexStats.Total.QueryCache.MemorySizeInBytes)
+				},
+				Labels: indexLabels,
+			},
 			{
 				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
@@ -874,9 +1023,9 @@ func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards boo
 				Labels: indexLabels,
 			},
 			{
-				Type: prometheus.CounterValue,
+				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "index_stats", "request_cache_memory_bytes_total"),
+					prometheus.BuildFQName(namespace, "index_stats", "request_cache_memory_bytes"),
 					"Total request cache memory bytes",
 					indexLabels.keys(), nil,
 				),
@@ -885,6 +1034,19 @@ func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards boo
 				},
 				Labels: indexLabels,
 			},
+			{
+				// the former name of request_cache_memory_bytes, kept for existing dashboards
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index_stats", "request_cache_memory_bytes_total"),
+					"Deprecated, use elasticsearch_index_stats_request_cache_memory_bytes. Total request cache memory bytes",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					return float64(indexStats.Total.RequestCache.MemorySizeInBytes)
+				},
+				Labels: indexLabels,
+			},
 			{
 				Type: prometheus.CounterValue,
 				Desc: prometheus.NewDesc(
@@ -945,6 +1107,18 @@ func NewIndices(logger log.Logger, client *http.Client, url *url.URL, shards boo
 				},
 				Labels: indexLabels,
 			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index_stats", "fielddata_global_ordinals_build_time_seconds"),
+					"Time spent building the currently loaded global ordinals of the index in seconds",
+					indexLabels.keys(), nil,
+				),
+				Value: func(indexStats IndexStatsIndexResponse) float64 {
+					return float64(indexStats.Total.Fielddata.GlobalOrdinals.BuildTimeInMillis) / 1000
+				},
+				Labels: indexLabels,
+			},
 		},
 		shardMetrics: []*shardMetric{
 			{
@@ -1013,6 +1187,9 @@ func (i *Indices) String() string {
 
 // Describe add Indices metrics descriptions
 func (i *Indices) Describe(ch chan<- *prometheus.Desc) {
+	if i.hidden {
+		ch <- i.systemStoreSize
+	}
 	for _, metric := range i.indexMetrics {
 		ch <- metric.Desc
 	}
@@ -1026,13 +1203,45 @@ func (i *Indices) fetchAndDecodeIndexStats() (indexStatsResponse, error) {
 
 	u := *i.url
 	u.Path = path.Join(u.Path, "/_all/_stats")
+	q := u.Query()
 	if i.shards {
-		u.RawQuery = "level=shards"
+		q.Set("level", "shards")
+	}
+	if i.hidden {
+		q.Set("expand_wildcards", "all")
+	}
+	u.RawQuery = q.Encode()
+
+	if err := i.getAndParseURL(&u, &isr); err != nil {
+		return isr, err
+	}
+	return isr, nil
+}
+
+// fetchAndDecodeSystemIndices returns the names of all system indices, which are only flagged in the cluster state
+func (i *Indices) fetchAndDecodeSystemIndices() (map[string]bool, error) {
+	var csr clusterStateResponse
+
+	u := *i.url
+	u.Path = path.Join(u.Path, "/_cluster/state/metadata")
+	u.RawQuery = "filter_path=metadata.indices.*.system"
+	if err := i.getAndParseURL(&u, &csr); err != nil {
+		return nil, err
 	}
 
+	system := make(map[string]bool)
+	for name, index := range csr.Metadata.Indices {
+		if index.System {
+			system[name] = true
+		}
+	}
+	return system, nil
+}
+
+func (i *Indices) getAndParseURL(u *url.URL, data interface{}) error {
 	res, err := i.client.Get(u.String())
 	if err != nil {
-		return isr, fmt.Errorf("failed to get index stats from %s://%s:%s%s: %s",
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
 			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
 	}
 
@@ -1047,15 +1256,14 @@ func (i *Indices) fetchAndDecodeIndexStats() (indexStatsResponse, error) {
 	}()
 
 	if res.StatusCode != http.StatusOK {
-		return isr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
 	}
 
-	if err := json.NewDecoder(res.Body).Decode(&isr); err != nil {
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
 		i.jsonParseFailures.Inc()
-		return isr, err
+		return err
 	}
-
-	return isr, nil
+	return nil
 }
 
 // Collect gets Indices metric values
@@ -1077,9 +1285,35 @@ func (i *Indices) Collect(ch chan<- prometheus.Metric) {
 		)
 		return
 	}
+
+	var systemIndices map[string]bool
+	if i.hidden {
+		systemIndices, err = i.fetchAndDecodeSystemIndices()
+		if err != nil {
+			i.up.Set(0)
+			_ = level.Warn(i.logger).Log(
+				"msg", "failed to fetch and decode system indices",
+				"err", err,
+			)
+			return
+		}
+	}
 	i.totalScrapes.Inc()
 	i.up.Set(1)
 
+	if i.hidden {
+		var systemStoreSize float64
+		for indexName := range systemIndices {
+			systemStoreSize += float64(indexStatsResp.Indices[indexName].Total.Store.SizeInBytes)
+		}
+		ch <- prometheus.MustNewConstMetric(
+			i.systemStoreSize,
+			prometheus.GaugeValue,
+			systemStoreSize,
+			i.lastClusterInfo.ClusterName,
+		)
+	}
+
 	// Index stats
 	for indexName, indexStats := range indexStatsResp.Indices {
 		for _, metric := range i.indexMetrics {
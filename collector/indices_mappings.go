@@ -0,0 +1,177 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// indicesMappings combines the mappings and the field limit settings of all indices
+type indicesMappings struct {
+	Mappings indicesMappingsResponse
+	Settings indicesFieldLimitSettingsResponse
+}
+
+// IndicesMappings information struct
+type IndicesMappings struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	fields      *prometheus.Desc
+	fieldsLimit *prometheus.Desc
+}
+
+func init() {
+	registerCollector("indices_mappings", defaultDisabled, "Export the number of mapped fields and the field limit of all indices.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewIndicesMappings(logger, client, url)
+	})
+}
+
+// NewIndicesMappings defines Indices Mappings Prometheus metrics
+func NewIndicesMappings(logger log.Logger, client *http.Client, url *url.URL) *IndicesMappings {
+	subsystem := "indices_mappings"
+
+	return &IndicesMappings{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch indices mappings endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch indices mappings scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		fields: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "fields"),
+			"Number of fields in the mapping of the index, counted towards index.mapping.total_fields.limit",
+			[]string{"index"}, nil,
+		),
+		fieldsLimit: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "total_fields_limit"),
+			"Configured maximum number of fields in the mapping of the index",
+			[]string{"index"}, nil,
+		),
+	}
+}
+
+// Describe add Indices Mappings metrics descriptions
+func (im *IndicesMappings) Describe(ch chan<- *prometheus.Desc) {
+	ch <- im.fields
+	ch <- im.fieldsLimit
+	ch <- im.up.Desc()
+	ch <- im.totalScrapes.Desc()
+	ch <- im.jsonParseFailures.Desc()
+}
+
+func (im *IndicesMappings) getAndParseURL(u *url.URL, data interface{}) error {
+	res, err := im.client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(im.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		im.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+func (im *IndicesMappings) fetchAndDecodeIndicesMappings() (indicesMappings, error) {
+	var mappings indicesMappings
+
+	u := *im.url
+	u.Path = path.Join(u.Path, "/_all/_mapping")
+	u.RawQuery = "expand_wildcards=all"
+	if err := im.getAndParseURL(&u, &mappings.Mappings); err != nil {
+		return mappings, err
+	}
+
+	// the limit is only returned as a default unless it is set on the index
+	u = *im.url
+	u.Path = path.Join(u.Path, "/_all/_settings/index.mapping.total_fields.limit")
+	u.RawQuery = "expand_wildcards=all&flat_settings=true&include_defaults=true"
+	if err := im.getAndParseURL(&u, &mappings.Settings); err != nil {
+		return mappings, err
+	}
+
+	return mappings, nil
+}
+
+// Collect gets Indices Mappings metric values
+func (im *IndicesMappings) Collect(ch chan<- prometheus.Metric) {
+	im.totalScrapes.Inc()
+	defer func() {
+		ch <- im.up
+		ch <- im.totalScrapes
+		ch <- im.jsonParseFailures
+	}()
+
+	mappings, err := im.fetchAndDecodeIndicesMappings()
+	if err != nil {
+		im.up.Set(0)
+		_ = level.Warn(im.logger).Log(
+			"msg", "failed to fetch and decode indices mappings",
+			"err", err,
+		)
+		return
+	}
+	im.up.Set(1)
+
+	for index, mapping := range mappings.Mappings {
+		ch <- prometheus.MustNewConstMetric(
+			im.fields,
+			prometheus.GaugeValue,
+			float64(mapping.Mappings.FieldCount()),
+			index,
+		)
+	}
+	for index, settings := range mappings.Settings {
+		limit := settings.Settings.TotalFieldsLimit
+		if limit == "" {
+			limit = settings.Defaults.TotalFieldsLimit
+		}
+		value, err := strconv.ParseFloat(limit, 64)
+		if err != nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			im.fieldsLimit,
+			prometheus.GaugeValue,
+			value,
+			index,
+		)
+	}
+}
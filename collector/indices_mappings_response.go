@@ -0,0 +1,49 @@
+package collector
+
+// indicesMappingsResponse is a representation of the Elasticsearch _mapping endpoint
+type indicesMappingsResponse map[string]indexMappingsResponse
+
+// indexMappingsResponse defines the mappings of a single index
+type indexMappingsResponse struct {
+	Mappings indexMappingResponse `json:"mappings"`
+}
+
+// indexMappingResponse defines the typeless mapping of an index
+type indexMappingResponse struct {
+	Properties map[string]mappingFieldResponse `json:"properties"`
+	Runtime    map[string]interface{}          `json:"runtime"`
+}
+
+// mappingFieldResponse defines a single field, object or field alias of a mapping
+type mappingFieldResponse struct {
+	Properties map[string]mappingFieldResponse `json:"properties"`
+	Fields     map[string]mappingFieldResponse `json:"fields"`
+}
+
+// FieldCount returns the number of fields, objects, multi-fields, field aliases and runtime
+// fields of the mapping, which is the number checked against index.mapping.total_fields.limit
+func (m indexMappingResponse) FieldCount() int {
+	return countMappingFields(m.Properties) + len(m.Runtime)
+}
+
+func countMappingFields(properties map[string]mappingFieldResponse) int {
+	count := len(properties)
+	for _, field := range properties {
+		count += countMappingFields(field.Properties) + countMappingFields(field.Fields)
+	}
+	return count
+}
+
+// indicesFieldLimitSettingsResponse is a representation of the flat field limit settings of the Elasticsearch _settings endpoint
+type indicesFieldLimitSettingsResponse map[string]indexFieldLimitSettingsResponse
+
+// indexFieldLimitSettingsResponse defines the field limit of a single index, either set or as default
+type indexFieldLimitSettingsResponse struct {
+	Settings indexFieldLimitSettingResponse `json:"settings"`
+	Defaults indexFieldLimitSettingResponse `json:"defaults"`
+}
+
+// indexFieldLimitSettingResponse defines the flat index.mapping.total_fields.limit setting
+type indexFieldLimitSettingResponse struct {
+	TotalFieldsLimit string `json:"index.mapping.total_fields.limit"`
+}
@@ -0,0 +1,61 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestIndicesMappings(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/twitter -d '{"settings":{"index.mapping.total_fields.limit":2000},"mappings":{"properties":{"user":{"properties":{"name":{"type":"text","fields":{"keyword":{"type":"keyword"}}},"id":{"type":"long"}}},"message":{"type":"text"},"author":{"type":"alias","path":"user.name"}},"runtime":{"day":{"type":"keyword"}}}}'
+	//  curl -XPUT http://localhost:9200/facebook
+	//  curl http://localhost:9200/_all/_mapping?expand_wildcards=all
+	//  curl http://localhost:9200/_all/_settings/index.mapping.total_fields.limit?expand_wildcards=all&flat_settings=true&include_defaults=true
+	tcs := map[string][]string{
+		"7.12.0": {
+			`{"twitter":{"mappings":{"runtime":{"day":{"type":"keyword"}},"properties":{"author":{"type":"alias","path":"user.name"},"message":{"type":"text"},"user":{"properties":{"id":{"type":"long"},"name":{"type":"text","fields":{"keyword":{"type":"keyword"}}}}}}}},"facebook":{"mappings":{}}}`,
+			`{"twitter":{"settings":{"index.mapping.total_fields.limit":"2000"},"defaults":{}},"facebook":{"settings":{},"defaults":{"index.mapping.total_fields.limit":"1000"}}}`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/_mapping") {
+				fmt.Fprint(w, out[0])
+				return
+			}
+			fmt.Fprint(w, out[1])
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		im := NewIndicesMappings(log.NewNopLogger(), http.DefaultClient, u)
+		mappings, err := im.fetchAndDecodeIndicesMappings()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode indices mappings: %s", err)
+		}
+		t.Logf("[%s] Indices Mappings Response: %+v", ver, mappings)
+		// user, user.id, user.name, user.name.keyword, message, author and the runtime field day
+		if count := mappings.Mappings["twitter"].Mappings.FieldCount(); count != 7 {
+			t.Errorf("Wrong number of fields for index twitter: %d", count)
+		}
+		if count := mappings.Mappings["facebook"].Mappings.FieldCount(); count != 0 {
+			t.Errorf("Wrong number of fields for index facebook: %d", count)
+		}
+		if limit := mappings.Settings["twitter"].Settings.TotalFieldsLimit; limit != "2000" {
+			t.Errorf("Wrong field limit for index twitter: %s", limit)
+		}
+		if limit := mappings.Settings["facebook"].Defaults.TotalFieldsLimit; limit != "1000" {
+			t.Errorf("Wrong default field limit for index facebook: %s", limit)
+		}
+	}
+}
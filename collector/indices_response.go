@@ -123,14 +123,17 @@ type IndexStatsIndexMergesResponse struct {
 
 // IndexStatsIndexRefreshResponse defines index stats index refresh information structure
 type IndexStatsIndexRefreshResponse struct {
-	Total             int64 `json:"total"`
-	TotalTimeInMillis int64 `json:"total_time_in_millis"`
-	Listeners         int64 `json:"listeners"`
+	Total                     int64 `json:"total"`
+	TotalTimeInMillis         int64 `json:"total_time_in_millis"`
+	ExternalTotal             int64 `json:"external_total"`
+	ExternalTotalTimeInMillis int64 `json:"external_total_time_in_millis"`
+	Listeners                 int64 `json:"listeners"`
 }
 
 // IndexStatsIndexFlushResponse defines index stats index flush information structure
 type IndexStatsIndexFlushResponse struct {
 	Total             int64 `json:"total"`
+	Periodic          int64 `json:"periodic"`
 	TotalTimeInMillis int64 `json:"total_time_in_millis"`
 }
 
@@ -154,8 +157,14 @@ type IndexStatsIndexQueryCacheResponse struct {
 
 // IndexStatsIndexFielddataResponse defines index stats index fielddata information structure
 type IndexStatsIndexFielddataResponse struct {
-	MemorySizeInBytes int64 `json:"memory_size_in_bytes"`
-	Evictions         int64 `json:"evictions"`
+	MemorySizeInBytes int64                            `json:"memory_size_in_bytes"`
+	Evictions         int64                            `json:"evictions"`
+	GlobalOrdinals    IndexStatsGlobalOrdinalsResponse `json:"global_ordinals"`
+}
+
+// IndexStatsGlobalOrdinalsResponse defines the build time of the loaded global ordinals, available since Elasticsearch 8.5
+type IndexStatsGlobalOrdinalsResponse struct {
+	BuildTimeInMillis int64 `json:"build_time_in_millis"`
 }
 
 // IndexStatsIndexCompletionResponse defines index stats index completion information structure
@@ -6,12 +6,29 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+var (
+	defaultIndicesSettingsLabels      = []string{"index"}
+	defaultIndicesSettingsLabelValues = func(indexName string) []string {
+		return []string{indexName}
+	}
+)
+
+type indicesSettingsMetric struct {
+	Type   prometheus.ValueType
+	Desc   *prometheus.Desc
+	Value  func(indexSettings IndexInfo) float64
+	Labels func(indexName string, indexSettings IndexInfo) []string
+}
+
 // IndicesSettings information struct
 type IndicesSettings struct {
 	logger log.Logger
@@ -21,6 +38,15 @@ type IndicesSettings struct {
 	up                              prometheus.Gauge
 	readOnlyIndices                 prometheus.Gauge
 	totalScrapes, jsonParseFailures prometheus.Counter
+
+	metrics []*indicesSettingsMetric
+	blocks  *prometheus.Desc
+}
+
+func init() {
+	registerCollector("indices_settings", defaultDisabled, "Export stats for settings of all indices of the cluster.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewIndicesSettings(logger, client, url)
+	})
 }
 
 // NewIndicesSettings defines Indices Settings Prometheus metrics
@@ -46,15 +72,160 @@ func NewIndicesSettings(logger log.Logger, client *http.Client, url *url.URL) *I
 			Name: prometheus.BuildFQName(namespace, "indices_settings_stats", "json_parse_failures"),
 			Help: "Number of errors while parsing JSON.",
 		}),
+		metrics: []*indicesSettingsMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indices_settings", "replicas"),
+					"Configured number of replicas of the index",
+					defaultIndicesSettingsLabels, nil,
+				),
+				Value: func(indexSettings IndexInfo) float64 {
+					replicas, _ := strconv.ParseFloat(indexSettings.NumberOfReplicas, 64)
+					return replicas
+				},
+				Labels: func(indexName string, indexSettings IndexInfo) []string {
+					return defaultIndicesSettingsLabelValues(indexName)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indices_settings", "refresh_interval_seconds"),
+					"Configured refresh interval of the index in seconds, -1 if periodic refreshes are disabled",
+					defaultIndicesSettingsLabels, nil,
+				),
+				Value: func(indexSettings IndexInfo) float64 {
+					return refreshIntervalSeconds(indexSettings.RefreshInterval)
+				},
+				Labels: func(indexName string, indexSettings IndexInfo) []string {
+					return defaultIndicesSettingsLabelValues(indexName)
+				},
+			},
+			{
+				// superseded by the block metric, kept for existing dashboards
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indices_settings", "read_only_allow_delete"),
+					"Deprecated, use elasticsearch_indices_settings_block{block=\"read_only_allow_delete\"}. Whether the index has the read_only_allow_delete block set",
+					defaultIndicesSettingsLabels, nil,
+				),
+				Value: func(indexSettings IndexInfo) float64 {
+					return bool2Float(indexSettings.Blocks.ReadOnly == "true")
+				},
+				Labels: func(indexName string, indexSettings IndexInfo) []string {
+					return defaultIndicesSettingsLabelValues(indexName)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indices_settings", "auto_expand_replicas"),
+					"Whether the number of replicas of the index is expanded automatically, with the configured range as label",
+					append(defaultIndicesSettingsLabels, "auto_expand_replicas"), nil,
+				),
+				Value: func(indexSettings IndexInfo) float64 {
+					return bool2Float(autoExpandReplicas(indexSettings.AutoExpandReplicas) != "false")
+				},
+				Labels: func(indexName string, indexSettings IndexInfo) []string {
+					return append(defaultIndicesSettingsLabelValues(indexName), autoExpandReplicas(indexSettings.AutoExpandReplicas))
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indices_settings", "tier_preference"),
+					"The data tiers the index prefers to be allocated to as label, empty if the index has no tier preference",
+					append(defaultIndicesSettingsLabels, "tier_preference"), nil,
+				),
+				Value: func(indexSettings IndexInfo) float64 {
+					return 1
+				},
+				Labels: func(indexName string, indexSettings IndexInfo) []string {
+					return append(defaultIndicesSettingsLabelValues(indexName), indexSettings.Routing.Allocation.Include.TierPreference)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "index", "creation_timestamp_seconds"),
+					"Creation time of the index as unix timestamp",
+					defaultIndicesSettingsLabels, nil,
+				),
+				Value: func(indexSettings IndexInfo) float64 {
+					creationDate, _ := strconv.ParseFloat(indexSettings.CreationDate, 64)
+					return creationDate / 1000
+				},
+				Labels: func(indexName string, indexSettings IndexInfo) []string {
+					return defaultIndicesSettingsLabelValues(indexName)
+				},
+			},
+		},
+		blocks: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "indices_settings", "block"),
+			"Whether the block (read_only, read_only_allow_delete, read, write or metadata) is set on the index",
+			[]string{"index", "block"}, nil,
+		),
 	}
 }
 
+// refreshIntervalSeconds converts the refresh interval of an index to seconds,
+// falling back to the default interval of one second if the setting is absent or cannot be parsed.
+func refreshIntervalSeconds(interval string) float64 {
+	interval = strings.TrimSpace(interval)
+	if interval == "" {
+		return 1
+	}
+	if interval == "-1" {
+		return -1
+	}
+	seconds, err := parseTimeValueSeconds(interval)
+	if err != nil {
+		return 1
+	}
+	return seconds
+}
+
+// parseTimeValueSeconds converts an Elasticsearch time value like "30s" or "500ms" to seconds
+func parseTimeValueSeconds(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	// time.ParseDuration understands all Elasticsearch time units apart from days and the long forms
+	switch {
+	case strings.HasSuffix(value, "nanos"):
+		value = strings.TrimSuffix(value, "nanos") + "ns"
+	case strings.HasSuffix(value, "micros"):
+		value = strings.TrimSuffix(value, "micros") + "us"
+	case strings.HasSuffix(value, "d"):
+		days, err := strconv.ParseFloat(strings.TrimSuffix(value, "d"), 64)
+		if err != nil {
+			return 0, err
+		}
+		return days * 24 * 60 * 60, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	return d.Seconds(), nil
+}
+
+func autoExpandReplicas(setting string) string {
+	if setting == "" {
+		return "false"
+	}
+	return setting
+}
+
 // Describe add Snapshots metrics descriptions
 func (cs *IndicesSettings) Describe(ch chan<- *prometheus.Desc) {
 	ch <- cs.up.Desc()
 	ch <- cs.totalScrapes.Desc()
 	ch <- cs.readOnlyIndices.Desc()
 	ch <- cs.jsonParseFailures.Desc()
+	for _, metric := range cs.metrics {
+		ch <- metric.Desc
+	}
+	ch <- cs.blocks
 }
 
 func (cs *IndicesSettings) getAndParseURL(u *url.URL, data interface{}) error {
@@ -122,10 +293,26 @@ func (cs *IndicesSettings) Collect(ch chan<- prometheus.Metric) {
 	cs.up.Set(1)
 
 	var c int
-	for _, value := range asr {
+	for indexName, value := range asr {
 		if value.Settings.IndexInfo.Blocks.ReadOnly == "true" {
 			c++
 		}
+		for _, metric := range cs.metrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(value.Settings.IndexInfo),
+				metric.Labels(indexName, value.Settings.IndexInfo)...,
+			)
+		}
+		for block, enabled := range value.Settings.IndexInfo.Blocks.Enabled() {
+			ch <- prometheus.MustNewConstMetric(
+				cs.blocks,
+				prometheus.GaugeValue,
+				bool2Float(enabled),
+				indexName, block,
+			)
+		}
 	}
 	cs.readOnlyIndices.Set(float64(c))
 }
@@ -13,12 +13,38 @@ type Settings struct {
 	IndexInfo IndexInfo `json:"index"`
 }
 
-// IndexInfo defines the blocks of the current index
+// IndexInfo defines the blocks, replication and routing settings and creation date of the current index
 type IndexInfo struct {
-	Blocks Blocks `json:"blocks"`
+	Blocks             Blocks `json:"blocks"`
+	CreationDate       string `json:"creation_date"`
+	NumberOfReplicas   string `json:"number_of_replicas"`
+	RefreshInterval    string `json:"refresh_interval"`
+	AutoExpandReplicas string `json:"auto_expand_replicas"`
+	Routing            struct {
+		Allocation struct {
+			Include struct {
+				TierPreference string `json:"_tier_preference"`
+			} `json:"include"`
+		} `json:"allocation"`
+	} `json:"routing"`
 }
 
-// Blocks defines whether current index has read_only_allow_delete enabled
+// Blocks defines which blocks are enabled on the current index
 type Blocks struct {
-	ReadOnly string `json:"read_only_allow_delete"`
+	ReadOnly         string `json:"read_only_allow_delete"`
+	ReadOnlyNoDelete string `json:"read_only"`
+	Read             string `json:"read"`
+	Write            string `json:"write"`
+	Metadata         string `json:"metadata"`
+}
+
+// Enabled returns whether each block is set, keyed by the name of the block
+func (b Blocks) Enabled() map[string]bool {
+	return map[string]bool{
+		"read_only":              b.ReadOnlyNoDelete == "true",
+		"read_only_allow_delete": b.ReadOnly == "true",
+		"read":                   b.Read == "true",
+		"write":                  b.Write == "true",
+		"metadata":               b.Metadata == "true",
+	}
 }
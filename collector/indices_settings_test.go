@@ -76,3 +76,158 @@ func TestIndicesSettings(t *testing.T) {
 		}
 	}
 }
+
+func TestIndicesSettingsReplication(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	// curl -XPUT http://localhost:9200/twitter -H "Content-Type: application/json" -d '{"settings":{"number_of_replicas":0,"refresh_interval":"30s"}}'
+	// curl -XPUT http://localhost:9200/facebook -H "Content-Type: application/json" -d '{"settings":{"auto_expand_replicas":"0-all","refresh_interval":"-1"}}'
+	// curl -XPUT http://localhost:9200/viber
+
+	// curl http://localhost:9200/_all/_settings
+	out := `{"twitter":{"settings":{"index":{"refresh_interval":"30s","number_of_shards":"1","provided_name":"twitter","creation_date":"1610031983732","number_of_replicas":"0","uuid":"o4qVd_JQQ9uFKwYl3QSbAA","version":{"created":"7100199"}}}},"facebook":{"settings":{"index":{"refresh_interval":"-1","number_of_shards":"1","auto_expand_replicas":"0-all","provided_name":"facebook","creation_date":"1610031991482","number_of_replicas":"0","uuid":"m9un4y-lRpeDwUOXQWzqRg","version":{"created":"7100199"}}}},"viber":{"settings":{"index":{"creation_date":"1610031998044","number_of_shards":"1","number_of_replicas":"1","uuid":"wVD0bk7VR0eMZ7WQxCNLWQ","version":{"created":"7100199"},"provided_name":"viber"}}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	c := NewIndicesSettings(log.NewNopLogger(), http.DefaultClient, u)
+	nsr, err := c.fetchAndDecodeIndicesSettings()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode indices settings: %s", err)
+	}
+
+	expected := map[string]struct {
+		replicas           string
+		refreshInterval    float64
+		autoExpandReplicas string
+		creationDate       string
+	}{
+		"twitter":  {"0", 30, "false", "1610031983732"},
+		"facebook": {"0", -1, "0-all", "1610031991482"},
+		"viber":    {"1", 1, "false", "1610031998044"},
+	}
+	for index, e := range expected {
+		settings := nsr[index].Settings.IndexInfo
+		if settings.NumberOfReplicas != e.replicas {
+			t.Errorf("Wrong number of replicas for %s: %s", index, settings.NumberOfReplicas)
+		}
+		if got := refreshIntervalSeconds(settings.RefreshInterval); got != e.refreshInterval {
+			t.Errorf("Wrong refresh interval for %s: %f", index, got)
+		}
+		if got := autoExpandReplicas(settings.AutoExpandReplicas); got != e.autoExpandReplicas {
+			t.Errorf("Wrong auto expand replicas for %s: %s", index, got)
+		}
+		if settings.CreationDate != e.creationDate {
+			t.Errorf("Wrong creation date for %s: %s", index, settings.CreationDate)
+		}
+	}
+}
+
+func TestIndicesSettingsTierPreference(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	// curl -XPUT http://localhost:9200/twitter
+	// curl -XPUT http://localhost:9200/facebook -H "Content-Type: application/json" -d '{"settings":{"index.routing.allocation.include._tier_preference":"data_warm,data_hot"}}'
+	// curl -XPUT http://localhost:9200/viber -H "Content-Type: application/json" -d '{"settings":{"index.routing.allocation.include._tier_preference":null}}'
+
+	// curl http://localhost:9200/_all/_settings
+	out := `{"twitter":{"settings":{"index":{"routing":{"allocation":{"include":{"_tier_preference":"data_content"}}},"number_of_shards":"1","provided_name":"twitter","creation_date":"1610031983732","number_of_replicas":"1","uuid":"o4qVd_JQQ9uFKwYl3QSbAA","version":{"created":"7100199"}}}},"facebook":{"settings":{"index":{"routing":{"allocation":{"include":{"_tier_preference":"data_warm,data_hot"}}},"number_of_shards":"1","provided_name":"facebook","creation_date":"1610031991482","number_of_replicas":"1","uuid":"m9un4y-lRpeDwUOXQWzqRg","version":{"created":"7100199"}}}},"viber":{"settings":{"index":{"creation_date":"1610031998044","number_of_shards":"1","number_of_replicas":"1","uuid":"wVD0bk7VR0eMZ7WQxCNLWQ","version":{"created":"7100199"},"provided_name":"viber"}}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	c := NewIndicesSettings(log.NewNopLogger(), http.DefaultClient, u)
+	nsr, err := c.fetchAndDecodeIndicesSettings()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode indices settings: %s", err)
+	}
+
+	for index, expected := range map[string]string{
+		"twitter":  "data_content",
+		"facebook": "data_warm,data_hot",
+		"viber":    "",
+	} {
+		if got := nsr[index].Settings.IndexInfo.Routing.Allocation.Include.TierPreference; got != expected {
+			t.Errorf("Wrong tier preference for %s: %s", index, got)
+		}
+	}
+}
+
+func TestRefreshIntervalSeconds(t *testing.T) {
+	for interval, expected := range map[string]float64{
+		"":           1,
+		"-1":         -1,
+		"500ms":      0.5,
+		"30s":        30,
+		"5m":         300,
+		"1h":         3600,
+		"1d":         86400,
+		"1000micros": 0.001,
+		"invalid":    1,
+	} {
+		if got := refreshIntervalSeconds(interval); got != expected {
+			t.Errorf("Wrong refresh interval for %q: expected %f, got %f", interval, expected, got)
+		}
+	}
+}
+
+func TestIndicesSettingsBlocks(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	// curl -XPUT http://localhost:9200/twitter
+	// curl -XPUT http://localhost:9200/facebook
+	// curl -XPUT http://localhost:9200/twitter/_block/write
+	// curl -XPUT http://localhost:9200/facebook/_settings -H "Content-Type: application/json" -d '{"index.blocks.read_only":true,"index.blocks.metadata":true}'
+
+	// curl http://localhost:9200/_all/_settings
+	out := `{"twitter":{"settings":{"index":{"number_of_shards":"1","blocks":{"write":"true"},"provided_name":"twitter","creation_date":"1610031983732","number_of_replicas":"1","uuid":"o4qVd_JQQ9uFKwYl3QSbAA","version":{"created":"7100199"}}}},"facebook":{"settings":{"index":{"number_of_shards":"1","blocks":{"metadata":"true","read_only":"true"},"provided_name":"facebook","creation_date":"1610031991482","number_of_replicas":"1","uuid":"m9un4y-lRpeDwUOXQWzqRg","version":{"created":"7100199"}}}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	c := NewIndicesSettings(log.NewNopLogger(), http.DefaultClient, u)
+	nsr, err := c.fetchAndDecodeIndicesSettings()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode indices settings: %s", err)
+	}
+
+	expected := map[string][]string{
+		"twitter":  {"write"},
+		"facebook": {"metadata", "read_only"},
+	}
+	for index, blocks := range expected {
+		enabled := nsr[index].Settings.IndexInfo.Blocks.Enabled()
+		if len(enabled) != 5 {
+			t.Errorf("Wrong number of blocks for %s", index)
+		}
+		var count int
+		for _, e := range enabled {
+			if e {
+				count++
+			}
+		}
+		if count != len(blocks) {
+			t.Errorf("Wrong number of enabled blocks for %s", index)
+		}
+		for _, block := range blocks {
+			if !enabled[block] {
+				t.Errorf("Block %s should be enabled on %s", block, index)
+			}
+		}
+	}
+}
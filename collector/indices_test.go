@@ -5,9 +5,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func TestIndices(t *testing.T) {
@@ -35,7 +37,7 @@ func TestIndices(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to parse URL: %s", err)
 		}
-		i := NewIndices(log.NewNopLogger(), http.DefaultClient, u, false)
+		i := NewIndices(log.NewNopLogger(), http.DefaultClient, u, false, false)
 		stats, err := i.fetchAndDecodeIndexStats()
 		if err != nil {
 			t.Fatalf("Failed to fetch or decode indices stats: %s", err)
@@ -58,3 +60,272 @@ func TestIndices(t *testing.T) {
 		}
 	}
 }
+
+func TestIndicesSegmentMemory(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1/_doc/1 -H "Content-Type: application/json" -d '{"title":"abc","content":"hello"}'
+	//  curl http://localhost:9200/_all/_stats
+	out := `{"_shards":{"total":2,"successful":1,"failed":0},"_all":{"primaries":{},"total":{}},"indices":{"foo_1":{"uuid":"GNZ0jP9CQS2tQPfhLzdOYA","primaries":{"docs":{"count":1,"deleted":0},"segments":{"count":1,"memory_in_bytes":1652,"terms_memory_in_bytes":1072,"stored_fields_memory_in_bytes":488,"term_vectors_memory_in_bytes":8,"norms_memory_in_bytes":64,"points_memory_in_bytes":4,"doc_values_memory_in_bytes":16,"index_writer_memory_in_bytes":2048,"version_map_memory_in_bytes":128,"fixed_bit_set_memory_in_bytes":32,"max_unsafe_auto_id_timestamp":-1,"file_sizes":{}}},"total":{"docs":{"count":2,"deleted":0},"segments":{"count":2,"memory_in_bytes":3304,"terms_memory_in_bytes":2144,"stored_fields_memory_in_bytes":976,"term_vectors_memory_in_bytes":16,"norms_memory_in_bytes":128,"points_memory_in_bytes":8,"doc_values_memory_in_bytes":32,"index_writer_memory_in_bytes":4096,"version_map_memory_in_bytes":256,"fixed_bit_set_memory_in_bytes":64,"max_unsafe_auto_id_timestamp":-1,"file_sizes":{}}}}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	i := NewIndices(log.NewNopLogger(), http.DefaultClient, u, false, false)
+	stats, err := i.fetchAndDecodeIndexStats()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode indices stats: %s", err)
+	}
+
+	expected := map[string]float64{
+		"elasticsearch_indices_segment_terms_memory_primary":               1072,
+		"elasticsearch_indices_segment_terms_memory_total":                 2144,
+		"elasticsearch_indices_segment_fields_memory_bytes_primary":        488,
+		"elasticsearch_indices_segment_fields_memory_bytes_total":          976,
+		"elasticsearch_indices_segment_term_vectors_memory_primary_bytes":  8,
+		"elasticsearch_indices_segment_term_vectors_memory_total_bytes":    16,
+		"elasticsearch_indices_segment_norms_memory_bytes_primary":         64,
+		"elasticsearch_indices_segment_norms_memory_bytes_total":           128,
+		"elasticsearch_indices_segment_points_memory_bytes_primary":        4,
+		"elasticsearch_indices_segment_points_memory_bytes_total":          8,
+		"elasticsearch_indices_segment_doc_values_memory_bytes_primary":    16,
+		"elasticsearch_indices_segment_doc_values_memory_bytes_total":      32,
+		"elasticsearch_indices_segment_index_writer_memory_bytes_primary":  2048,
+		"elasticsearch_indices_segment_index_writer_memory_bytes_total":    4096,
+		"elasticsearch_indices_segment_version_map_memory_bytes_primary":   128,
+		"elasticsearch_indices_segment_version_map_memory_bytes_total":     256,
+		"elasticsearch_indices_segment_fixed_bit_set_memory_bytes_primary": 32,
+		"elasticsearch_indices_segment_fixed_bit_set_memory_bytes_total":   64,
+	}
+	for _, metric := range i.indexMetrics {
+		for name, value := range expected {
+			if !strings.Contains(metric.Desc.String(), `fqName: "`+name+`"`) {
+				continue
+			}
+			if got := metric.Value(stats.Indices["foo_1"]); got != value {
+				t.Errorf("Wrong value for %s: expected %f, got %f", name, value, got)
+			}
+			delete(expected, name)
+		}
+	}
+	for name := range expected {
+		t.Errorf("Missing metric %s", name)
+	}
+}
+
+func TestIndicesDeletedDocsRatio(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1/_doc/1 -H "Content-Type: application/json" -d '{"title":"abc"}'
+	//  curl -XPUT http://localhost:9200/foo_1/_doc/1 -H "Content-Type: application/json" -d '{"title":"def"}'
+	//  curl http://localhost:9200/_all/_stats
+	out := `{"_shards":{"total":4,"successful":2,"failed":0},"_all":{"primaries":{},"total":{}},"indices":{"foo_1":{"uuid":"GNZ0jP9CQS2tQPfhLzdOYA","primaries":{"docs":{"count":3,"deleted":1}},"total":{"docs":{"count":3,"deleted":1}}},"foo_2":{"uuid":"kt2cGV-yQRaloESpqj2zsg","primaries":{"docs":{"count":0,"deleted":0}},"total":{"docs":{"count":0,"deleted":0}}}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	i := NewIndices(log.NewNopLogger(), http.DefaultClient, u, false, false)
+	stats, err := i.fetchAndDecodeIndexStats()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode indices stats: %s", err)
+	}
+
+	expected := map[string]float64{"foo_1": 0.25, "foo_2": 0}
+	for _, metric := range i.indexMetrics {
+		if !strings.Contains(metric.Desc.String(), `fqName: "elasticsearch_indices_deleted_docs_ratio"`) {
+			continue
+		}
+		for index, e := range expected {
+			if got := metric.Value(stats.Indices[index]); got != e {
+				t.Errorf("Wrong deleted docs ratio for %s: expected %f, got %f", index, e, got)
+			}
+		}
+		return
+	}
+	t.Errorf("Missing metric elasticsearch_indices_deleted_docs_ratio")
+}
+
+func TestIndicesCaches(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1/_doc/1 -H "Content-Type: application/json" -d '{"title":"abc","content":"hello"}'
+	//  curl http://localhost:9200/foo_1/_search?request_cache=true -H "Content-Type: application/json" -d '{"size":0,"query":{"bool":{"filter":{"term":{"title":"abc"}}}},"aggs":{"titles":{"terms":{"field":"title.keyword"}}}}'
+	//  curl http://localhost:9200/_all/_stats
+	out := `{"_shards":{"total":2,"successful":1,"failed":0},"_all":{"primaries":{},"total":{}},"indices":{"foo_1":{"uuid":"GNZ0jP9CQS2tQPfhLzdOYA","primaries":{},"total":{"query_cache":{"memory_size_in_bytes":1024,"total_count":12,"hit_count":9,"miss_count":3,"cache_size":2,"cache_count":3,"evictions":1},"request_cache":{"memory_size_in_bytes":2048,"evictions":2,"hit_count":7,"miss_count":5}}}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	i := NewIndices(log.NewNopLogger(), http.DefaultClient, u, false, false)
+	stats, err := i.fetchAndDecodeIndexStats()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode indices stats: %s", err)
+	}
+
+	expected := map[string]struct {
+		valueType prometheus.ValueType
+		value     float64
+	}{
+		"elasticsearch_index_stats_query_cache_memory_bytes":         {prometheus.GaugeValue, 1024},
+		"elasticsearch_index_stats_query_cache_memory_bytes_total":   {prometheus.GaugeValue, 1024},
+		"elasticsearch_index_stats_query_cache_size":                 {prometheus.GaugeValue, 2},
+		"elasticsearch_index_stats_query_cache_hits_total":           {prometheus.CounterValue, 9},
+		"elasticsearch_index_stats_query_cache_misses_total":         {prometheus.CounterValue, 3},
+		"elasticsearch_index_stats_query_cache_caches_total":         {prometheus.CounterValue, 3},
+		"elasticsearch_index_stats_query_cache_evictions_total":      {prometheus.CounterValue, 1},
+		"elasticsearch_index_stats_request_cache_memory_bytes":       {prometheus.GaugeValue, 2048},
+		"elasticsearch_index_stats_request_cache_memory_bytes_total": {prometheus.GaugeValue, 2048},
+		"elasticsearch_index_stats_request_cache_hits_total":         {prometheus.CounterValue, 7},
+		"elasticsearch_index_stats_request_cache_misses_total":       {prometheus.CounterValue, 5},
+		"elasticsearch_index_stats_request_cache_evictions_total":    {prometheus.CounterValue, 2},
+	}
+	for _, metric := range i.indexMetrics {
+		for name, e := range expected {
+			if !strings.Contains(metric.Desc.String(), `fqName: "`+name+`"`) {
+				continue
+			}
+			if metric.Type != e.valueType {
+				t.Errorf("Wrong type for %s", name)
+			}
+			if got := metric.Value(stats.Indices["foo_1"]); got != e.value {
+				t.Errorf("Wrong value for %s: expected %f, got %f", name, e.value, got)
+			}
+			delete(expected, name)
+		}
+	}
+	for name := range expected {
+		t.Errorf("Missing metric %s", name)
+	}
+}
+
+func TestIndicesSystemIndices(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1/_doc/1 -H "Content-Type: application/json" -d '{"title":"abc","content":"hello"}'
+	//  curl -XPUT http://localhost:9200/.foo_hidden -H "Content-Type: application/json" -d '{"settings":{"index.hidden":true}}'
+	//  curl -XPOST http://localhost:9200/_security/user/foo -H "Content-Type: application/json" -d '{"password":"foobar","roles":[]}'
+	//  curl http://localhost:9200/_all/_stats?expand_wildcards=all&filter_path=indices.*.primaries.store,indices.*.total.store
+	//  curl http://localhost:9200/_cluster/state/metadata?filter_path=metadata.indices.*.system
+	stats := `{"indices":{"foo_1":{"primaries":{"store":{"size_in_bytes":4520}},"total":{"store":{"size_in_bytes":4520}}},".foo_hidden":{"primaries":{"store":{"size_in_bytes":225}},"total":{"store":{"size_in_bytes":225}}},".security-7":{"primaries":{"store":{"size_in_bytes":9856}},"total":{"store":{"size_in_bytes":19712}}}}}`
+	state := `{"metadata":{"indices":{"foo_1":{"system":false},".foo_hidden":{"system":false},".security-7":{"system":true}}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_cluster/state/metadata" {
+			fmt.Fprintln(w, state)
+			return
+		}
+		if r.URL.Query().Get("expand_wildcards") != "all" {
+			t.Errorf("Hidden indices not requested: %s", r.URL)
+		}
+		fmt.Fprintln(w, stats)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	i := NewIndices(log.NewNopLogger(), http.DefaultClient, u, false, true)
+	isr, err := i.fetchAndDecodeIndexStats()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode indices stats: %s", err)
+	}
+	if len(isr.Indices) != 3 {
+		t.Errorf("Wrong number of indices: %d", len(isr.Indices))
+	}
+	system, err := i.fetchAndDecodeSystemIndices()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode system indices: %s", err)
+	}
+	if len(system) != 1 || !system[".security-7"] {
+		t.Errorf("Wrong system indices %v", system)
+	}
+}
+
+func TestIndicesRefreshFlush(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1/_doc/1 -H "Content-Type: application/json" -d '{"title":"abc","content":"hello"}'
+	//  curl -XPOST http://localhost:9200/foo_1/_refresh
+	//  curl -XPOST http://localhost:9200/foo_1/_flush
+	//  curl http://localhost:9200/_all/_stats
+	out := `{"_shards":{"total":2,"successful":1,"failed":0},"_all":{"primaries":{},"total":{}},"indices":{"foo_1":{"uuid":"GNZ0jP9CQS2tQPfhLzdOYA","primaries":{},"total":{"refresh":{"total":14,"total_time_in_millis":2500,"external_total":6,"external_total_time_in_millis":1500,"listeners":0},"flush":{"total":4,"periodic":3,"total_time_in_millis":750}}}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	i := NewIndices(log.NewNopLogger(), http.DefaultClient, u, false, false)
+	stats, err := i.fetchAndDecodeIndexStats()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode indices stats: %s", err)
+	}
+
+	expected := map[string]float64{
+		"elasticsearch_index_stats_refresh_total":                       14,
+		"elasticsearch_index_stats_refresh_time_seconds_total":          2.5,
+		"elasticsearch_index_stats_refresh_external_total":              6,
+		"elasticsearch_index_stats_refresh_external_time_seconds_total": 1.5,
+		"elasticsearch_index_stats_flush_total":                         4,
+		"elasticsearch_index_stats_flush_periodic_total":                3,
+		"elasticsearch_index_stats_flush_time_seconds_total":            0.75,
+	}
+	for _, metric := range i.indexMetrics {
+		for name, value := range expected {
+			if !strings.Contains(metric.Desc.String(), `fqName: "`+name+`"`) {
+				continue
+			}
+			if got := metric.Value(stats.Indices["foo_1"]); got != value {
+				t.Errorf("Wrong value for %s: expected %f, got %f", name, value, got)
+			}
+			delete(expected, name)
+		}
+	}
+	for name := range expected {
+		t.Errorf("Missing metric %s", name)
+	}
+}
+
+func TestIndicesGlobalOrdinals(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1/_doc/1 -H "Content-Type: application/json" -d '{"title":"abc","content":"hello"}'
+	//  curl http://localhost:9200/foo_1/_search -H "Content-Type: application/json" -d '{"size":0,"aggs":{"titles":{"terms":{"field":"title.keyword"}}}}'
+	//  curl http://localhost:9200/_all/_stats/fielddata
+	out := `{"_shards":{"total":2,"successful":1,"failed":0},"_all":{"primaries":{},"total":{}},"indices":{"foo_1":{"uuid":"GNZ0jP9CQS2tQPfhLzdOYA","primaries":{},"total":{"fielddata":{"memory_size_in_bytes":0,"evictions":0,"global_ordinals":{"build_time_in_millis":250}}}}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	i := NewIndices(log.NewNopLogger(), http.DefaultClient, u, false, false)
+	stats, err := i.fetchAndDecodeIndexStats()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode indices stats: %s", err)
+	}
+	if got := stats.Indices["foo_1"].Total.Fielddata.GlobalOrdinals.BuildTimeInMillis; got != 250 {
+		t.Errorf("Wrong global ordinals build time %d", got)
+	}
+}
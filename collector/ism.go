@@ -0,0 +1,253 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type ismIndexMetric struct {
+	Type   prometheus.ValueType
+	Desc   *prometheus.Desc
+	Value  func(index ismIndexResponse, now time.Time) float64
+	Labels func(indexName string, index ismIndexResponse) []string
+}
+
+// ISM information struct
+type ISM struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	managedIndices  *prometheus.Desc
+	ismIndexMetrics []*ismIndexMetric
+}
+
+func init() {
+	registerCollector("ism", defaultDisabled, "Export the state of indices managed by index state management. Requires OpenSearch.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewISM(logger, client, url)
+	})
+}
+
+// NewISM defines ISM Prometheus metrics
+func NewISM(logger log.Logger, client *http.Client, url *url.URL) *ISM {
+	subsystem := "ism"
+
+	return &ISM{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch ISM explain endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch ISM explain scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		managedIndices: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "managed_indices"),
+			"Number of indices managed by ISM",
+			nil, nil,
+		),
+		ismIndexMetrics: []*ismIndexMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_state"),
+					"Current ISM state of the index",
+					[]string{"index", "policy", "state"}, nil,
+				),
+				Value: func(index ismIndexResponse, now time.Time) float64 {
+					return 1
+				},
+				Labels: func(indexName string, index ismIndexResponse) []string {
+					return []string{indexName, index.PolicyID, index.State.Name}
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_state_seconds"),
+					"Time the index has spent in its current ISM state in seconds",
+					[]string{"index", "policy", "state"}, nil,
+				),
+				Value: func(index ismIndexResponse, now time.Time) float64 {
+					return millisSince(now, index.State.StartTime)
+				},
+				Labels: func(indexName string, index ismIndexResponse) []string {
+					return []string{indexName, index.PolicyID, index.State.Name}
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_action_seconds"),
+					"Time the index has spent in its current ISM action in seconds",
+					[]string{"index", "policy", "state", "action"}, nil,
+				),
+				Value: func(index ismIndexResponse, now time.Time) float64 {
+					return millisSince(now, index.Action.StartTime)
+				},
+				Labels: func(indexName string, index ismIndexResponse) []string {
+					return []string{indexName, index.PolicyID, index.State.Name, index.Action.Name}
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_action_retries"),
+					"Number of retries consumed by the current ISM action of the index",
+					[]string{"index", "policy", "action"}, nil,
+				),
+				Value: func(index ismIndexResponse, now time.Time) float64 {
+					return float64(index.Action.ConsumedRetries)
+				},
+				Labels: func(indexName string, index ismIndexResponse) []string {
+					return []string{indexName, index.PolicyID, index.Action.Name}
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_failed"),
+					"Whether the current ISM action of the index has failed",
+					[]string{"index", "policy", "action"}, nil,
+				),
+				Value: func(index ismIndexResponse, now time.Time) float64 {
+					return bool2Float(index.Action.Failed || index.RetryInfo.Failed)
+				},
+				Labels: func(indexName string, index ismIndexResponse) []string {
+					return []string{indexName, index.PolicyID, index.Action.Name}
+				},
+			},
+		},
+	}
+}
+
+// Describe add ISM metrics descriptions
+func (i *ISM) Describe(ch chan<- *prometheus.Desc) {
+	ch <- i.managedIndices
+	for _, metric := range i.ismIndexMetrics {
+		ch <- metric.Desc
+	}
+	ch <- i.up.Desc()
+	ch <- i.totalScrapes.Desc()
+	ch <- i.jsonParseFailures.Desc()
+}
+
+func (i *ISM) getAndParseURL(u *url.URL, data interface{}) error {
+	res, err := i.client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(i.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		i.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+func (i *ISM) fetchAndDecodeISMExplain() (ismExplainResponse, error) {
+	var ier ismExplainResponse
+
+	u := *i.url
+	u.Path = path.Join(u.Path, "/_plugins/_ism/explain")
+	var raw map[string]json.RawMessage
+	if err := i.getAndParseURL(&u, &raw); err != nil {
+		return ier, err
+	}
+
+	// the indices are returned next to the total_managed_indices counter
+	ier.Indices = make(map[string]ismIndexResponse, len(raw))
+	for name, value := range raw {
+		if name == "total_managed_indices" {
+			if err := json.Unmarshal(value, &ier.TotalManagedIndices); err != nil {
+				i.jsonParseFailures.Inc()
+				return ier, err
+			}
+			continue
+		}
+		var index ismIndexResponse
+		if err := json.Unmarshal(value, &index); err != nil {
+			i.jsonParseFailures.Inc()
+			return ier, err
+		}
+		ier.Indices[name] = index
+	}
+	return ier, nil
+}
+
+// Collect gets ISM metric values
+func (i *ISM) Collect(ch chan<- prometheus.Metric) {
+	i.totalScrapes.Inc()
+	defer func() {
+		ch <- i.up
+		ch <- i.totalScrapes
+		ch <- i.jsonParseFailures
+	}()
+
+	ier, err := i.fetchAndDecodeISMExplain()
+	if err != nil {
+		i.up.Set(0)
+		_ = level.Warn(i.logger).Log(
+			"msg", "failed to fetch and decode ISM explain",
+			"err", err,
+		)
+		return
+	}
+	i.up.Set(1)
+
+	ch <- prometheus.MustNewConstMetric(
+		i.managedIndices,
+		prometheus.GaugeValue,
+		float64(ier.TotalManagedIndices),
+	)
+
+	now := time.Now()
+	for indexName, index := range ier.Indices {
+		// indices which are not managed by a policy are reported without policy
+		if index.PolicyID == "" {
+			continue
+		}
+		for _, metric := range i.ismIndexMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(index, now),
+				metric.Labels(indexName, index)...,
+			)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package collector
+
+// ismExplainResponse is a representation of the OpenSearch _plugins/_ism/explain endpoint
+type ismExplainResponse struct {
+	TotalManagedIndices int64
+	Indices             map[string]ismIndexResponse
+}
+
+// ismIndexResponse defines the ISM explain information of a single index
+type ismIndexResponse struct {
+	Index    string `json:"index"`
+	PolicyID string `json:"policy_id"`
+	Enabled  bool   `json:"enabled"`
+	State    struct {
+		Name      string `json:"name"`
+		StartTime int64  `json:"start_time"`
+	} `json:"state"`
+	Action struct {
+		Name            string `json:"name"`
+		StartTime       int64  `json:"start_time"`
+		Failed          bool   `json:"failed"`
+		ConsumedRetries int64  `json:"consumed_retries"`
+	} `json:"action"`
+	Step struct {
+		Name       string `json:"name"`
+		StartTime  int64  `json:"start_time"`
+		StepStatus string `json:"step_status"`
+	} `json:"step"`
+	RetryInfo struct {
+		Failed          bool  `json:"failed"`
+		ConsumedRetries int64 `json:"consumed_retries"`
+	} `json:"retry_info"`
+}
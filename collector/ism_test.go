@@ -0,0 +1,52 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestISM(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e plugins.security.disabled=true opensearchproject/opensearch:VERSION
+	//  curl -XPUT http://localhost:9200/_plugins/_ism/policies/hot_warm -d '{"policy":{"default_state":"hot","states":[{"name":"hot","actions":[{"rollover":{"min_index_age":"1d"}}],"transitions":[]}],"ism_template":{"index_patterns":["logs-*"]}}}'
+	//  curl -XPUT http://localhost:9200/logs-000001
+	//  curl http://localhost:9200/_plugins/_ism/explain
+	tcs := map[string]string{
+		"2.11.0": `{"logs-000001":{"index.plugins.index_state_management.policy_id":"hot_warm","index.opendistro.index_state_management.policy_id":"hot_warm","index":"logs-000001","index_uuid":"RAha2wBgRZ2kVLuFsCFQzA","policy_id":"hot_warm","policy_seq_no":0,"policy_primary_term":1,"rolled_over":false,"index_creation_date":1700344621124,"state":{"name":"hot","start_time":1700344680000},"action":{"name":"rollover","start_time":1700344740000,"index":0,"failed":true,"consumed_retries":3,"last_retry_time":1700345040000},"step":{"name":"attempt_rollover","start_time":1700344740000,"step_status":"failed"},"retry_info":{"failed":true,"consumed_retries":3},"info":{"message":"Missing rollover_alias index setting [index=logs-000001]"},"enabled":false},"total_managed_indices":1}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		i := NewISM(log.NewNopLogger(), http.DefaultClient, u)
+		ier, err := i.fetchAndDecodeISMExplain()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode ISM explain: %s", err)
+		}
+		t.Logf("[%s] ISM Explain Response: %+v", ver, ier)
+		if ier.TotalManagedIndices != 1 || len(ier.Indices) != 1 {
+			t.Fatalf("Wrong number of managed indices")
+		}
+		index := ier.Indices["logs-000001"]
+		if index.PolicyID != "hot_warm" || index.State.Name != "hot" || index.State.StartTime != 1700344680000 {
+			t.Errorf("Wrong ISM policy or state for index logs-000001")
+		}
+		if index.Action.Name != "rollover" || !index.Action.Failed || index.Action.ConsumedRetries != 3 {
+			t.Errorf("Wrong ISM action for index logs-000001")
+		}
+		if index.Step.StepStatus != "failed" || !index.RetryInfo.Failed {
+			t.Errorf("Wrong ISM step or retry info for index logs-000001")
+		}
+	}
+}
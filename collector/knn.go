@@ -0,0 +1,249 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type knnNodeMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(node knnNodeStatsResponse) float64
+}
+
+// KNN information struct
+type KNN struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	circuitBreakerTriggered *prometheus.Desc
+	nodeMetrics             []*knnNodeMetric
+}
+
+func init() {
+	registerCollector("knn", defaultDisabled, "Export stats of the k-NN plugin. Requires OpenSearch.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewKNN(logger, client, url)
+	})
+}
+
+// NewKNN defines k-NN Prometheus metrics
+func NewKNN(logger log.Logger, client *http.Client, url *url.URL) *KNN {
+	subsystem := "knn"
+
+	return &KNN{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch k-NN stats endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch k-NN stats scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		circuitBreakerTriggered: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "circuit_breaker_triggered"),
+			"Whether the k-NN circuit breaker is triggered, which rejects indexing of k-NN vectors",
+			nil, nil,
+		),
+		nodeMetrics: []*knnNodeMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "graph_memory_usage_bytes"),
+					"Native memory used by the k-NN graphs loaded on the node in bytes",
+					[]string{"node"}, nil,
+				),
+				Value: func(node knnNodeStatsResponse) float64 {
+					return float64(node.GraphMemoryUsageKB) * 1024
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "graph_memory_usage_ratio"),
+					"Native memory used by the k-NN graphs as ratio of the circuit breaker limit",
+					[]string{"node"}, nil,
+				),
+				Value: func(node knnNodeStatsResponse) float64 {
+					return node.GraphMemoryUsagePercentage / 100
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "cache_capacity_reached"),
+					"Whether the k-NN graph cache of the node has reached its capacity",
+					[]string{"node"}, nil,
+				),
+				Value: func(node knnNodeStatsResponse) float64 {
+					return bool2Float(node.CacheCapacityReached)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "cache_hits_total"),
+					"Total number of k-NN graph cache hits",
+					[]string{"node"}, nil,
+				),
+				Value: func(node knnNodeStatsResponse) float64 {
+					return float64(node.HitCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "cache_misses_total"),
+					"Total number of k-NN graph cache misses",
+					[]string{"node"}, nil,
+				),
+				Value: func(node knnNodeStatsResponse) float64 {
+					return float64(node.MissCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "cache_evictions_total"),
+					"Total number of k-NN graphs evicted from the cache",
+					[]string{"node"}, nil,
+				),
+				Value: func(node knnNodeStatsResponse) float64 {
+					return float64(node.EvictionCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "graph_query_requests_total"),
+					"Total number of k-NN graph queries",
+					[]string{"node"}, nil,
+				),
+				Value: func(node knnNodeStatsResponse) float64 {
+					return float64(node.GraphQueryRequests)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "graph_query_errors_total"),
+					"Total number of failed k-NN graph queries",
+					[]string{"node"}, nil,
+				),
+				Value: func(node knnNodeStatsResponse) float64 {
+					return float64(node.GraphQueryErrors)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "load_exceptions_total"),
+					"Total number of failures loading a k-NN graph into the cache",
+					[]string{"node"}, nil,
+				),
+				Value: func(node knnNodeStatsResponse) float64 {
+					return float64(node.LoadExceptionCount)
+				},
+			},
+		},
+	}
+}
+
+// Describe add k-NN metrics descriptions
+func (k *KNN) Describe(ch chan<- *prometheus.Desc) {
+	ch <- k.circuitBreakerTriggered
+	for _, metric := range k.nodeMetrics {
+		ch <- metric.Desc
+	}
+	ch <- k.up.Desc()
+	ch <- k.totalScrapes.Desc()
+	ch <- k.jsonParseFailures.Desc()
+}
+
+func (k *KNN) fetchAndDecodeKNNStats() (knnStatsResponse, error) {
+	var ksr knnStatsResponse
+
+	u := *k.url
+	u.Path = path.Join(u.Path, "/_plugins/_knn/stats")
+
+	res, err := k.client.Get(u.String())
+	if err != nil {
+		return ksr, fmt.Errorf("failed to get k-NN stats from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(k.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return ksr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&ksr); err != nil {
+		k.jsonParseFailures.Inc()
+		return ksr, err
+	}
+	return ksr, nil
+}
+
+// Collect gets k-NN metric values
+func (k *KNN) Collect(ch chan<- prometheus.Metric) {
+	k.totalScrapes.Inc()
+	defer func() {
+		ch <- k.up
+		ch <- k.totalScrapes
+		ch <- k.jsonParseFailures
+	}()
+
+	ksr, err := k.fetchAndDecodeKNNStats()
+	if err != nil {
+		k.up.Set(0)
+		_ = level.Warn(k.logger).Log(
+			"msg", "failed to fetch and decode k-NN stats",
+			"err", err,
+		)
+		return
+	}
+	k.up.Set(1)
+
+	ch <- prometheus.MustNewConstMetric(
+		k.circuitBreakerTriggered,
+		prometheus.GaugeValue,
+		bool2Float(ksr.CircuitBreakerTriggered),
+	)
+	for id, node := range ksr.Nodes {
+		for _, metric := range k.nodeMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(node),
+				id,
+			)
+		}
+	}
+}
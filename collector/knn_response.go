@@ -0,0 +1,27 @@
+package collector
+
+// knnStatsResponse is a representation of the OpenSearch _plugins/_knn/stats endpoint
+type knnStatsResponse struct {
+	ClusterName             string                          `json:"cluster_name"`
+	CircuitBreakerTriggered bool                            `json:"circuit_breaker_triggered"`
+	Nodes                   map[string]knnNodeStatsResponse `json:"nodes"`
+}
+
+// knnNodeStatsResponse defines the k-NN stats of a single node, keyed by node id
+type knnNodeStatsResponse struct {
+	GraphMemoryUsageKB         int64   `json:"graph_memory_usage"`
+	GraphMemoryUsagePercentage float64 `json:"graph_memory_usage_percentage"`
+	CacheCapacityReached       bool    `json:"cache_capacity_reached"`
+	HitCount                   int64   `json:"hit_count"`
+	MissCount                  int64   `json:"miss_count"`
+	EvictionCount              int64   `json:"eviction_count"`
+	LoadSuccessCount           int64   `json:"load_success_count"`
+	LoadExceptionCount         int64   `json:"load_exception_count"`
+	TotalLoadTime              int64   `json:"total_load_time"`
+	GraphQueryRequests         int64   `json:"graph_query_requests"`
+	GraphQueryErrors           int64   `json:"graph_query_errors"`
+	GraphIndexRequests         int64   `json:"graph_index_requests"`
+	GraphIndexErrors           int64   `json:"graph_index_errors"`
+	KNNQueryRequests           int64   `json:"knn_query_requests"`
+	ScriptQueryErrors          int64   `json:"script_query_errors"`
+}
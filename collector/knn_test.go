@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestKNN(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e plugins.security.disabled=true opensearchproject/opensearch:VERSION
+	//  curl -XPUT http://localhost:9200/vectors -d '{"settings":{"index.knn":true},"mappings":{"properties":{"v":{"type":"knn_vector","dimension":2}}}}'
+	//  curl http://localhost:9200/_plugins/_knn/stats
+	tcs := map[string]string{
+		"2.11.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"opensearch","circuit_breaker_triggered":false,"model_index_status":null,"nodes":{"JdfxIkOSSNCNo3aqJ5tQzA":{"max_distance_query_with_filter_requests":0,"graph_memory_usage_percentage":34.72,"graph_query_requests":82,"graph_memory_usage":3556,"cache_capacity_reached":false,"load_success_count":4,"training_memory_usage":0,"indices_in_cache":{"vectors":{"graph_memory_usage":3556,"graph_memory_usage_percentage":34.72,"graph_count":4}},"script_query_errors":0,"hit_count":78,"knn_query_requests":20,"total_load_time":2436679306,"miss_count":4,"graph_query_errors":1,"eviction_count":0,"script_compilation_errors":0,"load_exception_count":0,"graph_index_requests":12,"graph_index_errors":0}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		k := NewKNN(log.NewNopLogger(), http.DefaultClient, u)
+		ksr, err := k.fetchAndDecodeKNNStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode k-NN stats: %s", err)
+		}
+		t.Logf("[%s] k-NN Stats Response: %+v", ver, ksr)
+		if ksr.CircuitBreakerTriggered || len(ksr.Nodes) != 1 {
+			t.Fatalf("Wrong k-NN stats")
+		}
+		node := ksr.Nodes["JdfxIkOSSNCNo3aqJ5tQzA"]
+		if node.GraphMemoryUsageKB != 3556 || node.GraphMemoryUsagePercentage != 34.72 {
+			t.Errorf("Wrong k-NN graph memory usage %+v", node)
+		}
+		if node.HitCount != 78 || node.MissCount != 4 || node.EvictionCount != 0 {
+			t.Errorf("Wrong k-NN cache stats %+v", node)
+		}
+		if node.GraphQueryRequests != 82 || node.GraphQueryErrors != 1 {
+			t.Errorf("Wrong k-NN graph query stats %+v", node)
+		}
+	}
+}
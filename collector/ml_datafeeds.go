@@ -0,0 +1,221 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	defaultMLDatafeedLabels = []string{"datafeed", "job"}
+
+	mlDatafeedStates = []string{"starting", "started", "stopping", "stopped"}
+)
+
+type mlDatafeedMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(datafeed mlDatafeedResponse) float64
+}
+
+// MLDatafeeds information struct
+type MLDatafeeds struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	datafeedMetrics []*mlDatafeedMetric
+	state           *prometheus.Desc
+}
+
+func init() {
+	registerCollector("ml_datafeeds", defaultDisabled, "Export stats for ML datafeeds.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewMLDatafeeds(logger, client, url)
+	})
+}
+
+// NewMLDatafeeds defines ML Datafeeds Prometheus metrics
+func NewMLDatafeeds(logger log.Logger, client *http.Client, url *url.URL) *MLDatafeeds {
+	subsystem := "ml_datafeed"
+
+	return &MLDatafeeds{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch ML datafeed stats endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch ML datafeed stats scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		datafeedMetrics: []*mlDatafeedMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "searches_total"),
+					"Total number of searches executed by the datafeed",
+					defaultMLDatafeedLabels, nil,
+				),
+				Value: func(datafeed mlDatafeedResponse) float64 {
+					return float64(datafeed.TimingStats.SearchCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "search_time_seconds_total"),
+					"Total time spent searching by the datafeed in seconds",
+					defaultMLDatafeedLabels, nil,
+				),
+				Value: func(datafeed mlDatafeedResponse) float64 {
+					return datafeed.TimingStats.TotalSearchTimeMs / 1000
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "buckets_total"),
+					"Total number of buckets processed by the datafeed",
+					defaultMLDatafeedLabels, nil,
+				),
+				Value: func(datafeed mlDatafeedResponse) float64 {
+					return float64(datafeed.TimingStats.BucketCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "search_time_per_bucket_average_seconds"),
+					"Average search time per bucket of the datafeed in seconds",
+					defaultMLDatafeedLabels, nil,
+				),
+				Value: func(datafeed mlDatafeedResponse) float64 {
+					return datafeed.TimingStats.AverageSearchTimePerBucketMs / 1000
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "real_time_running"),
+					"Whether the datafeed is running in real time",
+					defaultMLDatafeedLabels, nil,
+				),
+				Value: func(datafeed mlDatafeedResponse) float64 {
+					if datafeed.RunningState.RealTimeRunning {
+						return 1
+					}
+					return 0
+				},
+			},
+		},
+		state: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "state"),
+			"State of the datafeed",
+			append(defaultMLDatafeedLabels, "state"), nil,
+		),
+	}
+}
+
+// Describe add ML Datafeeds metrics descriptions
+func (d *MLDatafeeds) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range d.datafeedMetrics {
+		ch <- metric.Desc
+	}
+	ch <- d.state
+	ch <- d.up.Desc()
+	ch <- d.totalScrapes.Desc()
+	ch <- d.jsonParseFailures.Desc()
+}
+
+func (d *MLDatafeeds) fetchAndDecodeMLDatafeedStats() (mlDatafeedStatsResponse, error) {
+	var dsr mlDatafeedStatsResponse
+
+	u := *d.url
+	u.Path = path.Join(u.Path, "/_ml/datafeeds/_stats")
+
+	res, err := d.client.Get(u.String())
+	if err != nil {
+		return dsr, fmt.Errorf("failed to get ML datafeed stats from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(d.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return dsr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&dsr); err != nil {
+		d.jsonParseFailures.Inc()
+		return dsr, err
+	}
+	return dsr, nil
+}
+
+// Collect gets ML Datafeeds metric values
+func (d *MLDatafeeds) Collect(ch chan<- prometheus.Metric) {
+	d.totalScrapes.Inc()
+	defer func() {
+		ch <- d.up
+		ch <- d.totalScrapes
+		ch <- d.jsonParseFailures
+	}()
+
+	dsr, err := d.fetchAndDecodeMLDatafeedStats()
+	if err != nil {
+		d.up.Set(0)
+		_ = level.Warn(d.logger).Log(
+			"msg", "failed to fetch and decode ML datafeed stats",
+			"err", err,
+		)
+		return
+	}
+	d.up.Set(1)
+
+	for _, datafeed := range dsr.Datafeeds {
+		for _, metric := range d.datafeedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(datafeed),
+				datafeed.DatafeedID, datafeed.TimingStats.JobID,
+			)
+		}
+		for _, state := range mlDatafeedStates {
+			var value float64
+			if datafeed.State == state {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				d.state,
+				prometheus.GaugeValue,
+				value,
+				datafeed.DatafeedID, datafeed.TimingStats.JobID, state,
+			)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package collector
+
+// mlDatafeedStatsResponse is a representation of the Elasticsearch _ml/datafeeds/_stats endpoint
+type mlDatafeedStatsResponse struct {
+	Count     int64                `json:"count"`
+	Datafeeds []mlDatafeedResponse `json:"datafeeds"`
+}
+
+// mlDatafeedResponse defines the stats of a single datafeed
+type mlDatafeedResponse struct {
+	DatafeedID   string                         `json:"datafeed_id"`
+	State        string                         `json:"state"`
+	TimingStats  mlDatafeedTimingStatsResponse  `json:"timing_stats"`
+	RunningState mlDatafeedRunningStateResponse `json:"running_state"`
+}
+
+// mlDatafeedTimingStatsResponse defines the timing stats of a datafeed
+type mlDatafeedTimingStatsResponse struct {
+	JobID                        string  `json:"job_id"`
+	SearchCount                  int64   `json:"search_count"`
+	BucketCount                  int64   `json:"bucket_count"`
+	TotalSearchTimeMs            float64 `json:"total_search_time_ms"`
+	AverageSearchTimePerBucketMs float64 `json:"average_search_time_per_bucket_ms"`
+}
+
+// mlDatafeedRunningStateResponse defines the running state of a started datafeed
+type mlDatafeedRunningStateResponse struct {
+	RealTimeConfigured bool `json:"real_time_configured"`
+	RealTimeRunning    bool `json:"real_time_running"`
+}
@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestMLDatafeeds(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_ml/anomaly_detectors/low_request_rate -d '{"analysis_config":{"bucket_span":"1h","detectors":[{"function":"low_count"}]},"data_description":{"time_field":"timestamp"}}'
+	//  curl -XPUT http://localhost:9200/_ml/datafeeds/datafeed-low_request_rate -d '{"job_id":"low_request_rate","indices":["logs"]}'
+	//  curl -XPOST http://localhost:9200/_ml/anomaly_detectors/low_request_rate/_open
+	//  curl -XPOST http://localhost:9200/_ml/datafeeds/datafeed-low_request_rate/_start
+	//  curl http://localhost:9200/_ml/datafeeds/_stats
+	tcs := map[string]string{
+		"7.10.0": `{"count":1,"datafeeds":[{"datafeed_id":"datafeed-low_request_rate","state":"started","node":{"id":"7bmMXyWCRs-TuPfGJJ_yMw","name":"node-0","ephemeral_id":"hoXMLZB0RWKfR9UPPUCxXX","transport_address":"127.0.0.1:9300","attributes":{"ml.machine_memory":"17179869184","ml.max_open_jobs":"20"}},"assignment_explanation":"","timing_stats":{"job_id":"low_request_rate","search_count":7,"bucket_count":743,"total_search_time_ms":134.0,"average_search_time_per_bucket_ms":0.18,"exponential_average_search_time_per_hour_ms":0.12},"running_state":{"real_time_configured":true,"real_time_running":true}}]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		d := NewMLDatafeeds(log.NewNopLogger(), http.DefaultClient, u)
+		dsr, err := d.fetchAndDecodeMLDatafeedStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode ML datafeed stats: %s", err)
+		}
+		t.Logf("[%s] ML Datafeed Stats Response: %+v", ver, dsr)
+		if len(dsr.Datafeeds) != 1 {
+			t.Fatalf("Wrong number of datafeeds")
+		}
+		datafeed := dsr.Datafeeds[0]
+		if datafeed.DatafeedID != "datafeed-low_request_rate" || datafeed.State != "started" || datafeed.TimingStats.JobID != "low_request_rate" {
+			t.Errorf("Wrong datafeed")
+		}
+		if datafeed.TimingStats.SearchCount != 7 || datafeed.TimingStats.TotalSearchTimeMs != 134 {
+			t.Errorf("Wrong datafeed timing stats")
+		}
+		if !datafeed.RunningState.RealTimeRunning {
+			t.Errorf("Datafeed should be running in real time")
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	defaultMLJobLabels = []string{"job"}
+
+	mlJobStates         = []string{"closing", "closed", "opened", "failed", "opening"}
+	mlJobMemoryStatuses = []string{"ok", "soft_limit", "hard_limit"}
+)
+
+type mlJobMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(job mlJobResponse) float64
+}
+
+// MLJobs information struct
+type MLJobs struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	jobMetrics   []*mlJobMetric
+	state        *prometheus.Desc
+	memoryStatus *prometheus.Desc
+}
+
+func init() {
+	registerCollector("ml_jobs", defaultDisabled, "Export stats for ML anomaly detection jobs.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewMLJobs(logger, client, url)
+	})
+}
+
+// NewMLJobs defines ML Jobs Prometheus metrics
+func NewMLJobs(logger log.Logger, client *http.Client, url *url.URL) *MLJobs {
+	subsystem := "ml_job"
+
+	return &MLJobs{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch ML anomaly detection job stats endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch ML anomaly detection job stats scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		jobMetrics: []*mlJobMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "model_bytes"),
+					"Number of bytes of memory used by the models of the job",
+					defaultMLJobLabels, nil,
+				),
+				Value: func(job mlJobResponse) float64 {
+					return float64(job.ModelSizeStats.ModelBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "model_bytes_memory_limit"),
+					"Upper limit for the model memory of the job in bytes",
+					defaultMLJobLabels, nil,
+				),
+				Value: func(job mlJobResponse) float64 {
+					return float64(job.ModelSizeStats.ModelBytesMemoryLimit)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "model_bytes_exceeded"),
+					"Number of bytes over the model memory limit at the last allocation failure",
+					defaultMLJobLabels, nil,
+				),
+				Value: func(job mlJobResponse) float64 {
+					return float64(job.ModelSizeStats.ModelBytesExceeded)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "bucket_allocation_failures_total"),
+					"Number of buckets for which new entities were not processed because the hard memory limit was hit",
+					defaultMLJobLabels, nil,
+				),
+				Value: func(job mlJobResponse) float64 {
+					return float64(job.ModelSizeStats.BucketAllocationFailuresCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "processed_records_total"),
+					"Total number of input records processed by the job",
+					defaultMLJobLabels, nil,
+				),
+				Value: func(job mlJobResponse) float64 {
+					return float64(job.DataCounts.ProcessedRecordCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "buckets_total"),
+					"Total number of buckets processed by the job",
+					defaultMLJobLabels, nil,
+				),
+				Value: func(job mlJobResponse) float64 {
+					return float64(job.TimingStats.BucketCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "bucket_processing_time_seconds_total"),
+					"Total time spent processing buckets in seconds",
+					defaultMLJobLabels, nil,
+				),
+				Value: func(job mlJobResponse) float64 {
+					return job.TimingStats.TotalBucketProcessingTimeMs / 1000
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "bucket_processing_time_average_seconds"),
+					"Average time spent processing a bucket in seconds",
+					defaultMLJobLabels, nil,
+				),
+				Value: func(job mlJobResponse) float64 {
+					return job.TimingStats.AverageBucketProcessingTimeMs / 1000
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "bucket_processing_time_maximum_seconds"),
+					"Maximum time spent processing a bucket in seconds",
+					defaultMLJobLabels, nil,
+				),
+				Value: func(job mlJobResponse) float64 {
+					return job.TimingStats.MaximumBucketProcessingTimeMs / 1000
+				},
+			},
+		},
+		state: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "state"),
+			"State of the anomaly detection job",
+			append(defaultMLJobLabels, "state"), nil,
+		),
+		memoryStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "memory_status"),
+			"Memory status of the anomaly detection job (ok, soft_limit or hard_limit)",
+			append(defaultMLJobLabels, "memory_status"), nil,
+		),
+	}
+}
+
+// Describe add ML Jobs metrics descriptions
+func (m *MLJobs) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range m.jobMetrics {
+		ch <- metric.Desc
+	}
+	ch <- m.state
+	ch <- m.memoryStatus
+	ch <- m.up.Desc()
+	ch <- m.totalScrapes.Desc()
+	ch <- m.jsonParseFailures.Desc()
+}
+
+func (m *MLJobs) fetchAndDecodeMLJobStats() (mlJobStatsResponse, error) {
+	var jsr mlJobStatsResponse
+
+	u := *m.url
+	u.Path = path.Join(u.Path, "/_ml/anomaly_detectors/_stats")
+
+	res, err := m.client.Get(u.String())
+	if err != nil {
+		return jsr, fmt.Errorf("failed to get ML anomaly detection job stats from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(m.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return jsr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&jsr); err != nil {
+		m.jsonParseFailures.Inc()
+		return jsr, err
+	}
+	return jsr, nil
+}
+
+// Collect gets ML Jobs metric values
+func (m *MLJobs) Collect(ch chan<- prometheus.Metric) {
+	m.totalScrapes.Inc()
+	defer func() {
+		ch <- m.up
+		ch <- m.totalScrapes
+		ch <- m.jsonParseFailures
+	}()
+
+	jsr, err := m.fetchAndDecodeMLJobStats()
+	if err != nil {
+		m.up.Set(0)
+		_ = level.Warn(m.logger).Log(
+			"msg", "failed to fetch and decode ML anomaly detection job stats",
+			"err", err,
+		)
+		return
+	}
+	m.up.Set(1)
+
+	for _, job := range jsr.Jobs {
+		for _, metric := range m.jobMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(job),
+				job.JobID,
+			)
+		}
+		for _, state := range mlJobStates {
+			var value float64
+			if job.State == state {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				m.state,
+				prometheus.GaugeValue,
+				value,
+				job.JobID, state,
+			)
+		}
+		for _, status := range mlJobMemoryStatuses {
+			var value float64
+			if job.ModelSizeStats.MemoryStatus == status {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				m.memoryStatus,
+				prometheus.GaugeValue,
+				value,
+				job.JobID, status,
+			)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package collector
+
+// mlJobStatsResponse is a representation of the Elasticsearch _ml/anomaly_detectors/_stats endpoint
+type mlJobStatsResponse struct {
+	Count int64           `json:"count"`
+	Jobs  []mlJobResponse `json:"jobs"`
+}
+
+// mlJobResponse defines the stats of a single anomaly detection job
+type mlJobResponse struct {
+	JobID          string                      `json:"job_id"`
+	State          string                      `json:"state"`
+	DataCounts     mlJobDataCountsResponse     `json:"data_counts"`
+	ModelSizeStats mlJobModelSizeStatsResponse `json:"model_size_stats"`
+	TimingStats    mlJobTimingStatsResponse    `json:"timing_stats"`
+}
+
+// mlJobDataCountsResponse defines the data counts of an anomaly detection job
+type mlJobDataCountsResponse struct {
+	ProcessedRecordCount     int64 `json:"processed_record_count"`
+	InputRecordCount         int64 `json:"input_record_count"`
+	MissingFieldCount        int64 `json:"missing_field_count"`
+	OutOfOrderTimestampCount int64 `json:"out_of_order_timestamp_count"`
+	BucketCount              int64 `json:"bucket_count"`
+	LatestRecordTimestamp    int64 `json:"latest_record_timestamp"`
+}
+
+// mlJobModelSizeStatsResponse defines the model size stats of an anomaly detection job
+type mlJobModelSizeStatsResponse struct {
+	ModelBytes                    int64  `json:"model_bytes"`
+	ModelBytesExceeded            int64  `json:"model_bytes_exceeded"`
+	ModelBytesMemoryLimit         int64  `json:"model_bytes_memory_limit"`
+	TotalByFieldCount             int64  `json:"total_by_field_count"`
+	TotalPartitionFieldCount      int64  `json:"total_partition_field_count"`
+	BucketAllocationFailuresCount int64  `json:"bucket_allocation_failures_count"`
+	MemoryStatus                  string `json:"memory_status"`
+}
+
+// mlJobTimingStatsResponse defines the timing stats of an anomaly detection job
+type mlJobTimingStatsResponse struct {
+	BucketCount                   int64   `json:"bucket_count"`
+	TotalBucketProcessingTimeMs   float64 `json:"total_bucket_processing_time_ms"`
+	AverageBucketProcessingTimeMs float64 `json:"average_bucket_processing_time_ms"`
+	MaximumBucketProcessingTimeMs float64 `json:"maximum_bucket_processing_time_ms"`
+}
@@ -0,0 +1,52 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestMLJobs(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_ml/anomaly_detectors/low_request_rate -d '{"analysis_config":{"bucket_span":"1h","detectors":[{"function":"low_count"}]},"data_description":{"time_field":"timestamp"}}'
+	//  curl -XPOST http://localhost:9200/_ml/anomaly_detectors/low_request_rate/_open
+	//  curl http://localhost:9200/_ml/anomaly_detectors/_stats
+	tcs := map[string]string{
+		"7.10.0": `{"count":1,"jobs":[{"job_id":"low_request_rate","data_counts":{"job_id":"low_request_rate","processed_record_count":1216,"processed_field_count":1216,"input_bytes":51678,"input_field_count":1216,"invalid_date_count":0,"missing_field_count":0,"out_of_order_timestamp_count":0,"empty_bucket_count":242,"sparse_bucket_count":0,"bucket_count":1457,"earliest_record_timestamp":1575172659612,"latest_record_timestamp":1580417369440,"last_data_time":1576017595046,"latest_empty_bucket_timestamp":1580356800000,"input_record_count":1216},"model_size_stats":{"job_id":"low_request_rate","result_type":"model_size_stats","model_bytes":41480,"model_bytes_exceeded":10240,"model_bytes_memory_limit":10485760,"total_by_field_count":3,"total_over_field_count":0,"total_partition_field_count":2,"bucket_allocation_failures_count":4,"memory_status":"hard_limit","categorized_doc_count":0,"total_category_count":0,"frequent_category_count":0,"rare_category_count":0,"dead_category_count":0,"categorization_status":"ok","log_time":1576017596000,"timestamp":1580410800000},"forecasts_stats":{"total":0,"forecasted_jobs":0},"state":"opened","node":{"id":"7bmMXyWCRs-TuPfGJJ_yMw","name":"node-0","ephemeral_id":"s-fU6dCcSfu4ux1kUmN9eA","transport_address":"127.0.0.1:9300","attributes":{}},"assignment_explanation":"","open_time":"13s","timing_stats":{"job_id":"low_request_rate","bucket_count":1457,"total_bucket_processing_time_ms":1094.0,"minimum_bucket_processing_time_ms":0.0,"maximum_bucket_processing_time_ms":48.0,"average_bucket_processing_time_ms":0.75,"exponential_average_bucket_processing_time_ms":0.57}}]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		m := NewMLJobs(log.NewNopLogger(), http.DefaultClient, u)
+		jsr, err := m.fetchAndDecodeMLJobStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode ML job stats: %s", err)
+		}
+		t.Logf("[%s] ML Job Stats Response: %+v", ver, jsr)
+		if len(jsr.Jobs) != 1 {
+			t.Fatalf("Wrong number of jobs")
+		}
+		job := jsr.Jobs[0]
+		if job.JobID != "low_request_rate" || job.State != "opened" {
+			t.Errorf("Wrong job")
+		}
+		if job.ModelSizeStats.ModelBytes != 41480 || job.ModelSizeStats.MemoryStatus != "hard_limit" || job.ModelSizeStats.BucketAllocationFailuresCount != 4 {
+			t.Errorf("Wrong model size stats")
+		}
+		if job.TimingStats.TotalBucketProcessingTimeMs != 1094 || job.TimingStats.MaximumBucketProcessingTimeMs != 48 {
+			t.Errorf("Wrong timing stats")
+		}
+	}
+}
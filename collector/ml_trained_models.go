@@ -0,0 +1,375 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	defaultMLTrainedModelLabels      = []string{"model"}
+	defaultMLModelDeploymentLabels   = []string{"model", "deployment"}
+	mlModelDeploymentStates          = []string{"starting", "started", "stopping", "failed"}
+	mlModelDeploymentAllocationState = []string{"starting", "started", "fully_allocated"}
+)
+
+type mlTrainedModelMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(model mlTrainedModelStatResponse) float64
+}
+
+type mlModelDeploymentMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(deployment mlTrainedModelDeploymentStatsResponse) float64
+}
+
+// MLTrainedModels information struct
+type MLTrainedModels struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	modelMetrics      []*mlTrainedModelMetric
+	deploymentMetrics []*mlModelDeploymentMetric
+	deploymentState   *prometheus.Desc
+	allocationState   *prometheus.Desc
+}
+
+func init() {
+	registerCollector("ml_trained_models", defaultDisabled, "Export stats for ML trained models.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewMLTrainedModels(logger, client, url)
+	})
+}
+
+// NewMLTrainedModels defines ML Trained Models Prometheus metrics
+func NewMLTrainedModels(logger log.Logger, client *http.Client, url *url.URL) *MLTrainedModels {
+	subsystem := "ml_trained_model"
+
+	return &MLTrainedModels{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch ML trained model stats endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch ML trained model stats scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		modelMetrics: []*mlTrainedModelMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "size_bytes"),
+					"Size of the trained model in bytes",
+					defaultMLTrainedModelLabels, nil,
+				),
+				Value: func(model mlTrainedModelStatResponse) float64 {
+					return float64(model.ModelSizeStats.ModelSizeBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "pipelines"),
+					"Number of ingest pipelines which reference the trained model",
+					defaultMLTrainedModelLabels, nil,
+				),
+				Value: func(model mlTrainedModelStatResponse) float64 {
+					return float64(model.PipelineCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "inference_total"),
+					"Total number of inference calls of ingest processors using the trained model",
+					defaultMLTrainedModelLabels, nil,
+				),
+				Value: func(model mlTrainedModelStatResponse) float64 {
+					return float64(model.InferenceStats.InferenceCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "inference_failures_total"),
+					"Total number of failed inference calls of ingest processors using the trained model",
+					defaultMLTrainedModelLabels, nil,
+				),
+				Value: func(model mlTrainedModelStatResponse) float64 {
+					return float64(model.InferenceStats.FailureCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "inference_cache_misses_total"),
+					"Total number of inference calls which had to load the trained model",
+					defaultMLTrainedModelLabels, nil,
+				),
+				Value: func(model mlTrainedModelStatResponse) float64 {
+					return float64(model.InferenceStats.CacheMissCount)
+				},
+			},
+		},
+		deploymentMetrics: []*mlModelDeploymentMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deployment_threads_per_allocation"),
+					"Number of threads used by each allocation of the deployment",
+					defaultMLModelDeploymentLabels, nil,
+				),
+				Value: func(deployment mlTrainedModelDeploymentStatsResponse) float64 {
+					return float64(deployment.ThreadsPerAllocation)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deployment_allocations"),
+					"Number of allocations of the deployment",
+					defaultMLModelDeploymentLabels, nil,
+				),
+				Value: func(deployment mlTrainedModelDeploymentStatsResponse) float64 {
+					return float64(deployment.AllocationStatus.AllocationCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deployment_target_allocations"),
+					"Desired number of allocations of the deployment",
+					defaultMLModelDeploymentLabels, nil,
+				),
+				Value: func(deployment mlTrainedModelDeploymentStatsResponse) float64 {
+					return float64(deployment.AllocationStatus.TargetAllocationCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deployment_inference_total"),
+					"Total number of inference calls handled by the deployment",
+					defaultMLModelDeploymentLabels, nil,
+				),
+				Value: func(deployment mlTrainedModelDeploymentStatsResponse) float64 {
+					return deployment.Sum(func(node mlTrainedModelDeploymentNodeStatsResponse) int64 {
+						return node.InferenceCount
+					})
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deployment_inference_cache_hits_total"),
+					"Total number of inference calls of the deployment served from the inference cache",
+					defaultMLModelDeploymentLabels, nil,
+				),
+				Value: func(deployment mlTrainedModelDeploymentStatsResponse) float64 {
+					return deployment.Sum(func(node mlTrainedModelDeploymentNodeStatsResponse) int64 {
+						return node.InferenceCacheHitCount
+					})
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deployment_errors_total"),
+					"Total number of inference calls of the deployment which failed",
+					defaultMLModelDeploymentLabels, nil,
+				),
+				Value: func(deployment mlTrainedModelDeploymentStatsResponse) float64 {
+					return deployment.Sum(func(node mlTrainedModelDeploymentNodeStatsResponse) int64 {
+						return node.ErrorCount
+					})
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deployment_rejected_executions_total"),
+					"Total number of inference calls of the deployment rejected because the queue was full",
+					defaultMLModelDeploymentLabels, nil,
+				),
+				Value: func(deployment mlTrainedModelDeploymentStatsResponse) float64 {
+					return deployment.Sum(func(node mlTrainedModelDeploymentNodeStatsResponse) int64 {
+						return node.RejectedExecutionCount
+					})
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deployment_timeouts_total"),
+					"Total number of inference calls of the deployment which timed out",
+					defaultMLModelDeploymentLabels, nil,
+				),
+				Value: func(deployment mlTrainedModelDeploymentStatsResponse) float64 {
+					return deployment.Sum(func(node mlTrainedModelDeploymentNodeStatsResponse) int64 {
+						return node.TimeoutCount
+					})
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deployment_pending_requests"),
+					"Number of inference calls queued for the deployment",
+					defaultMLModelDeploymentLabels, nil,
+				),
+				Value: func(deployment mlTrainedModelDeploymentStatsResponse) float64 {
+					return deployment.Sum(func(node mlTrainedModelDeploymentNodeStatsResponse) int64 {
+						return node.NumberOfPendingRequests
+					})
+				},
+			},
+		},
+		deploymentState: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "deployment_state"),
+			"State of the trained model deployment",
+			append(defaultMLModelDeploymentLabels, "state"), nil,
+		),
+		allocationState: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "deployment_allocation_state"),
+			"Allocation state of the trained model deployment",
+			append(defaultMLModelDeploymentLabels, "state"), nil,
+		),
+	}
+}
+
+// Describe add ML Trained Models metrics descriptions
+func (tm *MLTrainedModels) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range tm.modelMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range tm.deploymentMetrics {
+		ch <- metric.Desc
+	}
+	ch <- tm.deploymentState
+	ch <- tm.allocationState
+	ch <- tm.up.Desc()
+	ch <- tm.totalScrapes.Desc()
+	ch <- tm.jsonParseFailures.Desc()
+}
+
+func (tm *MLTrainedModels) fetchAndDecodeMLTrainedModelStats() (mlTrainedModelStatsResponse, error) {
+	var msr mlTrainedModelStatsResponse
+
+	u := *tm.url
+	u.Path = path.Join(u.Path, "/_ml/trained_models/_stats")
+	u.RawQuery = "size=1000"
+
+	res, err := tm.client.Get(u.String())
+	if err != nil {
+		return msr, fmt.Errorf("failed to get ML trained model stats from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(tm.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return msr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&msr); err != nil {
+		tm.jsonParseFailures.Inc()
+		return msr, err
+	}
+	return msr, nil
+}
+
+// Collect gets ML Trained Models metric values
+func (tm *MLTrainedModels) Collect(ch chan<- prometheus.Metric) {
+	tm.totalScrapes.Inc()
+	defer func() {
+		ch <- tm.up
+		ch <- tm.totalScrapes
+		ch <- tm.jsonParseFailures
+	}()
+
+	msr, err := tm.fetchAndDecodeMLTrainedModelStats()
+	if err != nil {
+		tm.up.Set(0)
+		_ = level.Warn(tm.logger).Log(
+			"msg", "failed to fetch and decode ML trained model stats",
+			"err", err,
+		)
+		return
+	}
+	tm.up.Set(1)
+
+	for _, model := range msr.TrainedModelStats {
+		for _, metric := range tm.modelMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(model),
+				model.ModelID,
+			)
+		}
+
+		deployment := model.DeploymentStats
+		if deployment == nil {
+			continue
+		}
+		for _, metric := range tm.deploymentMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(*deployment),
+				model.ModelID, deployment.ID(),
+			)
+		}
+		for _, state := range mlModelDeploymentStates {
+			var value float64
+			if deployment.State == state {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				tm.deploymentState,
+				prometheus.GaugeValue,
+				value,
+				model.ModelID, deployment.ID(), state,
+			)
+		}
+		for _, state := range mlModelDeploymentAllocationState {
+			var value float64
+			if deployment.AllocationStatus.State == state {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				tm.allocationState,
+				prometheus.GaugeValue,
+				value,
+				model.ModelID, deployment.ID(), state,
+			)
+		}
+	}
+}
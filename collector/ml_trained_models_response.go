@@ -0,0 +1,73 @@
+package collector
+
+// mlTrainedModelStatsResponse is a representation of the Elasticsearch _ml/trained_models/_stats endpoint
+type mlTrainedModelStatsResponse struct {
+	Count             int64                        `json:"count"`
+	TrainedModelStats []mlTrainedModelStatResponse `json:"trained_model_stats"`
+}
+
+// mlTrainedModelStatResponse defines the stats of a single trained model
+type mlTrainedModelStatResponse struct {
+	ModelID         string                                 `json:"model_id"`
+	PipelineCount   int64                                  `json:"pipeline_count"`
+	ModelSizeStats  mlTrainedModelSizeStatsResponse        `json:"model_size_stats"`
+	InferenceStats  mlTrainedModelInferenceStatsResponse   `json:"inference_stats"`
+	DeploymentStats *mlTrainedModelDeploymentStatsResponse `json:"deployment_stats"`
+}
+
+// mlTrainedModelSizeStatsResponse defines the size stats of a trained model
+type mlTrainedModelSizeStatsResponse struct {
+	ModelSizeBytes            int64 `json:"model_size_bytes"`
+	RequiredNativeMemoryBytes int64 `json:"required_native_memory_bytes"`
+}
+
+// mlTrainedModelInferenceStatsResponse defines the ingest inference stats of a trained model
+type mlTrainedModelInferenceStatsResponse struct {
+	InferenceCount        int64 `json:"inference_count"`
+	FailureCount          int64 `json:"failure_count"`
+	CacheMissCount        int64 `json:"cache_miss_count"`
+	MissingAllFieldsCount int64 `json:"missing_all_fields_count"`
+}
+
+// mlTrainedModelDeploymentStatsResponse defines the stats of a trained model deployment
+type mlTrainedModelDeploymentStatsResponse struct {
+	DeploymentID         string `json:"deployment_id"`
+	ModelID              string `json:"model_id"`
+	State                string `json:"state"`
+	ThreadsPerAllocation int64  `json:"threads_per_allocation"`
+	NumberOfAllocations  int64  `json:"number_of_allocations"`
+	QueueCapacity        int64  `json:"queue_capacity"`
+	AllocationStatus     struct {
+		AllocationCount       int64  `json:"allocation_count"`
+		TargetAllocationCount int64  `json:"target_allocation_count"`
+		State                 string `json:"state"`
+	} `json:"allocation_status"`
+	Nodes []mlTrainedModelDeploymentNodeStatsResponse `json:"nodes"`
+}
+
+// mlTrainedModelDeploymentNodeStatsResponse defines the stats of a trained model deployment on a node
+type mlTrainedModelDeploymentNodeStatsResponse struct {
+	InferenceCount          int64 `json:"inference_count"`
+	InferenceCacheHitCount  int64 `json:"inference_cache_hit_count"`
+	ErrorCount              int64 `json:"error_count"`
+	RejectedExecutionCount  int64 `json:"rejected_execution_count"`
+	TimeoutCount            int64 `json:"timeout_count"`
+	NumberOfPendingRequests int64 `json:"number_of_pending_requests"`
+}
+
+// ID returns the deployment id, which defaults to the model id before 8.8
+func (d mlTrainedModelDeploymentStatsResponse) ID() string {
+	if d.DeploymentID != "" {
+		return d.DeploymentID
+	}
+	return d.ModelID
+}
+
+// Sum adds up a value over all nodes of the deployment
+func (d mlTrainedModelDeploymentStatsResponse) Sum(value func(node mlTrainedModelDeploymentNodeStatsResponse) int64) float64 {
+	var sum int64
+	for _, node := range d.Nodes {
+		sum += value(node)
+	}
+	return float64(sum)
+}
@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestMLTrainedModels(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  eland_import_hub_model --url http://localhost:9200 --hub-model-id elastic/distilbert-base-cased-finetuned-conll03-english --task-type ner
+	//  curl -XPOST http://localhost:9200/_ml/trained_models/elastic__distilbert-base-cased-finetuned-conll03-english/deployment/_start
+	//  curl http://localhost:9200/_ml/trained_models/_stats?size=1000
+	tcs := map[string]string{
+		"8.8.0": `{"count":2,"trained_model_stats":[{"model_id":"elastic__distilbert-base-cased-finetuned-conll03-english","model_size_stats":{"model_size_bytes":260831121,"required_native_memory_bytes":773790146},"pipeline_count":1,"inference_stats":{"failure_count":0,"inference_count":0,"cache_miss_count":0,"missing_all_fields_count":0,"timestamp":1686658907759},"deployment_stats":{"deployment_id":"ner","model_id":"elastic__distilbert-base-cased-finetuned-conll03-english","threads_per_allocation":1,"number_of_allocations":1,"queue_capacity":1024,"state":"started","allocation_status":{"allocation_count":1,"target_allocation_count":1,"state":"fully_allocated"},"cache_size":"248.7mb","priority":"normal","start_time":1686658871936,"inference_count":120,"peak_throughput_per_minute":54,"nodes":[{"node":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"name":"node-0"}},"routing_state":{"routing_state":"started"},"inference_count":120,"average_inference_time_ms":32.5,"inference_cache_hit_count":30,"error_count":2,"rejected_execution_count":1,"timeout_count":0,"number_of_pending_requests":3,"threads_per_allocation":1,"number_of_allocations":1}]}},{"model_id":"lang_ident_model_1","model_size_stats":{"model_size_bytes":1053992,"required_native_memory_bytes":0},"pipeline_count":0}]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		tm := NewMLTrainedModels(log.NewNopLogger(), http.DefaultClient, u)
+		msr, err := tm.fetchAndDecodeMLTrainedModelStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode ML trained model stats: %s", err)
+		}
+		t.Logf("[%s] ML Trained Model Stats Response: %+v", ver, msr)
+		if len(msr.TrainedModelStats) != 2 {
+			t.Fatalf("Wrong number of trained models")
+		}
+		deployment := msr.TrainedModelStats[0].DeploymentStats
+		if deployment == nil {
+			t.Fatalf("Trained model should be deployed")
+		}
+		if deployment.ID() != "ner" || deployment.State != "started" || deployment.AllocationStatus.State != "fully_allocated" {
+			t.Errorf("Wrong deployment")
+		}
+		if deployment.Sum(func(node mlTrainedModelDeploymentNodeStatsResponse) int64 { return node.InferenceCacheHitCount }) != 30 {
+			t.Errorf("Wrong deployment cache hits")
+		}
+		if msr.TrainedModelStats[1].DeploymentStats != nil {
+			t.Errorf("Trained model lang_ident_model_1 should not be deployed")
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NodeAttrs information struct
+type NodeAttrs struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	attribute *prometheus.Desc
+}
+
+func init() {
+	registerCollector("nodeattrs", defaultDisabled, "Export custom node attributes as info metrics.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewNodeAttrs(logger, client, url)
+	})
+}
+
+// NewNodeAttrs defines Node Attributes Prometheus metrics
+func NewNodeAttrs(logger log.Logger, client *http.Client, url *url.URL) *NodeAttrs {
+	subsystem := "nodeattrs"
+
+	return &NodeAttrs{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch node attributes endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch node attributes scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		attribute: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "node", "attribute"),
+			"Custom attribute of the node, like rack, zone or box_type",
+			[]string{"node", "attr", "value"}, nil,
+		),
+	}
+}
+
+// Describe add Node Attributes metrics descriptions
+func (na *NodeAttrs) Describe(ch chan<- *prometheus.Desc) {
+	ch <- na.attribute
+	ch <- na.up.Desc()
+	ch <- na.totalScrapes.Desc()
+	ch <- na.jsonParseFailures.Desc()
+}
+
+func (na *NodeAttrs) fetchAndDecodeNodeAttrs() (catNodeAttrsResponse, error) {
+	var nar catNodeAttrsResponse
+
+	u := *na.url
+	u.Path = path.Join(u.Path, "/_cat/nodeattrs")
+	u.RawQuery = "format=json"
+
+	res, err := na.client.Get(u.String())
+	if err != nil {
+		return nar, fmt.Errorf("failed to get node attributes from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(na.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nar, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&nar); err != nil {
+		na.jsonParseFailures.Inc()
+		return nar, err
+	}
+	return nar, nil
+}
+
+// Collect gets Node Attributes metric values
+func (na *NodeAttrs) Collect(ch chan<- prometheus.Metric) {
+	na.totalScrapes.Inc()
+	defer func() {
+		ch <- na.up
+		ch <- na.totalScrapes
+		ch <- na.jsonParseFailures
+	}()
+
+	nar, err := na.fetchAndDecodeNodeAttrs()
+	if err != nil {
+		na.up.Set(0)
+		_ = level.Warn(na.logger).Log(
+			"msg", "failed to fetch and decode node attributes",
+			"err", err,
+		)
+		return
+	}
+	na.up.Set(1)
+
+	for _, attr := range nar {
+		ch <- prometheus.MustNewConstMetric(
+			na.attribute,
+			prometheus.GaugeValue,
+			1,
+			attr.Node, attr.Attr, attr.Value,
+		)
+	}
+}
@@ -0,0 +1,13 @@
+package collector
+
+// catNodeAttrsResponse is a representation of the Elasticsearch _cat/nodeattrs endpoint
+type catNodeAttrsResponse []catNodeAttrResponse
+
+// catNodeAttrResponse defines a single attribute of a node
+type catNodeAttrResponse struct {
+	Node  string `json:"node"`
+	Host  string `json:"host"`
+	IP    string `json:"ip"`
+	Attr  string `json:"attr"`
+	Value string `json:"value"`
+}
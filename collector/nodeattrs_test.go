@@ -0,0 +1,56 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestNodeAttrs(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e node.attr.rack=r1 -e node.attr.zone=zone-a elasticsearch:VERSION
+	//  curl http://localhost:9200/_cat/nodeattrs?format=json
+	tcs := map[string]string{
+		"5.4.2":  `[]`,
+		"7.10.0": `[{"node":"es01","host":"172.17.0.2","ip":"172.17.0.2","attr":"ml.machine_memory","value":"8348520448"},{"node":"es01","host":"172.17.0.2","ip":"172.17.0.2","attr":"rack","value":"r1"},{"node":"es01","host":"172.17.0.2","ip":"172.17.0.2","attr":"zone","value":"zone-a"},{"node":"es01","host":"172.17.0.2","ip":"172.17.0.2","attr":"xpack.installed","value":"true"}]`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("format") != "json" {
+				t.Errorf("Node attributes should be queried as JSON")
+			}
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		na := NewNodeAttrs(log.NewNopLogger(), http.DefaultClient, u)
+		nar, err := na.fetchAndDecodeNodeAttrs()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node attributes: %s", err)
+		}
+		t.Logf("[%s] Node Attributes Response: %+v", ver, nar)
+		if ver == "5.4.2" && len(nar) != 0 {
+			t.Errorf("There should be no node attributes")
+		}
+		if ver == "7.10.0" {
+			attrs := map[string]string{}
+			for _, attr := range nar {
+				if attr.Node != "es01" {
+					t.Errorf("Wrong node %s", attr.Node)
+				}
+				attrs[attr.Attr] = attr.Value
+			}
+			if attrs["rack"] != "r1" || attrs["zone"] != "zone-a" {
+				t.Errorf("Wrong node attributes %v", attrs)
+			}
+		}
+	}
+}
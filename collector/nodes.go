@@ -3,9 +3,11 @@ package collector
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
@@ -42,7 +44,7 @@ func getRoles(node NodeStatsNodeResponse) map[string]bool {
 			}
 		}
 	}
-	if len(node.HTTP) == 0 {
+	if node.HTTP == nil {
 		roles["client"] = false
 	}
 	return roles
@@ -77,6 +79,16 @@ var (
 	defaultFilesystemDataLabels     = append(defaultNodeLabels, "mount", "path")
 	defaultFilesystemIODeviceLabels = append(defaultNodeLabels, "device")
 	defaultCacheLabels              = append(defaultNodeLabels, "cache")
+	defaultIngestPipelineLabels     = append(defaultNodeLabels, "pipeline")
+	defaultIngestProcessorLabels    = append(defaultNodeLabels, "type")
+	defaultHTTPAgentLabels          = append(defaultNodeLabels, "agent")
+	defaultBufferPoolLabels         = append(defaultNodeLabels, "type")
+	defaultScriptContextLabels      = append(defaultNodeLabels, "context")
+	defaultAdaptiveSelectionLabels  = append(defaultNodeLabels, "target_node")
+	defaultDataTierLabels           = []string{"cluster", "tier"}
+
+	// dataTierRoles are the node roles of the data tiers
+	dataTierRoles = map[string]bool{"data_content": true, "data_hot": true, "data_warm": true, "data_cold": true, "data_frozen": true}
 
 	defaultNodeLabelValues = func(cluster string, node NodeStatsNodeResponse) []string {
 		roles := getRoles(node)
@@ -99,6 +111,24 @@ var (
 	defaultFilesystemIODeviceLabelValues = func(cluster string, node NodeStatsNodeResponse, device string) []string {
 		return append(defaultNodeLabelValues(cluster, node), device)
 	}
+	defaultAdaptiveSelectionLabelValues = func(cluster string, node NodeStatsNodeResponse, target string) []string {
+		return append(defaultNodeLabelValues(cluster, node), target)
+	}
+	defaultScriptContextLabelValues = func(cluster string, node NodeStatsNodeResponse, context string) []string {
+		return append(defaultNodeLabelValues(cluster, node), context)
+	}
+	defaultBufferPoolLabelValues = func(cluster string, node NodeStatsNodeResponse, pool string) []string {
+		return append(defaultNodeLabelValues(cluster, node), pool)
+	}
+	defaultHTTPAgentLabelValues = func(cluster string, node NodeStatsNodeResponse, agent string) []string {
+		return append(defaultNodeLabelValues(cluster, node), agent)
+	}
+	defaultIngestPipelineLabelValues = func(cluster string, node NodeStatsNodeResponse, pipeline string) []string {
+		return append(defaultNodeLabelValues(cluster, node), pipeline)
+	}
+	defaultIngestProcessorLabelValues = func(cluster string, node NodeStatsNodeResponse, processorType string) []string {
+		return append(defaultNodeLabelValues(cluster, node), processorType)
+	}
 	defaultCacheHitLabelValues = func(cluster string, node NodeStatsNodeResponse) []string {
 		return append(defaultNodeLabelValues(cluster, node), "hit")
 	}
@@ -149,6 +179,86 @@ type filesystemIODeviceMetric struct {
 	Labels func(cluster string, node NodeStatsNodeResponse, device string) []string
 }
 
+type ingestPipelineMetric struct {
+	Type   prometheus.ValueType
+	Desc   *prometheus.Desc
+	Value  func(pipelineStats NodeStatsIngestPipelineResponse) float64
+	Labels func(cluster string, node NodeStatsNodeResponse, pipeline string) []string
+}
+
+type ingestProcessorMetric struct {
+	Type   prometheus.ValueType
+	Desc   *prometheus.Desc
+	Value  func(processorStats NodeStatsIngestStatsResponse) float64
+	Labels func(cluster string, node NodeStatsNodeResponse, processorType string) []string
+}
+
+type httpAgentMetric struct {
+	Type   prometheus.ValueType
+	Desc   *prometheus.Desc
+	Value  func(agentStats NodeStatsHTTPAgentResponse) float64
+	Labels func(cluster string, node NodeStatsNodeResponse, agent string) []string
+}
+
+type bufferPoolMetric struct {
+	Type   prometheus.ValueType
+	Desc   *prometheus.Desc
+	Value  func(poolStats NodeStatsJVMBufferPoolResponse) float64
+	Labels func(cluster string, node NodeStatsNodeResponse, pool string) []string
+}
+
+type scriptContextMetric struct {
+	Type   prometheus.ValueType
+	Desc   *prometheus.Desc
+	Value  func(contextStats NodeStatsScriptContextResponse) float64
+	Labels func(cluster string, node NodeStatsNodeResponse, context string) []string
+}
+
+type adaptiveSelectionMetric struct {
+	Type   prometheus.ValueType
+	Desc   *prometheus.Desc
+	Value  func(selectionStats NodeStatsAdaptiveSelectionResponse) float64
+	Labels func(cluster string, node NodeStatsNodeResponse, target string) []string
+}
+
+type dataTierMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(tier dataTier) float64
+}
+
+// dataTier sums up the capacity of all nodes of a data tier
+type dataTier struct {
+	nodes       int64
+	fsTotal     int64
+	fsFree      int64
+	fsAvailable int64
+	heapUsed    int64
+	heapMax     int64
+}
+
+// dataTiers sums up the filesystem and heap of the nodes by data tier role. Nodes with the generic data
+// role are not assigned to a tier, a node with several tier roles counts towards each of them
+func dataTiers(nodes map[string]NodeStatsNodeResponse) map[string]dataTier {
+	tiers := make(map[string]dataTier)
+	for _, node := range nodes {
+		for _, role := range node.Roles {
+			if !dataTierRoles[role] {
+				continue
+			}
+			tier := tiers[role]
+			tier.nodes++
+			tier.fsTotal += node.FS.Total.Total
+			tier.fsFree += node.FS.Total.Free
+			tier.fsAvailable += node.FS.Total.Available
+			tier.heapUsed += node.JVM.Mem.HeapUsed
+			tier.heapMax += node.JVM.Mem.HeapMax
+			tiers[role] = tier
+		}
+	}
+	return tiers
+}
+
 // Nodes information struct
 type Nodes struct {
 	logger log.Logger
@@ -166,6 +276,30 @@ type Nodes struct {
 	threadPoolMetrics         []*threadPoolMetric
 	filesystemDataMetrics     []*filesystemDataMetric
 	filesystemIODeviceMetrics []*filesystemIODeviceMetric
+	adaptiveSelectionMetrics  []*adaptiveSelectionMetric
+	scriptContextMetrics      []*scriptContextMetric
+	bufferPoolMetrics         []*bufferPoolMetric
+	httpAgentMetrics          []*httpAgentMetric
+	ingestPipelineMetrics     []*ingestPipelineMetric
+	ingestProcessorMetrics    []*ingestProcessorMetric
+	dataTierMetrics           []*dataTierMetric
+}
+
+// cgroupLimit converts a cgroup limit to a float, an unlimited quota or memory limit is +Inf
+func cgroupLimit(value string) float64 {
+	limit, err := strconv.ParseFloat(value, 64)
+	if err != nil || limit < 0 {
+		return math.Inf(1)
+	}
+	return limit
+}
+
+// httpStats returns the HTTP stats of the node, nodes without HTTP enabled report no connections
+func httpStats(node NodeStatsNodeResponse) NodeStatsHTTPResponse {
+	if node.HTTP == nil {
+		return NodeStatsHTTPResponse{}
+	}
+	return *node.HTTP
 }
 
 // NewNodes defines Nodes Prometheus metrics
@@ -311,6 +445,18 @@ func NewNodes(logger log.Logger, client *http.Client, url *url.URL, all bool, no
 				},
 				Labels: defaultNodeLabelValues,
 			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indices", "fielddata_global_ordinals_build_time_seconds"),
+					"Time spent building the currently loaded global ordinals in seconds, rebuilt after refreshes by aggregations on keyword fields",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Indices.FieldData.GlobalOrdinals.BuildTimeInMillis) / 1000
+				},
+				Labels: defaultNodeLabelValues,
+			},
 			{
 				Type: prometheus.CounterValue,
 				Desc: prometheus.NewDesc(
@@ -1082,6 +1228,42 @@ func NewNodes(logger log.Logger, client *http.Client, url *url.URL, all bool, no
 				},
 				Labels: defaultNodeLabelValues,
 			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indices_merges", "current_docs"),
+					"Number of documents in current merges",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Indices.Merges.CurrentDocs)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indices_merges", "total_stopped_time_seconds_total"),
+					"Total time large merges were stopped in seconds, allowing smaller merges to complete",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Indices.Merges.TotalStoppedTime) / 1000
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indices_merges", "auto_throttle_bytes_per_second"),
+					"Current auto-throttle rate of merges in bytes per second",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Indices.Merges.TotalAutoThrottle)
+				},
+				Labels: defaultNodeLabelValues,
+			},
 			{
 				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
@@ -1320,34 +1502,6 @@ func NewNodes(logger log.Logger, client *http.Client, url *url.URL, all bool, no
 					return append(defaultNodeLabelValues(cluster, node), "old")
 				},
 			},
-			{
-				Type: prometheus.GaugeValue,
-				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "jvm_buffer_pool", "used_bytes"),
-					"JVM buffer currently used",
-					append(defaultNodeLabels, "type"), nil,
-				),
-				Value: func(node NodeStatsNodeResponse) float64 {
-					return float64(node.JVM.BufferPools["direct"].Used)
-				},
-				Labels: func(cluster string, node NodeStatsNodeResponse) []string {
-					return append(defaultNodeLabelValues(cluster, node), "direct")
-				},
-			},
-			{
-				Type: prometheus.GaugeValue,
-				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "jvm_buffer_pool", "used_bytes"),
-					"JVM buffer currently used",
-					append(defaultNodeLabels, "type"), nil,
-				),
-				Value: func(node NodeStatsNodeResponse) float64 {
-					return float64(node.JVM.BufferPools["mapped"].Used)
-				},
-				Labels: func(cluster string, node NodeStatsNodeResponse) []string {
-					return append(defaultNodeLabelValues(cluster, node), "mapped")
-				},
-			},
 			{
 				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
@@ -1510,170 +1664,774 @@ func NewNodes(logger log.Logger, client *http.Client, url *url.URL, all bool, no
 				},
 				Labels: defaultNodeLabelValues,
 			},
-		},
-		gcCollectionMetrics: []*gcCollectionMetric{
 			{
-				Type: prometheus.CounterValue,
+				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "jvm_gc", "collection_seconds_count"),
-					"Count of JVM GC runs",
-					append(defaultNodeLabels, "gc"), nil,
+					prometheus.BuildFQName(namespace, "indexing_pressure", "memory_limit_bytes"),
+					"Configured memory limit for indexing requests in bytes",
+					defaultNodeLabels, nil,
 				),
-				Value: func(gcStats NodeStatsJVMGCCollectorResponse) float64 {
-					return float64(gcStats.CollectionCount)
-				},
-				Labels: func(cluster string, node NodeStatsNodeResponse, collector string) []string {
-					return append(defaultNodeLabelValues(cluster, node), collector)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.IndexingPressure.Memory.LimitInBytes)
 				},
+				Labels: defaultNodeLabelValues,
 			},
 			{
-				Type: prometheus.CounterValue,
+				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "jvm_gc", "collection_seconds_sum"),
-					"GC run time in seconds",
-					append(defaultNodeLabels, "gc"), nil,
+					prometheus.BuildFQName(namespace, "indexing_pressure", "current_all_bytes"),
+					"Memory consumed by indexing requests in the coordinating, primary or replica stage in bytes",
+					defaultNodeLabels, nil,
 				),
-				Value: func(gcStats NodeStatsJVMGCCollectorResponse) float64 {
-					return float64(gcStats.CollectionTime) / 1000
-				},
-				Labels: func(cluster string, node NodeStatsNodeResponse, collector string) []string {
-					return append(defaultNodeLabelValues(cluster, node), collector)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.IndexingPressure.Memory.Current.AllInBytes)
 				},
+				Labels: defaultNodeLabelValues,
 			},
-		},
-		breakerMetrics: []*breakerMetric{
 			{
 				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "breakers", "estimated_size_bytes"),
-					"Estimated size in bytes of breaker",
-					defaultBreakerLabels, nil,
+					prometheus.BuildFQName(namespace, "indexing_pressure", "current_combined_coordinating_and_primary_bytes"),
+					"Memory consumed by indexing requests in the coordinating or primary stage in bytes",
+					defaultNodeLabels, nil,
 				),
-				Value: func(breakerStats NodeStatsBreakersResponse) float64 {
-					return float64(breakerStats.EstimatedSize)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.IndexingPressure.Memory.Current.CombinedCoordinatingAndPrimaryInBytes)
 				},
-				Labels: func(cluster string, node NodeStatsNodeResponse, breaker string) []string {
-					return append(defaultNodeLabelValues(cluster, node), breaker)
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indexing_pressure", "current_coordinating_bytes"),
+					"Memory consumed by indexing requests in the coordinating stage in bytes",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.IndexingPressure.Memory.Current.CoordinatingInBytes)
 				},
+				Labels: defaultNodeLabelValues,
 			},
 			{
 				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "breakers", "limit_size_bytes"),
-					"Limit size in bytes for breaker",
-					defaultBreakerLabels, nil,
+					prometheus.BuildFQName(namespace, "indexing_pressure", "current_primary_bytes"),
+					"Memory consumed by indexing requests in the primary stage in bytes",
+					defaultNodeLabels, nil,
 				),
-				Value: func(breakerStats NodeStatsBreakersResponse) float64 {
-					return float64(breakerStats.LimitSize)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.IndexingPressure.Memory.Current.PrimaryInBytes)
 				},
-				Labels: func(cluster string, node NodeStatsNodeResponse, breaker string) []string {
-					return append(defaultNodeLabelValues(cluster, node), breaker)
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "indexing_pressure", "current_replica_bytes"),
+					"Memory consumed by indexing requests in the replica stage in bytes",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.IndexingPressure.Memory.Current.ReplicaInBytes)
 				},
+				Labels: defaultNodeLabelValues,
 			},
 			{
 				Type: prometheus.CounterValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "breakers", "tripped"),
-					"tripped for breaker",
-					defaultBreakerLabels, nil,
+					prometheus.BuildFQName(namespace, "indexing_pressure", "all_bytes_total"),
+					"Total memory consumed by indexing requests in bytes",
+					defaultNodeLabels, nil,
 				),
-				Value: func(breakerStats NodeStatsBreakersResponse) float64 {
-					return float64(breakerStats.Tripped)
-				},
-				Labels: func(cluster string, node NodeStatsNodeResponse, breaker string) []string {
-					return append(defaultNodeLabelValues(cluster, node), breaker)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.IndexingPressure.Memory.Total.AllInBytes)
 				},
+				Labels: defaultNodeLabelValues,
 			},
 			{
 				Type: prometheus.CounterValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "breakers", "overhead"),
-					"Overhead of circuit breakers",
-					defaultBreakerLabels, nil,
+					prometheus.BuildFQName(namespace, "indexing_pressure", "coordinating_rejections_total"),
+					"Total number of indexing requests rejected in the coordinating stage",
+					defaultNodeLabels, nil,
 				),
-				Value: func(breakerStats NodeStatsBreakersResponse) float64 {
-					return breakerStats.Overhead
-				},
-				Labels: func(cluster string, node NodeStatsNodeResponse, breaker string) []string {
-					return append(defaultNodeLabelValues(cluster, node), breaker)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.IndexingPressure.Memory.Total.CoordinatingRejections)
 				},
+				Labels: defaultNodeLabelValues,
 			},
-		},
-		threadPoolMetrics: []*threadPoolMetric{
 			{
 				Type: prometheus.CounterValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "thread_pool", "completed_count"),
-					"Thread Pool operations completed",
-					defaultThreadPoolLabels, nil,
+					prometheus.BuildFQName(namespace, "indexing_pressure", "primary_rejections_total"),
+					"Total number of indexing requests rejected in the primary stage",
+					defaultNodeLabels, nil,
 				),
-				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
-					return float64(threadPoolStats.Completed)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.IndexingPressure.Memory.Total.PrimaryRejections)
 				},
-				Labels: defaultThreadPoolLabelValues,
+				Labels: defaultNodeLabelValues,
 			},
 			{
 				Type: prometheus.CounterValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "thread_pool", "rejected_count"),
-					"Thread Pool operations rejected",
-					defaultThreadPoolLabels, nil,
+					prometheus.BuildFQName(namespace, "indexing_pressure", "replica_rejections_total"),
+					"Total number of indexing requests rejected in the replica stage",
+					defaultNodeLabels, nil,
 				),
-				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
-					return float64(threadPoolStats.Rejected)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.IndexingPressure.Memory.Total.ReplicaRejections)
 				},
-				Labels: defaultThreadPoolLabelValues,
+				Labels: defaultNodeLabelValues,
 			},
 			{
 				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "thread_pool", "active_count"),
-					"Thread Pool threads active",
-					defaultThreadPoolLabels, nil,
+					prometheus.BuildFQName(namespace, "indices", "dense_vector_values"),
+					"Count of indexed dense vector values",
+					defaultNodeLabels, nil,
 				),
-				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
-					return float64(threadPoolStats.Active)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Indices.DenseVector.ValueCount)
 				},
-				Labels: defaultThreadPoolLabelValues,
+				Labels: defaultNodeLabelValues,
 			},
 			{
 				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "thread_pool", "largest_count"),
-					"Thread Pool largest threads count",
-					defaultThreadPoolLabels, nil,
+					prometheus.BuildFQName(namespace, "indices", "dense_vector_off_heap_size_bytes"),
+					"Off-heap size of the dense vector data structures in bytes",
+					defaultNodeLabels, nil,
 				),
-				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
-					return float64(threadPoolStats.Largest)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Indices.DenseVector.OffHeap.TotalSizeBytes)
 				},
-				Labels: defaultThreadPoolLabelValues,
+				Labels: defaultNodeLabelValues,
 			},
 			{
 				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "thread_pool", "queue_count"),
-					"Thread Pool operations queued",
-					defaultThreadPoolLabels, nil,
+					prometheus.BuildFQName(namespace, "indices", "dense_vector_hnsw_graph_size_bytes"),
+					"Off-heap size of the HNSW graphs of dense vector fields in bytes",
+					defaultNodeLabels, nil,
 				),
-				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
-					return float64(threadPoolStats.Queue)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Indices.DenseVector.OffHeap.TotalVexSizeBytes)
 				},
-				Labels: defaultThreadPoolLabelValues,
+				Labels: defaultNodeLabelValues,
 			},
 			{
-				Type: prometheus.GaugeValue,
+				Type: prometheus.CounterValue,
 				Desc: prometheus.NewDesc(
-					prometheus.BuildFQName(namespace, "thread_pool", "threads_count"),
-					"Thread Pool current threads count",
-					defaultThreadPoolLabels, nil,
+					prometheus.BuildFQName(namespace, "script", "compilations_total"),
+					"Number of inline script compilations",
+					defaultNodeLabels, nil,
 				),
-				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
-					return float64(threadPoolStats.Threads)
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Script.Compilations)
 				},
-				Labels: defaultThreadPoolLabelValues,
+				Labels: defaultNodeLabelValues,
 			},
-		},
-		filesystemDataMetrics: []*filesystemDataMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "script", "cache_evictions_total"),
+					"Number of times the script cache evicted a compiled script",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Script.CacheEvictions)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "script", "compilation_limit_triggered_total"),
+					"Number of script compilations rejected by the script compilation rate limit",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Script.CompilationLimitTriggered)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "cluster_state_queue_pending"),
+					"Number of cluster states received by the node which are pending commit",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.ClusterStateQueue.Pending)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "cluster_state_queue_committed"),
+					"Number of committed cluster states received by the node which are not applied yet",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.ClusterStateQueue.Committed)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "published_full_states_total"),
+					"Number of full cluster states published by the elected master",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.PublishedClusterStates.FullStates)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "published_compatible_diffs_total"),
+					"Number of cluster state diffs published by the elected master",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.PublishedClusterStates.CompatibleDiffs)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "published_incompatible_diffs_total"),
+					"Number of cluster state diffs published by the elected master which a node could not apply",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.PublishedClusterStates.IncompatibleDiffs)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "cluster_state_updates_unchanged_total"),
+					"Number of cluster state updates computed by the elected master which did not change the cluster state",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.ClusterStateUpdate.Unchanged.Count)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "cluster_state_updates_success_total"),
+					"Number of cluster state updates published successfully by the elected master",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.ClusterStateUpdate.Success.Count)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "cluster_state_updates_failure_total"),
+					"Number of cluster state updates the elected master failed to publish",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.ClusterStateUpdate.Failure.Count)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "cluster_state_update_computation_seconds_total"),
+					"Time spent by the elected master computing successful cluster state updates in seconds",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.ClusterStateUpdate.Success.ComputationTimeMillis) / 1000
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "cluster_state_update_publication_seconds_total"),
+					"Time spent by the elected master publishing successful cluster state updates in seconds",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.ClusterStateUpdate.Success.PublicationTimeMillis) / 1000
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "cluster_state_update_commit_seconds_total"),
+					"Time spent by the elected master waiting for successful cluster state updates to be committed in seconds",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.ClusterStateUpdate.Success.CommitTimeMillis) / 1000
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "cluster_state_update_master_apply_seconds_total"),
+					"Time spent by the elected master applying successful cluster state updates in seconds",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.ClusterStateUpdate.Success.MasterApplyTimeMillis) / 1000
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "serialized_full_states_total"),
+					"Number of full cluster states serialized by the elected master",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.SerializedClusterStates.FullStates.Count)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "serialized_full_states_compressed_size_bytes_total"),
+					"Compressed size of the full cluster states serialized by the elected master in bytes",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.SerializedClusterStates.FullStates.CompressedSizeInBytes)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "serialized_full_states_uncompressed_size_bytes_total"),
+					"Uncompressed size of the full cluster states serialized by the elected master in bytes",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.SerializedClusterStates.FullStates.UncompressedSizeInBytes)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "serialized_diffs_total"),
+					"Number of cluster state diffs serialized by the elected master",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.SerializedClusterStates.Diffs.Count)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "serialized_diffs_compressed_size_bytes_total"),
+					"Compressed size of the cluster state diffs serialized by the elected master in bytes",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.SerializedClusterStates.Diffs.CompressedSizeInBytes)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "discovery", "serialized_diffs_uncompressed_size_bytes_total"),
+					"Uncompressed size of the cluster state diffs serialized by the elected master in bytes",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Discovery.SerializedClusterStates.Diffs.UncompressedSizeInBytes)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "os", "cgroup_cpuacct_usage_seconds_total"),
+					"CPU time consumed by all tasks in the cgroup of the node in seconds",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.OS.Cgroup.CPUAcct.UsageNanos) / 1e9
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "os", "cgroup_cpu_cfs_period_seconds"),
+					"Period of the CFS CPU quota of the cgroup in seconds",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.OS.Cgroup.CPU.CFSPeriodMicros) / 1e6
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "os", "cgroup_cpu_cfs_quota_seconds"),
+					"CPU time the cgroup may consume per CFS period in seconds, +Inf if no quota is set",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					if quota := node.OS.Cgroup.CPU.CFSQuotaMicros; quota >= 0 {
+						return float64(quota) / 1e6
+					}
+					return math.Inf(1)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "os", "cgroup_cpu_cfs_elapsed_periods_total"),
+					"Number of CFS periods elapsed for the cgroup",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.OS.Cgroup.CPU.Stat.NumberOfElapsedPeriods)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "os", "cgroup_cpu_cfs_throttled_periods_total"),
+					"Number of CFS periods in which the cgroup was throttled",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.OS.Cgroup.CPU.Stat.NumberOfTimesThrottled)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "os", "cgroup_cpu_cfs_throttled_seconds_total"),
+					"Time the cgroup was throttled in seconds",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.OS.Cgroup.CPU.Stat.TimeThrottledNanos) / 1e9
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "os", "cgroup_memory_usage_bytes"),
+					"Memory used by the cgroup of the node in bytes",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					usage, _ := strconv.ParseFloat(node.OS.Cgroup.Memory.UsageInBytes, 64)
+					return usage
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "os", "cgroup_memory_limit_bytes"),
+					"Memory limit of the cgroup of the node in bytes, +Inf if no limit is set",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return cgroupLimit(node.OS.Cgroup.Memory.LimitInBytes)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "transport", "server_open"),
+					"Current number of inbound transport connections from other nodes",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Transport.ServerOpen)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "transport", "outbound_connections_total"),
+					"Total number of outbound transport connections opened to other nodes",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Transport.TotalOutboundConnections)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "http", "current_open"),
+					"Current number of open HTTP connections",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(httpStats(node).CurrentOpen)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "http", "opened_total"),
+					"Total number of HTTP connections opened",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(httpStats(node).TotalOpen)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest", "documents_total"),
+					"Total number of documents ingested by pipelines on the node",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Ingest.Total.Count)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest", "time_seconds_total"),
+					"Total time spent ingesting documents by pipelines on the node in seconds",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Ingest.Total.TimeInMillis) / 1000
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest", "documents_current"),
+					"Number of documents currently ingested by pipelines on the node",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Ingest.Total.Current)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest", "failed_total"),
+					"Total number of failed ingest operations on the node",
+					defaultNodeLabels, nil,
+				),
+				Value: func(node NodeStatsNodeResponse) float64 {
+					return float64(node.Ingest.Total.Failed)
+				},
+				Labels: defaultNodeLabelValues,
+			},
+		},
+		gcCollectionMetrics: []*gcCollectionMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "jvm_gc", "collection_seconds_count"),
+					"Count of JVM GC runs",
+					append(defaultNodeLabels, "gc"), nil,
+				),
+				Value: func(gcStats NodeStatsJVMGCCollectorResponse) float64 {
+					return float64(gcStats.CollectionCount)
+				},
+				Labels: func(cluster string, node NodeStatsNodeResponse, collector string) []string {
+					return append(defaultNodeLabelValues(cluster, node), collector)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "jvm_gc", "collection_seconds_sum"),
+					"GC run time in seconds",
+					append(defaultNodeLabels, "gc"), nil,
+				),
+				Value: func(gcStats NodeStatsJVMGCCollectorResponse) float64 {
+					return float64(gcStats.CollectionTime) / 1000
+				},
+				Labels: func(cluster string, node NodeStatsNodeResponse, collector string) []string {
+					return append(defaultNodeLabelValues(cluster, node), collector)
+				},
+			},
+		},
+		breakerMetrics: []*breakerMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "breakers", "estimated_size_bytes"),
+					"Estimated size in bytes of breaker",
+					defaultBreakerLabels, nil,
+				),
+				Value: func(breakerStats NodeStatsBreakersResponse) float64 {
+					return float64(breakerStats.EstimatedSize)
+				},
+				Labels: func(cluster string, node NodeStatsNodeResponse, breaker string) []string {
+					return append(defaultNodeLabelValues(cluster, node), breaker)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "breakers", "limit_size_bytes"),
+					"Limit size in bytes for breaker",
+					defaultBreakerLabels, nil,
+				),
+				Value: func(breakerStats NodeStatsBreakersResponse) float64 {
+					return float64(breakerStats.LimitSize)
+				},
+				Labels: func(cluster string, node NodeStatsNodeResponse, breaker string) []string {
+					return append(defaultNodeLabelValues(cluster, node), breaker)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "breakers", "tripped"),
+					"tripped for breaker",
+					defaultBreakerLabels, nil,
+				),
+				Value: func(breakerStats NodeStatsBreakersResponse) float64 {
+					return float64(breakerStats.Tripped)
+				},
+				Labels: func(cluster string, node NodeStatsNodeResponse, breaker string) []string {
+					return append(defaultNodeLabelValues(cluster, node), breaker)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "breakers", "overhead"),
+					"Overhead of circuit breakers",
+					defaultBreakerLabels, nil,
+				),
+				Value: func(breakerStats NodeStatsBreakersResponse) float64 {
+					return breakerStats.Overhead
+				},
+				Labels: func(cluster string, node NodeStatsNodeResponse, breaker string) []string {
+					return append(defaultNodeLabelValues(cluster, node), breaker)
+				},
+			},
+		},
+		threadPoolMetrics: []*threadPoolMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "thread_pool", "completed_count"),
+					"Thread Pool operations completed",
+					defaultThreadPoolLabels, nil,
+				),
+				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
+					return float64(threadPoolStats.Completed)
+				},
+				Labels: defaultThreadPoolLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "thread_pool", "rejected_count"),
+					"Thread Pool operations rejected",
+					defaultThreadPoolLabels, nil,
+				),
+				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
+					return float64(threadPoolStats.Rejected)
+				},
+				Labels: defaultThreadPoolLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "thread_pool", "active_count"),
+					"Thread Pool threads active",
+					defaultThreadPoolLabels, nil,
+				),
+				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
+					return float64(threadPoolStats.Active)
+				},
+				Labels: defaultThreadPoolLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "thread_pool", "largest_count"),
+					"Thread Pool largest threads count",
+					defaultThreadPoolLabels, nil,
+				),
+				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
+					return float64(threadPoolStats.Largest)
+				},
+				Labels: defaultThreadPoolLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "thread_pool", "queue_count"),
+					"Thread Pool operations queued",
+					defaultThreadPoolLabels, nil,
+				),
+				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
+					return float64(threadPoolStats.Queue)
+				},
+				Labels: defaultThreadPoolLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "thread_pool", "threads_count"),
+					"Thread Pool current threads count",
+					defaultThreadPoolLabels, nil,
+				),
+				Value: func(threadPoolStats NodeStatsThreadPoolPoolResponse) float64 {
+					return float64(threadPoolStats.Threads)
+				},
+				Labels: defaultThreadPoolLabelValues,
+			},
+		},
+		filesystemDataMetrics: []*filesystemDataMetric{
 			{
 				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
@@ -1772,6 +2530,362 @@ func NewNodes(logger log.Logger, client *http.Client, url *url.URL, all bool, no
 				},
 				Labels: defaultFilesystemIODeviceLabelValues,
 			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "filesystem_io_stats_device", "io_time_seconds_sum"),
+					"Total time the disk spent doing I/O in seconds",
+					defaultFilesystemIODeviceLabels, nil,
+				),
+				Value: func(fsIODeviceStats NodeStatsFSIOStatsDeviceResponse) float64 {
+					return float64(fsIODeviceStats.IOTimeInMillis) / 1000
+				},
+				Labels: defaultFilesystemIODeviceLabelValues,
+			},
+		},
+		adaptiveSelectionMetrics: []*adaptiveSelectionMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "adaptive_selection", "outgoing_searches"),
+					"Number of outstanding search requests from the node to the target node",
+					defaultAdaptiveSelectionLabels, nil,
+				),
+				Value: func(selectionStats NodeStatsAdaptiveSelectionResponse) float64 {
+					return float64(selectionStats.OutgoingSearches)
+				},
+				Labels: defaultAdaptiveSelectionLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "adaptive_selection", "avg_queue_size"),
+					"Exponentially weighted moving average queue size of search requests on the target node",
+					defaultAdaptiveSelectionLabels, nil,
+				),
+				Value: func(selectionStats NodeStatsAdaptiveSelectionResponse) float64 {
+					return float64(selectionStats.AvgQueueSize)
+				},
+				Labels: defaultAdaptiveSelectionLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "adaptive_selection", "avg_service_time_seconds"),
+					"Exponentially weighted moving average service time of search requests on the target node in seconds",
+					defaultAdaptiveSelectionLabels, nil,
+				),
+				Value: func(selectionStats NodeStatsAdaptiveSelectionResponse) float64 {
+					return float64(selectionStats.AvgServiceTimeNs) / 1e9
+				},
+				Labels: defaultAdaptiveSelectionLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "adaptive_selection", "avg_response_time_seconds"),
+					"Exponentially weighted moving average response time of search requests on the target node in seconds",
+					defaultAdaptiveSelectionLabels, nil,
+				),
+				Value: func(selectionStats NodeStatsAdaptiveSelectionResponse) float64 {
+					return float64(selectionStats.AvgResponseTimeNs) / 1e9
+				},
+				Labels: defaultAdaptiveSelectionLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "adaptive_selection", "rank"),
+					"Rank of the target node used for adaptive replica selection, lower is preferred",
+					defaultAdaptiveSelectionLabels, nil,
+				),
+				Value: func(selectionStats NodeStatsAdaptiveSelectionResponse) float64 {
+					return selectionStats.Rank
+				},
+				Labels: defaultAdaptiveSelectionLabelValues,
+			},
+		},
+		scriptContextMetrics: []*scriptContextMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "script", "context_compilations_total"),
+					"Number of inline script compilations for the script context",
+					defaultScriptContextLabels, nil,
+				),
+				Value: func(contextStats NodeStatsScriptContextResponse) float64 {
+					return float64(contextStats.Compilations)
+				},
+				Labels: defaultScriptContextLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "script", "context_cache_evictions_total"),
+					"Number of times the script cache evicted a compiled script of the script context",
+					defaultScriptContextLabels, nil,
+				),
+				Value: func(contextStats NodeStatsScriptContextResponse) float64 {
+					return float64(contextStats.CacheEvictions)
+				},
+				Labels: defaultScriptContextLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "script", "context_compilation_limit_triggered_total"),
+					"Number of script compilations for the script context rejected by the script compilation rate limit",
+					defaultScriptContextLabels, nil,
+				),
+				Value: func(contextStats NodeStatsScriptContextResponse) float64 {
+					return float64(contextStats.CompilationLimitTriggered)
+				},
+				Labels: defaultScriptContextLabelValues,
+			},
+		},
+		bufferPoolMetrics: []*bufferPoolMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "jvm_buffer_pool", "used_bytes"),
+					"JVM buffer currently used",
+					defaultBufferPoolLabels, nil,
+				),
+				Value: func(poolStats NodeStatsJVMBufferPoolResponse) float64 {
+					return float64(poolStats.Used)
+				},
+				Labels: defaultBufferPoolLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "jvm_buffer_pool", "total_capacity_bytes"),
+					"Total capacity of the buffers in the JVM buffer pool",
+					defaultBufferPoolLabels, nil,
+				),
+				Value: func(poolStats NodeStatsJVMBufferPoolResponse) float64 {
+					return float64(poolStats.TotalCapacity)
+				},
+				Labels: defaultBufferPoolLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "jvm_buffer_pool", "count"),
+					"Number of buffers in the JVM buffer pool",
+					defaultBufferPoolLabels, nil,
+				),
+				Value: func(poolStats NodeStatsJVMBufferPoolResponse) float64 {
+					return float64(poolStats.Count)
+				},
+				Labels: defaultBufferPoolLabelValues,
+			},
+		},
+		httpAgentMetrics: []*httpAgentMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "http", "clients_open"),
+					"Number of open HTTP client connections tracked by the node",
+					defaultHTTPAgentLabels, nil,
+				),
+				Value: func(agentStats NodeStatsHTTPAgentResponse) float64 {
+					return float64(agentStats.Open)
+				},
+				Labels: defaultHTTPAgentLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "http", "clients_closed"),
+					"Number of recently closed HTTP client connections tracked by the node",
+					defaultHTTPAgentLabels, nil,
+				),
+				Value: func(agentStats NodeStatsHTTPAgentResponse) float64 {
+					return float64(agentStats.Closed)
+				},
+				Labels: defaultHTTPAgentLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "http", "client_requests"),
+					"Number of requests sent by the tracked HTTP client connections",
+					defaultHTTPAgentLabels, nil,
+				),
+				Value: func(agentStats NodeStatsHTTPAgentResponse) float64 {
+					return float64(agentStats.RequestCount)
+				},
+				Labels: defaultHTTPAgentLabelValues,
+			},
+		},
+		ingestPipelineMetrics: []*ingestPipelineMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest_pipeline", "documents_total"),
+					"Total number of documents ingested by the pipeline on the node",
+					defaultIngestPipelineLabels, nil,
+				),
+				Value: func(pipelineStats NodeStatsIngestPipelineResponse) float64 {
+					return float64(pipelineStats.Count)
+				},
+				Labels: defaultIngestPipelineLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest_pipeline", "time_seconds_total"),
+					"Total time spent ingesting documents by the pipeline on the node in seconds",
+					defaultIngestPipelineLabels, nil,
+				),
+				Value: func(pipelineStats NodeStatsIngestPipelineResponse) float64 {
+					return float64(pipelineStats.TimeInMillis) / 1000
+				},
+				Labels: defaultIngestPipelineLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest_pipeline", "documents_current"),
+					"Number of documents currently ingested by the pipeline on the node",
+					defaultIngestPipelineLabels, nil,
+				),
+				Value: func(pipelineStats NodeStatsIngestPipelineResponse) float64 {
+					return float64(pipelineStats.Current)
+				},
+				Labels: defaultIngestPipelineLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest_pipeline", "failed_total"),
+					"Total number of failed ingest operations of the pipeline on the node",
+					defaultIngestPipelineLabels, nil,
+				),
+				Value: func(pipelineStats NodeStatsIngestPipelineResponse) float64 {
+					return float64(pipelineStats.Failed)
+				},
+				Labels: defaultIngestPipelineLabelValues,
+			},
+		},
+		ingestProcessorMetrics: []*ingestProcessorMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest_processor", "documents_total"),
+					"Total number of documents processed by processors of the type in all pipelines on the node",
+					defaultIngestProcessorLabels, nil,
+				),
+				Value: func(processorStats NodeStatsIngestStatsResponse) float64 {
+					return float64(processorStats.Count)
+				},
+				Labels: defaultIngestProcessorLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest_processor", "time_seconds_total"),
+					"Total time spent in processors of the type in all pipelines on the node in seconds",
+					defaultIngestProcessorLabels, nil,
+				),
+				Value: func(processorStats NodeStatsIngestStatsResponse) float64 {
+					return float64(processorStats.TimeInMillis) / 1000
+				},
+				Labels: defaultIngestProcessorLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest_processor", "documents_current"),
+					"Number of documents currently processed by processors of the type on the node",
+					defaultIngestProcessorLabels, nil,
+				),
+				Value: func(processorStats NodeStatsIngestStatsResponse) float64 {
+					return float64(processorStats.Current)
+				},
+				Labels: defaultIngestProcessorLabelValues,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "ingest_processor", "failed_total"),
+					"Total number of failed operations of processors of the type in all pipelines on the node",
+					defaultIngestProcessorLabels, nil,
+				),
+				Value: func(processorStats NodeStatsIngestStatsResponse) float64 {
+					return float64(processorStats.Failed)
+				},
+				Labels: defaultIngestProcessorLabelValues,
+			},
+		},
+		dataTierMetrics: []*dataTierMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "data_tier", "nodes"),
+					"Number of nodes with the data tier role",
+					defaultDataTierLabels, nil,
+				),
+				Value: func(tier dataTier) float64 {
+					return float64(tier.nodes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "data_tier", "filesystem_size_bytes"),
+					"Total size of the filesystems of the nodes of the data tier in bytes",
+					defaultDataTierLabels, nil,
+				),
+				Value: func(tier dataTier) float64 {
+					return float64(tier.fsTotal)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "data_tier", "filesystem_free_bytes"),
+					"Free space on the filesystems of the nodes of the data tier in bytes",
+					defaultDataTierLabels, nil,
+				),
+				Value: func(tier dataTier) float64 {
+					return float64(tier.fsFree)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "data_tier", "filesystem_available_bytes"),
+					"Space available to Elasticsearch on the filesystems of the nodes of the data tier in bytes",
+					defaultDataTierLabels, nil,
+				),
+				Value: func(tier dataTier) float64 {
+					return float64(tier.fsAvailable)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "data_tier", "jvm_memory_heap_used_bytes"),
+					"JVM heap used by the nodes of the data tier in bytes",
+					defaultDataTierLabels, nil,
+				),
+				Value: func(tier dataTier) float64 {
+					return float64(tier.heapUsed)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "data_tier", "jvm_memory_heap_max_bytes"),
+					"Maximum JVM heap of the nodes of the data tier in bytes",
+					defaultDataTierLabels, nil,
+				),
+				Value: func(tier dataTier) float64 {
+					return float64(tier.heapMax)
+				},
+			},
 		},
 	}
 }
@@ -1784,6 +2898,9 @@ func (c *Nodes) Describe(ch chan<- *prometheus.Desc) {
 	for _, metric := range c.gcCollectionMetrics {
 		ch <- metric.Desc
 	}
+	for _, metric := range c.breakerMetrics {
+		ch <- metric.Desc
+	}
 	for _, metric := range c.threadPoolMetrics {
 		ch <- metric.Desc
 	}
@@ -1793,6 +2910,27 @@ func (c *Nodes) Describe(ch chan<- *prometheus.Desc) {
 	for _, metric := range c.filesystemIODeviceMetrics {
 		ch <- metric.Desc
 	}
+	for _, metric := range c.adaptiveSelectionMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range c.scriptContextMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range c.bufferPoolMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range c.httpAgentMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range c.ingestPipelineMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range c.ingestProcessorMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range c.dataTierMetrics {
+		ch <- metric.Desc
+	}
 	ch <- c.up.Desc()
 	ch <- c.totalScrapes.Desc()
 	ch <- c.jsonParseFailures.Desc()
@@ -1941,5 +3079,91 @@ func (c *Nodes) Collect(ch chan<- prometheus.Metric) {
 			}
 		}
 
+		// Adaptive Selection Stats
+		for target, selectionStats := range node.AdaptiveSelection {
+			for _, metric := range c.adaptiveSelectionMetrics {
+				ch <- prometheus.MustNewConstMetric(
+					metric.Desc,
+					metric.Type,
+					metric.Value(selectionStats),
+					metric.Labels(nodeStatsResp.ClusterName, node, target)...,
+				)
+			}
+		}
+
+		// Script compilation stats per script context
+		for _, contextStats := range node.Script.Contexts {
+			for _, metric := range c.scriptContextMetrics {
+				ch <- prometheus.MustNewConstMetric(
+					metric.Desc,
+					metric.Type,
+					metric.Value(contextStats),
+					metric.Labels(nodeStatsResp.ClusterName, node, contextStats.Context)...,
+				)
+			}
+		}
+
+		// JVM buffer pool stats
+		for pool, poolStats := range node.JVM.BufferPools {
+			for _, metric := range c.bufferPoolMetrics {
+				ch <- prometheus.MustNewConstMetric(
+					metric.Desc,
+					metric.Type,
+					metric.Value(poolStats),
+					metric.Labels(nodeStatsResp.ClusterName, node, pool)...,
+				)
+			}
+		}
+
+		// HTTP clients tracked by the node by user agent
+		for agent, agentStats := range node.HTTP.ClientsByAgent() {
+			for _, metric := range c.httpAgentMetrics {
+				ch <- prometheus.MustNewConstMetric(
+					metric.Desc,
+					metric.Type,
+					metric.Value(agentStats),
+					metric.Labels(nodeStatsResp.ClusterName, node, agent)...,
+				)
+			}
+		}
+
+		// Ingest pipeline stats
+		for pipeline, pipelineStats := range node.Ingest.Pipelines {
+			for _, metric := range c.ingestPipelineMetrics {
+				ch <- prometheus.MustNewConstMetric(
+					metric.Desc,
+					metric.Type,
+					metric.Value(pipelineStats),
+					metric.Labels(nodeStatsResp.ClusterName, node, pipeline)...,
+				)
+			}
+		}
+
+		// Ingest processor stats of all pipelines by processor type
+		for processorType, processorStats := range node.Ingest.ProcessorsByType() {
+			for _, metric := range c.ingestProcessorMetrics {
+				ch <- prometheus.MustNewConstMetric(
+					metric.Desc,
+					metric.Type,
+					metric.Value(processorStats),
+					metric.Labels(nodeStatsResp.ClusterName, node, processorType)...,
+				)
+			}
+		}
+	}
+
+	// Capacity per data tier, only complete when the stats of all nodes are queried
+	if !c.all {
+		return
+	}
+	for role, tier := range dataTiers(nodeStatsResp.Nodes) {
+		for _, metric := range c.dataTierMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(tier),
+				nodeStatsResp.ClusterName, role,
+			)
+		}
 	}
 }
@@ -0,0 +1,131 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NodesInfo information struct
+type NodesInfo struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	info *prometheus.Desc
+}
+
+func init() {
+	registerCollector("nodes_info", defaultDisabled, "Export version and build information of all nodes.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewNodesInfo(logger, client, url)
+	})
+}
+
+// NewNodesInfo defines Nodes Info Prometheus metrics
+func NewNodesInfo(logger log.Logger, client *http.Client, url *url.URL) *NodesInfo {
+	subsystem := "nodes_info"
+
+	return &NodesInfo{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch nodes info endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch nodes info scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "node", "info"),
+			"Version and build information of the node",
+			[]string{"cluster", "node", "version", "build_flavor", "build_type", "jvm_version", "os"}, nil,
+		),
+	}
+}
+
+// Describe add Nodes Info metrics descriptions
+func (ni *NodesInfo) Describe(ch chan<- *prometheus.Desc) {
+	ch <- ni.info
+	ch <- ni.up.Desc()
+	ch <- ni.totalScrapes.Desc()
+	ch <- ni.jsonParseFailures.Desc()
+}
+
+func (ni *NodesInfo) fetchAndDecodeNodesInfo() (nodesInfoResponse, error) {
+	var nir nodesInfoResponse
+
+	u := *ni.url
+	u.Path = path.Join(u.Path, "/_nodes/jvm,os")
+
+	res, err := ni.client.Get(u.String())
+	if err != nil {
+		return nir, fmt.Errorf("failed to get nodes info from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(ni.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nir, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&nir); err != nil {
+		ni.jsonParseFailures.Inc()
+		return nir, err
+	}
+	return nir, nil
+}
+
+// Collect gets Nodes Info metric values
+func (ni *NodesInfo) Collect(ch chan<- prometheus.Metric) {
+	ni.totalScrapes.Inc()
+	defer func() {
+		ch <- ni.up
+		ch <- ni.totalScrapes
+		ch <- ni.jsonParseFailures
+	}()
+
+	nir, err := ni.fetchAndDecodeNodesInfo()
+	if err != nil {
+		ni.up.Set(0)
+		_ = level.Warn(ni.logger).Log(
+			"msg", "failed to fetch and decode nodes info",
+			"err", err,
+		)
+		return
+	}
+	ni.up.Set(1)
+
+	for _, node := range nir.Nodes {
+		ch <- prometheus.MustNewConstMetric(
+			ni.info,
+			prometheus.GaugeValue,
+			1,
+			nir.ClusterName, node.Name, node.Version, node.BuildFlavor, node.BuildType, node.JVM.Version, node.OS.String(),
+		)
+	}
+}
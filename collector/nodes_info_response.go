@@ -0,0 +1,40 @@
+package collector
+
+// nodesInfoResponse is a representation of the Elasticsearch _nodes endpoint
+type nodesInfoResponse struct {
+	ClusterName string                           `json:"cluster_name"`
+	Nodes       map[string]nodesInfoNodeResponse `json:"nodes"`
+}
+
+// nodesInfoNodeResponse defines the version and build information of a single node
+type nodesInfoNodeResponse struct {
+	Name        string               `json:"name"`
+	Version     string               `json:"version"`
+	BuildFlavor string               `json:"build_flavor"`
+	BuildType   string               `json:"build_type"`
+	BuildHash   string               `json:"build_hash"`
+	Roles       []string             `json:"roles"`
+	JVM         nodesInfoJVMResponse `json:"jvm"`
+	OS          nodesInfoOSResponse  `json:"os"`
+}
+
+// nodesInfoJVMResponse defines the JVM a node is running on
+type nodesInfoJVMResponse struct {
+	Version string `json:"version"`
+	VMName  string `json:"vm_name"`
+}
+
+// nodesInfoOSResponse defines the operating system a node is running on
+type nodesInfoOSResponse struct {
+	Name       string `json:"name"`
+	PrettyName string `json:"pretty_name"`
+	Version    string `json:"version"`
+}
+
+// String returns the pretty name of the operating system, which is only reported since 6.5
+func (o nodesInfoOSResponse) String() string {
+	if o.PrettyName != "" {
+		return o.PrettyName
+	}
+	return o.Name
+}
@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestNodesInfo(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/jvm,os
+	tcs := map[string]string{
+		"5.4.2":  `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"7CRtZyB2Q2yy4hn8r5Rmsg":{"name":"7CRtZyB","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2","version":"5.4.2","build_hash":"929b078","roles":["master","data","ingest"],"os":{"refresh_interval_in_millis":1000,"name":"Linux","arch":"amd64","version":"4.9.27-moby","available_processors":2,"allocated_processors":2},"jvm":{"pid":1,"version":"1.8.0_131","vm_name":"OpenJDK 64-Bit Server VM","vm_version":"25.131-b11","vm_vendor":"Oracle Corporation","start_time_in_millis":1498048597965}}}}`,
+		"7.10.0": `{"_nodes":{"total":2,"successful":2,"failed":0},"cluster_name":"docker-cluster","nodes":{"tMTocMvQQgGCkj7QDHl3OA":{"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2","version":"7.10.0","build_flavor":"default","build_type":"docker","build_hash":"51e9d6f22758d0374a0f3f5c6e8f3a7997850f96","roles":["data","ingest","master"],"os":{"refresh_interval_in_millis":1000,"name":"Linux","pretty_name":"CentOS Linux 8 (Core)","arch":"amd64","version":"5.4.39-linuxkit","available_processors":4,"allocated_processors":4},"jvm":{"pid":7,"version":"15.0.1","vm_name":"OpenJDK 64-Bit Server VM","vm_version":"15.0.1+9","vm_vendor":"AdoptOpenJDK","bundled_jdk":true,"using_bundled_jdk":true,"start_time_in_millis":1605733135110}},"9_P7yui-SN2DLgaw3YHCqA":{"name":"es02","transport_address":"172.17.0.3:9300","host":"172.17.0.3","ip":"172.17.0.3","version":"7.9.3","build_flavor":"default","build_type":"docker","build_hash":"c4138e51121ef06a6404866cddc601906fe5c868","roles":["data","ingest","master"],"os":{"refresh_interval_in_millis":1000,"name":"Linux","pretty_name":"CentOS Linux 7 (Core)","arch":"amd64","version":"5.4.39-linuxkit","available_processors":4,"allocated_processors":4},"jvm":{"pid":7,"version":"15","vm_name":"OpenJDK 64-Bit Server VM","vm_version":"15+36","vm_vendor":"AdoptOpenJDK","bundled_jdk":true,"using_bundled_jdk":true,"start_time_in_millis":1605733135221}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		ni := NewNodesInfo(log.NewNopLogger(), http.DefaultClient, u)
+		nir, err := ni.fetchAndDecodeNodesInfo()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode nodes info: %s", err)
+		}
+		t.Logf("[%s] Nodes Info Response: %+v", ver, nir)
+		switch ver {
+		case "5.4.2":
+			node := nir.Nodes["7CRtZyB2Q2yy4hn8r5Rmsg"]
+			if node.Version != "5.4.2" || node.BuildFlavor != "" || node.JVM.Version != "1.8.0_131" {
+				t.Errorf("Wrong node version information")
+			}
+			if node.OS.String() != "Linux" {
+				t.Errorf("Wrong node operating system %s", node.OS.String())
+			}
+		case "7.10.0":
+			versions := map[string]int{}
+			for _, node := range nir.Nodes {
+				versions[node.Version]++
+			}
+			if versions["7.10.0"] != 1 || versions["7.9.3"] != 1 {
+				t.Errorf("Wrong node versions %v", versions)
+			}
+			node := nir.Nodes["tMTocMvQQgGCkj7QDHl3OA"]
+			if node.Name != "es01" || node.BuildFlavor != "default" || node.BuildType != "docker" || node.JVM.Version != "15.0.1" {
+				t.Errorf("Wrong node build information")
+			}
+			if node.OS.String() != "CentOS Linux 8 (Core)" {
+				t.Errorf("Wrong node operating system %s", node.OS.String())
+			}
+		}
+	}
+}
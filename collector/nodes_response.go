@@ -10,23 +10,159 @@ type nodeStatsResponse struct {
 
 // NodeStatsNodeResponse defines node stats information structure for nodes
 type NodeStatsNodeResponse struct {
-	Name             string                                     `json:"name"`
-	Host             string                                     `json:"host"`
-	Timestamp        int64                                      `json:"timestamp"`
-	TransportAddress string                                     `json:"transport_address"`
-	Hostname         string                                     `json:"hostname"`
-	Roles            []string                                   `json:"roles"`
-	Attributes       map[string]string                          `json:"attributes"`
-	Indices          NodeStatsIndicesResponse                   `json:"indices"`
-	OS               NodeStatsOSResponse                        `json:"os"`
-	Network          NodeStatsNetworkResponse                   `json:"network"`
-	FS               NodeStatsFSResponse                        `json:"fs"`
-	ThreadPool       map[string]NodeStatsThreadPoolPoolResponse `json:"thread_pool"`
-	JVM              NodeStatsJVMResponse                       `json:"jvm"`
-	Breakers         map[string]NodeStatsBreakersResponse       `json:"breakers"`
-	HTTP             map[string]int                             `json:"http"`
-	Transport        NodeStatsTransportResponse                 `json:"transport"`
-	Process          NodeStatsProcessResponse                   `json:"process"`
+	Name              string                                        `json:"name"`
+	Host              string                                        `json:"host"`
+	Timestamp         int64                                         `json:"timestamp"`
+	TransportAddress  string                                        `json:"transport_address"`
+	Hostname          string                                        `json:"hostname"`
+	Roles             []string                                      `json:"roles"`
+	Attributes        map[string]string                             `json:"attributes"`
+	Indices           NodeStatsIndicesResponse                      `json:"indices"`
+	OS                NodeStatsOSResponse                           `json:"os"`
+	Network           NodeStatsNetworkResponse                      `json:"network"`
+	FS                NodeStatsFSResponse                           `json:"fs"`
+	ThreadPool        map[string]NodeStatsThreadPoolPoolResponse    `json:"thread_pool"`
+	JVM               NodeStatsJVMResponse                          `json:"jvm"`
+	Breakers          map[string]NodeStatsBreakersResponse          `json:"breakers"`
+	HTTP              *NodeStatsHTTPResponse                        `json:"http"`
+	Transport         NodeStatsTransportResponse                    `json:"transport"`
+	Process           NodeStatsProcessResponse                      `json:"process"`
+	IndexingPressure  NodeStatsIndexingPressureResponse             `json:"indexing_pressure"`
+	AdaptiveSelection map[string]NodeStatsAdaptiveSelectionResponse `json:"adaptive_selection"`
+	Script            NodeStatsScriptResponse                       `json:"script"`
+	Discovery         NodeStatsDiscoveryResponse                    `json:"discovery"`
+	Ingest            NodeStatsIngestResponse                       `json:"ingest"`
+}
+
+// NodeStatsIngestResponse is a representation of the ingest stats of a node
+type NodeStatsIngestResponse struct {
+	Total     NodeStatsIngestStatsResponse               `json:"total"`
+	Pipelines map[string]NodeStatsIngestPipelineResponse `json:"pipelines"`
+}
+
+// NodeStatsIngestStatsResponse defines the ingest counters of a node, a pipeline or a processor
+type NodeStatsIngestStatsResponse struct {
+	Count        int64 `json:"count"`
+	TimeInMillis int64 `json:"time_in_millis"`
+	Current      int64 `json:"current"`
+	Failed       int64 `json:"failed"`
+}
+
+// NodeStatsIngestPipelineResponse defines the ingest stats of a single pipeline on a node
+type NodeStatsIngestPipelineResponse struct {
+	NodeStatsIngestStatsResponse
+	Processors []map[string]NodeStatsIngestProcessorResponse `json:"processors"`
+}
+
+// NodeStatsIngestProcessorResponse defines the ingest stats of a single processor of a pipeline, keyed by
+// the processor type and its tag
+type NodeStatsIngestProcessorResponse struct {
+	Type  string                       `json:"type"`
+	Stats NodeStatsIngestStatsResponse `json:"stats"`
+}
+
+// ProcessorsByType sums up the stats of the processors of all pipelines by processor type
+func (i NodeStatsIngestResponse) ProcessorsByType() map[string]NodeStatsIngestStatsResponse {
+	types := make(map[string]NodeStatsIngestStatsResponse)
+	for _, pipeline := range i.Pipelines {
+		for _, processors := range pipeline.Processors {
+			for _, processor := range processors {
+				stats := types[processor.Type]
+				stats.Count += processor.Stats.Count
+				stats.TimeInMillis += processor.Stats.TimeInMillis
+				stats.Current += processor.Stats.Current
+				stats.Failed += processor.Stats.Failed
+				types[processor.Type] = stats
+			}
+		}
+	}
+	return types
+}
+
+// NodeStatsDiscoveryResponse is a representation of the cluster state publication stats of a node
+type NodeStatsDiscoveryResponse struct {
+	ClusterStateQueue struct {
+		Total     int64 `json:"total"`
+		Pending   int64 `json:"pending"`
+		Committed int64 `json:"committed"`
+	} `json:"cluster_state_queue"`
+	PublishedClusterStates struct {
+		FullStates        int64 `json:"full_states"`
+		IncompatibleDiffs int64 `json:"incompatible_diffs"`
+		CompatibleDiffs   int64 `json:"compatible_diffs"`
+	} `json:"published_cluster_states"`
+	ClusterStateUpdate struct {
+		Unchanged NodeStatsClusterStateUpdateResponse `json:"unchanged"`
+		Success   NodeStatsClusterStateUpdateResponse `json:"success"`
+		Failure   NodeStatsClusterStateUpdateResponse `json:"failure"`
+	} `json:"cluster_state_update"`
+	SerializedClusterStates struct {
+		FullStates NodeStatsSerializedClusterStatesResponse `json:"full_states"`
+		Diffs      NodeStatsSerializedClusterStatesResponse `json:"diffs"`
+	} `json:"serialized_cluster_states"`
+}
+
+// NodeStatsSerializedClusterStatesResponse defines the number and size of the full cluster states or diffs serialized by the elected master
+type NodeStatsSerializedClusterStatesResponse struct {
+	Count                   int64 `json:"count"`
+	UncompressedSizeInBytes int64 `json:"uncompressed_size_in_bytes"`
+	CompressedSizeInBytes   int64 `json:"compressed_size_in_bytes"`
+}
+
+// NodeStatsClusterStateUpdateResponse defines the number and duration of the cluster state updates computed by the elected master with a single outcome
+type NodeStatsClusterStateUpdateResponse struct {
+	Count                         int64 `json:"count"`
+	ComputationTimeMillis         int64 `json:"computation_time_millis"`
+	PublicationTimeMillis         int64 `json:"publication_time_millis"`
+	ContextConstructionTimeMillis int64 `json:"context_construction_time_millis"`
+	CommitTimeMillis              int64 `json:"commit_time_millis"`
+	CompletionTimeMillis          int64 `json:"completion_time_millis"`
+	MasterApplyTimeMillis         int64 `json:"master_apply_time_millis"`
+	NotificationTimeMillis        int64 `json:"notification_time_millis"`
+}
+
+// NodeStatsScriptResponse is a representation of the script compilation stats of a node
+type NodeStatsScriptResponse struct {
+	NodeStatsScriptContextResponse
+	Contexts []NodeStatsScriptContextResponse `json:"contexts"`
+}
+
+// NodeStatsScriptContextResponse defines the script compilation stats of a single script context
+type NodeStatsScriptContextResponse struct {
+	Context                   string `json:"context"`
+	Compilations              int64  `json:"compilations"`
+	CacheEvictions            int64  `json:"cache_evictions"`
+	CompilationLimitTriggered int64  `json:"compilation_limit_triggered"`
+}
+
+// NodeStatsAdaptiveSelectionResponse is a representation of the adaptive replica selection stats of a node for a target node
+type NodeStatsAdaptiveSelectionResponse struct {
+	OutgoingSearches  int64   `json:"outgoing_searches"`
+	AvgQueueSize      int64   `json:"avg_queue_size"`
+	AvgServiceTimeNs  int64   `json:"avg_service_time_ns"`
+	AvgResponseTimeNs int64   `json:"avg_response_time_ns"`
+	Rank              float64 `json:"rank,string"`
+}
+
+// NodeStatsIndexingPressureResponse is a representation of the indexing pressure stats of a node
+type NodeStatsIndexingPressureResponse struct {
+	Memory struct {
+		Current      NodeStatsIndexingPressureMemoryResponse `json:"current"`
+		Total        NodeStatsIndexingPressureMemoryResponse `json:"total"`
+		LimitInBytes int64                                   `json:"limit_in_bytes"`
+	} `json:"memory"`
+}
+
+// NodeStatsIndexingPressureMemoryResponse defines the memory used by indexing operations and the rejected operations
+type NodeStatsIndexingPressureMemoryResponse struct {
+	CombinedCoordinatingAndPrimaryInBytes int64 `json:"combined_coordinating_and_primary_in_bytes"`
+	CoordinatingInBytes                   int64 `json:"coordinating_in_bytes"`
+	PrimaryInBytes                        int64 `json:"primary_in_bytes"`
+	ReplicaInBytes                        int64 `json:"replica_in_bytes"`
+	AllInBytes                            int64 `json:"all_in_bytes"`
+	CoordinatingRejections                int64 `json:"coordinating_rejections"`
+	PrimaryRejections                     int64 `json:"primary_rejections"`
+	ReplicaRejections                     int64 `json:"replica_rejections"`
 }
 
 // NodeStatsBreakersResponse is a representation of a statistics about the field data circuit breaker
@@ -92,6 +228,8 @@ type NodeStatsTransportResponse struct {
 	RxSize     int64 `json:"rx_size_in_bytes"`
 	TxCount    int64 `json:"tx_count"`
 	TxSize     int64 `json:"tx_size_in_bytes"`
+	// TotalOutboundConnections is reported since 7.10
+	TotalOutboundConnections int64 `json:"total_outbound_connections"`
 }
 
 // NodeStatsThreadPoolPoolResponse is a representation of a statistics about each thread pool, including current size, queue and rejected tasks
@@ -136,6 +274,7 @@ type NodeStatsIndicesResponse struct {
 	Refresh      NodeStatsIndicesRefreshResponse
 	Translog     NodeStatsIndicesTranslogResponse
 	Completion   NodeStatsIndicesCompletionResponse
+	DenseVector  NodeStatsIndicesDenseVectorResponse `json:"dense_vector"`
 }
 
 // NodeStatsIndicesDocsResponse defines node stats docs information structure for indices
@@ -161,6 +300,15 @@ type NodeStatsIndicesCompletionResponse struct {
 	Size int64 `json:"size_in_bytes"`
 }
 
+// NodeStatsIndicesDenseVectorResponse defines node stats dense vector information structure for indices
+type NodeStatsIndicesDenseVectorResponse struct {
+	ValueCount int64 `json:"value_count"`
+	OffHeap    struct {
+		TotalSizeBytes    int64 `json:"total_size_bytes"`
+		TotalVexSizeBytes int64 `json:"total_vex_size_bytes"`
+	} `json:"off_heap"`
+}
+
 // NodeStatsIndicesSegmentsResponse defines node stats segments information structure for indices
 type NodeStatsIndicesSegmentsResponse struct {
 	Count              int64 `json:"count"`
@@ -204,6 +352,8 @@ type NodeStatsIndicesMergesResponse struct {
 	TotalSize          int64 `json:"total_size_in_bytes"`
 	TotalTime          int64 `json:"total_time_in_millis"`
 	TotalThrottledTime int64 `json:"total_throttled_time_in_millis"`
+	TotalStoppedTime   int64 `json:"total_stopped_time_in_millis"`
+	TotalAutoThrottle  int64 `json:"total_auto_throttle_in_bytes"`
 }
 
 // NodeStatsIndicesGetResponse defines node stats get information structure for indices
@@ -253,6 +403,8 @@ type NodeStatsIndicesCacheResponse struct {
 	HitCount   int64 `json:"hit_count"`
 	MissCount  int64 `json:"miss_count"`
 	TotalCount int64 `json:"total_count"`
+	// GlobalOrdinals is only reported for fielddata
+	GlobalOrdinals IndexStatsGlobalOrdinalsResponse `json:"global_ordinals"`
 }
 
 // NodeStatsOSResponse is a representation of a  operating system stats, load average, mem, swap
@@ -261,10 +413,35 @@ type NodeStatsOSResponse struct {
 	Uptime    int64 `json:"uptime_in_millis"`
 	// LoadAvg was an array of per-cpu values pre-2.0, and is a string in 2.0
 	// Leaving this here in case we want to implement parsing logic later
-	LoadAvg json.RawMessage         `json:"load_average"`
-	CPU     NodeStatsOSCPUResponse  `json:"cpu"`
-	Mem     NodeStatsOSMemResponse  `json:"mem"`
-	Swap    NodeStatsOSSwapResponse `json:"swap"`
+	LoadAvg json.RawMessage           `json:"load_average"`
+	CPU     NodeStatsOSCPUResponse    `json:"cpu"`
+	Mem     NodeStatsOSMemResponse    `json:"mem"`
+	Swap    NodeStatsOSSwapResponse   `json:"swap"`
+	Cgroup  NodeStatsOSCgroupResponse `json:"cgroup"`
+}
+
+// NodeStatsOSCgroupResponse defines node stats control group structure of containerized nodes
+type NodeStatsOSCgroupResponse struct {
+	CPUAcct struct {
+		ControlGroup string `json:"control_group"`
+		UsageNanos   int64  `json:"usage_nanos"`
+	} `json:"cpuacct"`
+	CPU struct {
+		ControlGroup    string `json:"control_group"`
+		CFSPeriodMicros int64  `json:"cfs_period_micros"`
+		CFSQuotaMicros  int64  `json:"cfs_quota_micros"`
+		Stat            struct {
+			NumberOfElapsedPeriods int64 `json:"number_of_elapsed_periods"`
+			NumberOfTimesThrottled int64 `json:"number_of_times_throttled"`
+			TimeThrottledNanos     int64 `json:"time_throttled_nanos"`
+		} `json:"stat"`
+	} `json:"cpu"`
+	Memory struct {
+		ControlGroup string `json:"control_group"`
+		// LimitInBytes and UsageInBytes are strings, as cgroup v2 reports "max" if no limit is set
+		LimitInBytes string `json:"limit_in_bytes"`
+		UsageInBytes string `json:"usage_in_bytes"`
+	} `json:"memory"`
 }
 
 // NodeStatsOSMemResponse defines node stats operating system memory usage structure
@@ -324,13 +501,55 @@ type NodeStatsProcessCPUResponse struct {
 
 // NodeStatsHTTPResponse defines node stats HTTP connections structure
 type NodeStatsHTTPResponse struct {
-	CurrentOpen int64 `json:"current_open"`
-	TotalOpen   int64 `json:"total_open"`
+	CurrentOpen int64                         `json:"current_open"`
+	TotalOpen   int64                         `json:"total_opened"`
+	Clients     []NodeStatsHTTPClientResponse `json:"clients"`
+}
+
+// NodeStatsHTTPClientResponse defines a single HTTP client tracked by the node since 7.13
+type NodeStatsHTTPClientResponse struct {
+	ID                    int64  `json:"id"`
+	Agent                 string `json:"agent"`
+	LocalAddress          string `json:"local_address"`
+	RemoteAddress         string `json:"remote_address"`
+	LastURI               string `json:"last_uri"`
+	OpenedTimeMillis      int64  `json:"opened_time_millis"`
+	ClosedTimeMillis      int64  `json:"closed_time_millis"`
+	LastRequestTimeMillis int64  `json:"last_request_time_millis"`
+	RequestCount          int64  `json:"request_count"`
+	RequestSizeBytes      int64  `json:"request_size_bytes"`
+}
+
+// NodeStatsHTTPAgentResponse sums up the tracked HTTP clients of a single user agent
+type NodeStatsHTTPAgentResponse struct {
+	Open         int64
+	Closed       int64
+	RequestCount int64
+}
+
+// ClientsByAgent sums up the tracked HTTP clients by their user agent, as every client is a single connection
+func (h *NodeStatsHTTPResponse) ClientsByAgent() map[string]NodeStatsHTTPAgentResponse {
+	if h == nil {
+		return nil
+	}
+	agents := make(map[string]NodeStatsHTTPAgentResponse)
+	for _, client := range h.Clients {
+		agent := agents[client.Agent]
+		if client.ClosedTimeMillis > 0 {
+			agent.Closed++
+		} else {
+			agent.Open++
+		}
+		agent.RequestCount += client.RequestCount
+		agents[client.Agent] = agent
+	}
+	return agents
 }
 
 // NodeStatsFSResponse is a representation of a file system information, data path, free disk space, read/write stats
 type NodeStatsFSResponse struct {
 	Timestamp int64                      `json:"timestamp"`
+	Total     NodeStatsFSDataResponse    `json:"total"`
 	Data      []NodeStatsFSDataResponse  `json:"data"`
 	IOStats   NodeStatsFSIOStatsResponse `json:"io_stats"`
 }
@@ -358,6 +577,8 @@ type NodeStatsFSIOStatsDeviceResponse struct {
 	WriteOperations int64  `json:"write_operations"`
 	ReadSize        int64  `json:"read_kilobytes"`
 	WriteSize       int64  `json:"write_kilobytes"`
+	// io_time_in_millis is reported since 8.2
+	IOTimeInMillis int64 `json:"io_time_in_millis"`
 }
 
 // ClusterHealthResponse is a representation of a Elasticsearch Cluster Health
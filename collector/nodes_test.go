@@ -3,6 +3,7 @@ package collector
 import (
 	"encoding/base64"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -122,6 +123,680 @@ func TestNodesStats(t *testing.T) {
 	}
 }
 
+func TestNodesThreadPools(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/thread_pool
+	tcs := map[string]string{
+		"8.5.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1668000000000,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master","ml"],"thread_pool":{"search":{"threads":13,"queue":4,"active":13,"rejected":7,"largest":13,"completed":1024},"write":{"threads":8,"queue":0,"active":1,"rejected":0,"largest":8,"completed":4096},"ml_utility":{"threads":2,"queue":0,"active":0,"rejected":0,"largest":3,"completed":58},"searchable_snapshots_cache_fetch_async":{"threads":0,"queue":0,"active":0,"rejected":0,"largest":0,"completed":0},"security-token-key":{"threads":0,"queue":0,"active":0,"rejected":0,"largest":1,"completed":1}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		pools := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"].ThreadPool
+		// plugin and module pools must be exported without being known in advance
+		for _, pool := range []string{"search", "write", "ml_utility", "searchable_snapshots_cache_fetch_async", "security-token-key"} {
+			if _, ok := pools[pool]; !ok {
+				t.Errorf("Thread pool %s is missing", pool)
+			}
+		}
+		if search := pools["search"]; search.Queue != 4 || search.Active != 13 || search.Rejected != 7 || search.Largest != 13 || search.Completed != 1024 {
+			t.Errorf("Wrong search thread pool stats")
+		}
+	}
+}
+
+func TestNodesBreakers(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/breaker
+	tcs := map[string]string{
+		"8.5.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1668000000000,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master","ml"],"breakers":{"model_inference":{"limit_size_in_bytes":536870912,"limit_size":"512mb","estimated_size_in_bytes":0,"estimated_size":"0b","overhead":1.0,"tripped":0},"eql_sequence":{"limit_size_in_bytes":536870912,"limit_size":"512mb","estimated_size_in_bytes":0,"estimated_size":"0b","overhead":1.0,"tripped":0},"fielddata":{"limit_size_in_bytes":429496729,"limit_size":"409.5mb","estimated_size_in_bytes":10240,"estimated_size":"10kb","overhead":1.03,"tripped":2},"request":{"limit_size_in_bytes":644245094,"limit_size":"614.3mb","estimated_size_in_bytes":0,"estimated_size":"0b","overhead":1.0,"tripped":0},"inflight_requests":{"limit_size_in_bytes":1073741824,"limit_size":"1gb","estimated_size_in_bytes":512,"estimated_size":"512b","overhead":2.0,"tripped":0},"parent":{"limit_size_in_bytes":1020054732,"limit_size":"972.7mb","estimated_size_in_bytes":412316860,"estimated_size":"393.2mb","overhead":1.0,"tripped":5}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		breakers := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"].Breakers
+		for _, breaker := range []string{"parent", "fielddata", "request", "inflight_requests", "model_inference", "eql_sequence"} {
+			if _, ok := breakers[breaker]; !ok {
+				t.Errorf("Breaker %s is missing", breaker)
+			}
+		}
+		if parent := breakers["parent"]; parent.LimitSize != 1020054732 || parent.EstimatedSize != 412316860 || parent.Tripped != 5 {
+			t.Errorf("Wrong parent breaker stats")
+		}
+		if breakers["inflight_requests"].Overhead != 2 {
+			t.Errorf("Wrong inflight_requests breaker overhead")
+		}
+	}
+}
+
+func TestNodesIndexingPressure(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/indexing_pressure
+	tcs := map[string]string{
+		"7.10.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"indexing_pressure":{"memory":{"current":{"combined_coordinating_and_primary_in_bytes":2048,"coordinating_in_bytes":1024,"primary_in_bytes":1024,"replica_in_bytes":512,"all_in_bytes":2560},"total":{"combined_coordinating_and_primary_in_bytes":81920,"coordinating_in_bytes":40960,"primary_in_bytes":40960,"replica_in_bytes":20480,"all_in_bytes":102400,"coordinating_rejections":3,"primary_rejections":1,"replica_rejections":0},"limit_in_bytes":107374182}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		memory := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"].IndexingPressure.Memory
+		if memory.LimitInBytes != 107374182 || memory.Current.AllInBytes != 2560 || memory.Current.ReplicaInBytes != 512 {
+			t.Errorf("Wrong indexing pressure memory")
+		}
+		if memory.Total.CoordinatingRejections != 3 || memory.Total.PrimaryRejections != 1 {
+			t.Errorf("Wrong indexing pressure rejections")
+		}
+	}
+}
+
+func TestNodesAdaptiveSelection(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/adaptive_selection
+	tcs := map[string]string{
+		"7.10.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"adaptive_selection":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"outgoing_searches":0,"avg_queue_size":0,"avg_service_time_ns":2176713,"avg_response_time_ns":3041880,"rank":"3.0"},"x5dUAzL5RIqnXbcqLpMcjA":{"outgoing_searches":2,"avg_queue_size":1,"avg_service_time_ns":7479228,"avg_response_time_ns":9207702,"rank":"9.2"}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		if len(node.AdaptiveSelection) != 2 {
+			t.Fatalf("Wrong number of adaptive selection target nodes")
+		}
+		selection := node.AdaptiveSelection["x5dUAzL5RIqnXbcqLpMcjA"]
+		if selection.OutgoingSearches != 2 || selection.AvgQueueSize != 1 || selection.AvgServiceTimeNs != 7479228 || selection.AvgResponseTimeNs != 9207702 || selection.Rank != 9.2 {
+			t.Errorf("Wrong adaptive selection stats")
+		}
+	}
+}
+
+func TestNodesMerges(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats
+	tcs := map[string]string{
+		"7.10.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"indices":{"merges":{"current":1,"current_docs":3217,"current_size_in_bytes":2451906,"total":301,"total_time_in_millis":81234,"total_docs":982301,"total_size_in_bytes":771923011,"total_stopped_time_in_millis":120,"total_throttled_time_in_millis":5430,"total_auto_throttle_in_bytes":20971520}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		merges := node.Indices.Merges
+		if merges.Current != 1 || merges.CurrentDocs != 3217 || merges.TotalDocs != 982301 {
+			t.Errorf("Wrong merge counts")
+		}
+		if merges.TotalStoppedTime != 120 || merges.TotalThrottledTime != 5430 {
+			t.Errorf("Wrong merge stopped or throttled time")
+		}
+		if merges.TotalAutoThrottle != 20971520 {
+			t.Errorf("Wrong merge auto-throttle rate")
+		}
+	}
+}
+
+func TestNodesDenseVector(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/indices/dense_vector
+	tcs := map[string]string{
+		"9.0.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"indices":{"dense_vector":{"value_count":150000,"off_heap":{"total_size_bytes":622080000,"total_veb_size_bytes":0,"total_vec_size_bytes":576000000,"total_veq_size_bytes":9216000,"total_vex_size_bytes":36864000,"fielddata":{}}}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		dv := node.Indices.DenseVector
+		if dv.ValueCount != 150000 || dv.OffHeap.TotalSizeBytes != 622080000 || dv.OffHeap.TotalVexSizeBytes != 36864000 {
+			t.Errorf("Wrong dense vector stats %+v", dv)
+		}
+	}
+}
+
+func TestNodesScript(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/script
+	tcs := map[string]string{
+		"7.10.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"script":{"compilations":12,"cache_evictions":2,"compilation_limit_triggered":1,"contexts":[{"context":"aggs","compilations":2,"cache_evictions":0,"compilation_limit_triggered":0},{"context":"update","compilations":10,"cache_evictions":2,"compilation_limit_triggered":1}]}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		if node.Script.Compilations != 12 || node.Script.CacheEvictions != 2 || node.Script.CompilationLimitTriggered != 1 {
+			t.Errorf("Wrong script stats %+v", node.Script)
+		}
+		if len(node.Script.Contexts) != 2 {
+			t.Fatalf("Wrong number of script contexts")
+		}
+		if c := node.Script.Contexts[1]; c.Context != "update" || c.Compilations != 10 || c.CompilationLimitTriggered != 1 {
+			t.Errorf("Wrong update script context stats %+v", c)
+		}
+	}
+}
+
+func TestNodesDiscovery(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/discovery
+	tcs := map[string]string{
+		"7.16.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"discovery":{"cluster_state_queue":{"total":3,"pending":1,"committed":2},"published_cluster_states":{"full_states":3,"incompatible_diffs":1,"compatible_diffs":210},"cluster_state_update":{"unchanged":{"count":84,"computation_time_millis":120,"notification_time_millis":3},"success":{"count":215,"computation_time_millis":512,"publication_time_millis":4120,"context_construction_time_millis":130,"commit_time_millis":1822,"completion_time_millis":3877,"master_apply_time_millis":611,"notification_time_millis":45},"failure":{"count":2,"computation_time_millis":7,"publication_time_millis":60040,"context_construction_time_millis":1,"commit_time_millis":30011,"completion_time_millis":0,"master_apply_time_millis":0,"notification_time_millis":0}},"serialized_cluster_states":{"full_states":{"count":3,"uncompressed_size_in_bytes":9437184,"compressed_size_in_bytes":1048576},"diffs":{"count":210,"uncompressed_size_in_bytes":2097152,"compressed_size_in_bytes":524288}}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		d := node.Discovery
+		if d.ClusterStateQueue.Pending != 1 || d.ClusterStateQueue.Committed != 2 {
+			t.Errorf("Wrong cluster state queue %+v", d.ClusterStateQueue)
+		}
+		if d.PublishedClusterStates.FullStates != 3 || d.PublishedClusterStates.CompatibleDiffs != 210 || d.PublishedClusterStates.IncompatibleDiffs != 1 {
+			t.Errorf("Wrong published cluster states %+v", d.PublishedClusterStates)
+		}
+		if s := d.ClusterStateUpdate.Success; s.Count != 215 || s.PublicationTimeMillis != 4120 || s.CommitTimeMillis != 1822 || s.MasterApplyTimeMillis != 611 {
+			t.Errorf("Wrong successful cluster state updates %+v", s)
+		}
+		if d.ClusterStateUpdate.Failure.Count != 2 || d.ClusterStateUpdate.Unchanged.Count != 84 {
+			t.Errorf("Wrong cluster state update counts")
+		}
+		if f := d.SerializedClusterStates.FullStates; f.Count != 3 || f.CompressedSizeInBytes != 1048576 || f.UncompressedSizeInBytes != 9437184 {
+			t.Errorf("Wrong serialized full cluster states %+v", f)
+		}
+		if d.SerializedClusterStates.Diffs.Count != 210 {
+			t.Errorf("Wrong number of serialized cluster state diffs")
+		}
+	}
+}
+
+func TestNodesCgroup(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/os
+	tcs := map[string]string{
+		"7.10.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"os":{"timestamp":1610120231047,"cpu":{"percent":12,"load_average":{"1m":0.5,"5m":0.4,"15m":0.3}},"mem":{"total_in_bytes":4294967296,"free_in_bytes":1073741824,"used_in_bytes":3221225472,"free_percent":25,"used_percent":75},"swap":{"total_in_bytes":0,"free_in_bytes":0,"used_in_bytes":0},"cgroup":{"cpuacct":{"control_group":"/","usage_nanos":1871219998664},"cpu":{"control_group":"/","cfs_period_micros":100000,"cfs_quota_micros":200000,"stat":{"number_of_elapsed_periods":12000,"number_of_times_throttled":320,"time_throttled_nanos":18475000000}},"memory":{"control_group":"/","limit_in_bytes":"4294967296","usage_in_bytes":"2147483648"}}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		cgroup := node.OS.Cgroup
+		if cgroup.CPUAcct.UsageNanos != 1871219998664 || cgroup.CPU.CFSQuotaMicros != 200000 {
+			t.Errorf("Wrong cgroup cpu stats %+v", cgroup)
+		}
+		if stat := cgroup.CPU.Stat; stat.NumberOfElapsedPeriods != 12000 || stat.NumberOfTimesThrottled != 320 || stat.TimeThrottledNanos != 18475000000 {
+			t.Errorf("Wrong cgroup cfs stats %+v", stat)
+		}
+		if cgroupLimit(cgroup.Memory.LimitInBytes) != 4294967296 || cgroup.Memory.UsageInBytes != "2147483648" {
+			t.Errorf("Wrong cgroup memory stats %+v", cgroup.Memory)
+		}
+		if !math.IsInf(cgroupLimit("max"), 1) {
+			t.Errorf("An unlimited cgroup memory limit should be +Inf")
+		}
+	}
+}
+
+func TestNodesJVMBufferPoolsAndGC(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/jvm
+	tcs := map[string]string{
+		"8.12.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"jvm":{"timestamp":1705000000000,"uptime_in_millis":3600000,"mem":{"heap_used_in_bytes":268435456,"heap_used_percent":25,"heap_committed_in_bytes":1073741824,"heap_max_in_bytes":1073741824,"non_heap_used_in_bytes":157286400,"non_heap_committed_in_bytes":167772160,"pools":{}},"gc":{"collectors":{"young":{"collection_count":0,"collection_time_in_millis":0},"old":{"collection_count":0,"collection_time_in_millis":0},"ZGC Major Cycles":{"collection_count":12,"collection_time_in_millis":8421},"ZGC Minor Pauses":{"collection_count":340,"collection_time_in_millis":17}}},"buffer_pools":{"mapped":{"count":36,"used_in_bytes":1059303,"total_capacity_in_bytes":1059303},"direct":{"count":142,"used_in_bytes":38797405,"total_capacity_in_bytes":38797404},"mapped - 'non-volatile memory'":{"count":0,"used_in_bytes":0,"total_capacity_in_bytes":0}}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		pools := node.JVM.BufferPools
+		if len(pools) != 3 {
+			t.Fatalf("Wrong number of buffer pools")
+		}
+		if direct := pools["direct"]; direct.Count != 142 || direct.Used != 38797405 || direct.TotalCapacity != 38797404 {
+			t.Errorf("Wrong direct buffer pool %+v", direct)
+		}
+		if pools["mapped - 'non-volatile memory'"].Count != 0 || pools["mapped"].Count != 36 {
+			t.Errorf("Wrong mapped buffer pools")
+		}
+		for _, collector := range []string{"young", "old", "ZGC Major Cycles", "ZGC Minor Pauses"} {
+			if _, ok := node.JVM.GC.Collectors[collector]; !ok {
+				t.Errorf("GC collector %s is missing", collector)
+			}
+		}
+		if zgc := node.JVM.GC.Collectors["ZGC Major Cycles"]; zgc.CollectionCount != 12 || zgc.CollectionTime != 8421 {
+			t.Errorf("Wrong ZGC collector stats %+v", zgc)
+		}
+	}
+}
+
+func TestNodesTransport(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/transport
+	tcs := map[string]string{
+		"7.10.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"transport":{"server_open":26,"total_outbound_connections":13,"rx_count":1958213,"rx_size_in_bytes":4304812983,"tx_count":1958205,"tx_size_in_bytes":3190125315}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		tr := node.Transport
+		if tr.ServerOpen != 26 || tr.TotalOutboundConnections != 13 {
+			t.Errorf("Wrong transport connections %+v", tr)
+		}
+		if tr.RxCount != 1958213 || tr.RxSize != 4304812983 || tr.TxCount != 1958205 || tr.TxSize != 3190125315 {
+			t.Errorf("Wrong transport traffic %+v", tr)
+		}
+	}
+}
+
+func TestNodesHTTP(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/http
+	tcs := map[string]string{
+		"7.17.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"http":{"current_open":3,"total_opened":42,"clients":[{"id":1,"agent":"curl/7.68.0","local_address":"172.17.0.2:9200","remote_address":"172.17.0.1:51234","last_uri":"/_cat/nodes","opened_time_millis":1605733340000,"closed_time_millis":1605733341000,"last_request_time_millis":1605733340500,"request_count":2,"request_size_bytes":0},{"id":2,"agent":"curl/7.68.0","local_address":"172.17.0.2:9200","remote_address":"172.17.0.1:51236","last_uri":"/_nodes/stats","opened_time_millis":1605733342000,"last_request_time_millis":1605733342100,"request_count":3,"request_size_bytes":0},{"id":3,"agent":"Go-http-client/1.1","local_address":"172.17.0.2:9200","remote_address":"172.17.0.3:40120","last_uri":"/_nodes/stats","opened_time_millis":1605733300000,"last_request_time_millis":1605733345000,"request_count":120,"request_size_bytes":0}]}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		h := httpStats(node)
+		if h.CurrentOpen != 3 || h.TotalOpen != 42 {
+			t.Errorf("Wrong HTTP connections %+v", h)
+		}
+		agents := node.HTTP.ClientsByAgent()
+		if len(agents) != 2 {
+			t.Fatalf("Wrong number of HTTP client agents %+v", agents)
+		}
+		if a := agents["curl/7.68.0"]; a.Open != 1 || a.Closed != 1 || a.RequestCount != 5 {
+			t.Errorf("Wrong HTTP clients for curl %+v", a)
+		}
+		if a := agents["Go-http-client/1.1"]; a.Open != 1 || a.Closed != 0 || a.RequestCount != 120 {
+			t.Errorf("Wrong HTTP clients for Go %+v", a)
+		}
+		if agents := (*NodeStatsHTTPResponse)(nil).ClientsByAgent(); agents != nil {
+			t.Errorf("Expected no HTTP client agents without HTTP stats, got %+v", agents)
+		}
+	}
+}
+
+func TestNodesFilesystemDataPaths(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/fs
+	tcs := map[string]string{
+		"7.10.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"fs":{"timestamp":1605733345105,"total":{"total_in_bytes":214748364800,"free_in_bytes":92341796864,"available_in_bytes":86973087744},"data":[{"path":"/data1/nodes/0","mount":"/data1 (/dev/sdb1)","type":"ext4","total_in_bytes":107374182400,"free_in_bytes":1073741824,"available_in_bytes":1073741824},{"path":"/data2/nodes/0","mount":"/data2 (/dev/sdc1)","type":"ext4","total_in_bytes":107374182400,"free_in_bytes":91268055040,"available_in_bytes":85899345920}]}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		if len(node.FS.Data) != 2 {
+			t.Fatalf("Wrong number of data paths %+v", node.FS.Data)
+		}
+		if data := node.FS.Data[0]; data.Path != "/data1/nodes/0" || data.Mount != "/data1 (/dev/sdb1)" || data.Available != 1073741824 || data.Total != 107374182400 {
+			t.Errorf("Wrong first data path %+v", data)
+		}
+		if data := node.FS.Data[1]; data.Path != "/data2/nodes/0" || data.Available != 85899345920 || data.Free != 91268055040 {
+			t.Errorf("Wrong second data path %+v", data)
+		}
+	}
+}
+
+func TestNodesIngestPipelines(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/ingest
+	tcs := map[string]string{
+		"7.10.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"ingest":{"total":{"count":1520,"time_in_millis":3120,"current":2,"failed":12},"pipelines":{"logs-grok":{"count":1500,"time_in_millis":3100,"current":2,"failed":12,"processors":[{"grok":{"type":"grok","stats":{"count":1500,"time_in_millis":2950,"current":2,"failed":12}}},{"remove":{"type":"remove","stats":{"count":1488,"time_in_millis":12,"current":0,"failed":0}}}]},"xpack_monitoring_7":{"count":20,"time_in_millis":20,"current":0,"failed":0,"processors":[{"script":{"type":"script","stats":{"count":20,"time_in_millis":20,"current":0,"failed":0}}}]}}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		ingest := node.Ingest
+		if ingest.Total.Count != 1520 || ingest.Total.TimeInMillis != 3120 || ingest.Total.Failed != 12 {
+			t.Errorf("Wrong ingest totals %+v", ingest.Total)
+		}
+		if len(ingest.Pipelines) != 2 {
+			t.Fatalf("Wrong number of ingest pipelines %+v", ingest.Pipelines)
+		}
+		if pipeline := ingest.Pipelines["logs-grok"]; pipeline.Count != 1500 || pipeline.TimeInMillis != 3100 || pipeline.Current != 2 || pipeline.Failed != 12 {
+			t.Errorf("Wrong stats for ingest pipeline logs-grok %+v", pipeline)
+		}
+		if pipeline := ingest.Pipelines["xpack_monitoring_7"]; pipeline.Count != 20 || pipeline.Failed != 0 {
+			t.Errorf("Wrong stats for ingest pipeline xpack_monitoring_7 %+v", pipeline)
+		}
+	}
+}
+
+func TestNodesFilesystemIOStats(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/_local/stats
+	tcs := map[string]string{
+		"8.5.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"fs":{"timestamp":1605733345105,"total":{"total_in_bytes":501809791488,"free_in_bytes":385262694400,"available_in_bytes":359727730688},"data":[{"path":"/usr/share/elasticsearch/data","mount":"/ (overlay)","type":"overlay","total_in_bytes":501809791488,"free_in_bytes":385262694400,"available_in_bytes":359727730688}],"io_stats":{"devices":[{"device_name":"nvme0n1","operations":2195555,"read_operations":458639,"write_operations":1736916,"read_kilobytes":5629048,"write_kilobytes":10599932,"io_time_in_millis":1837456},{"device_name":"nvme1n1","operations":1391943,"read_operations":95891,"write_operations":1296052,"read_kilobytes":1947644,"write_kilobytes":20826124,"io_time_in_millis":903377}],"total":{"operations":3587498,"read_operations":554530,"write_operations":3032968,"read_kilobytes":7576692,"write_kilobytes":31426056,"io_time_in_millis":2740833}}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		devices := node.FS.IOStats.Devices
+		if len(devices) != 2 {
+			t.Fatalf("Wrong number of io stats devices")
+		}
+		if d := devices[0]; d.DeviceName != "nvme0n1" || d.ReadSize != 5629048 || d.WriteSize != 10599932 || d.IOTimeInMillis != 1837456 {
+			t.Errorf("Wrong io stats of device nvme0n1 %+v", d)
+		}
+		if devices[1].DeviceName != "nvme1n1" || devices[1].WriteOperations != 1296052 {
+			t.Errorf("Wrong io stats of device nvme1n1")
+		}
+	}
+}
+
+func TestNodesDataTiers(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e node.roles=data_content,data_hot,ingest,master elasticsearch:VERSION
+	//  docker run -d -e node.roles=data_warm,master elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/stats/jvm,fs
+	tcs := map[string]string{
+		"7.10.0": `{"_nodes":{"total":4,"successful":4,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data_content","data_hot","ingest","master"],"jvm":{"mem":{"heap_used_in_bytes":536870912,"heap_max_in_bytes":1073741824}},"fs":{"timestamp":1605733345105,"total":{"total_in_bytes":107374182400,"free_in_bytes":53687091200,"available_in_bytes":48318382080},"data":[]}},"kW0o1ULGRgOoPa8RyrOcZA":{"timestamp":1605733345105,"name":"es02","transport_address":"172.17.0.3:9300","host":"172.17.0.3","ip":"172.17.0.3:9300","roles":["data_content","data_hot","ingest","master"],"jvm":{"mem":{"heap_used_in_bytes":268435456,"heap_max_in_bytes":1073741824}},"fs":{"timestamp":1605733345105,"total":{"total_in_bytes":107374182400,"free_in_bytes":85899345920,"available_in_bytes":80530636800},"data":[]}},"YzZ1jFb1SIeyMfyc7QUfRw":{"timestamp":1605733345105,"name":"es03","transport_address":"172.17.0.4:9300","host":"172.17.0.4","ip":"172.17.0.4:9300","roles":["data_warm","master"],"jvm":{"mem":{"heap_used_in_bytes":805306368,"heap_max_in_bytes":2147483648}},"fs":{"timestamp":1605733345105,"total":{"total_in_bytes":1099511627776,"free_in_bytes":549755813888,"available_in_bytes":494780232499},"data":[]}},"Gxw0J7rWRb6ri4fnkd9Pyw":{"timestamp":1605733345105,"name":"es04","transport_address":"172.17.0.5:9300","host":"172.17.0.5","ip":"172.17.0.5:9300","roles":["master"],"jvm":{"mem":{"heap_used_in_bytes":134217728,"heap_max_in_bytes":536870912}},"fs":{"timestamp":1605733345105,"total":{"total_in_bytes":10737418240,"free_in_bytes":8589934592,"available_in_bytes":8053063680},"data":[]}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_all")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		tiers := dataTiers(nsr.Nodes)
+		t.Logf("[%s] Data Tiers: %+v", ver, tiers)
+		if len(tiers) != 3 {
+			t.Fatalf("Wrong number of data tiers")
+		}
+		if hot := tiers["data_hot"]; hot != tiers["data_content"] || hot.nodes != 2 || hot.fsTotal != 214748364800 || hot.fsAvailable != 128849018880 || hot.heapUsed != 805306368 || hot.heapMax != 2147483648 {
+			t.Errorf("Wrong capacity of the hot tier %+v", hot)
+		}
+		if warm := tiers["data_warm"]; warm.nodes != 1 || warm.fsFree != 549755813888 {
+			t.Errorf("Wrong capacity of the warm tier %+v", warm)
+		}
+	}
+}
+
+func TestNodesIngestProcessors(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/_local/stats/ingest
+	tcs := map[string]string{
+		"7.10.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"ingest":{"total":{"count":2500,"time_in_millis":3450,"current":1,"failed":12},"pipelines":{"logs-grok":{"count":1500,"time_in_millis":2100,"current":0,"failed":12,"processors":[{"grok:parse-message":{"type":"grok","stats":{"count":1500,"time_in_millis":2000,"current":0,"failed":12}}},{"set":{"type":"set","stats":{"count":1488,"time_in_millis":35,"current":0,"failed":0}}}]},"metrics-enrich":{"count":1000,"time_in_millis":1350,"current":1,"failed":0,"processors":[{"grok":{"type":"grok","stats":{"count":1000,"time_in_millis":900,"current":0,"failed":0}}},{"script:normalize":{"type":"script","stats":{"count":1000,"time_in_millis":400,"current":1,"failed":0}}},{"set":{"type":"set","stats":{"count":12,"time_in_millis":5,"current":0,"failed":0}}}]}}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		processors := node.Ingest.ProcessorsByType()
+		if len(processors) != 3 {
+			t.Fatalf("Wrong number of ingest processor types %+v", processors)
+		}
+		if grok := processors["grok"]; grok.Count != 2500 || grok.TimeInMillis != 2900 || grok.Failed != 12 {
+			t.Errorf("Wrong stats for grok processors %+v", grok)
+		}
+		if set := processors["set"]; set.Count != 1500 || set.TimeInMillis != 40 {
+			t.Errorf("Wrong stats for set processors %+v", set)
+		}
+		if script := processors["script"]; script.Count != 1000 || script.Current != 1 {
+			t.Errorf("Wrong stats for script processors %+v", script)
+		}
+	}
+}
+
+func TestNodesGlobalOrdinals(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_nodes/_local/stats/indices/fielddata
+	tcs := map[string]string{
+		"8.5.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"indices":{"fielddata":{"memory_size_in_bytes":2048,"evictions":0,"global_ordinals":{"build_time_in_millis":1234}}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewNodes(log.NewNopLogger(), http.DefaultClient, u, true, "_local")
+		nsr, err := c.fetchAndDecodeNodeStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode node stats: %s", err)
+		}
+		t.Logf("[%s] Node Stats Response: %+v", ver, nsr)
+		node := nsr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		if got := node.Indices.FieldData.GlobalOrdinals.BuildTimeInMillis; got != 1234 {
+			t.Errorf("Wrong global ordinals build time %d", got)
+		}
+	}
+}
+
 type basicAuth struct {
 	User string
 	Pass string
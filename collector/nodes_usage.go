@@ -0,0 +1,163 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NodesUsage information struct
+type NodesUsage struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	restActions  *prometheus.Desc
+	aggregations *prometheus.Desc
+	since        *prometheus.Desc
+}
+
+func init() {
+	registerCollector("nodes_usage", defaultDisabled, "Export REST action usage per node.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewNodesUsage(logger, client, url)
+	})
+}
+
+// NewNodesUsage defines Nodes Usage Prometheus metrics
+func NewNodesUsage(logger log.Logger, client *http.Client, url *url.URL) *NodesUsage {
+	subsystem := "nodes_usage"
+
+	return &NodesUsage{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch nodes usage endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch nodes usage scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		restActions: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "rest_actions_total"),
+			"Number of times the REST action has been called on the node since it started",
+			[]string{"cluster", "node", "action"}, nil,
+		),
+		aggregations: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "aggregations_total"),
+			"Number of times the aggregation has been used on the node since it started",
+			[]string{"cluster", "node", "aggregation", "type"}, nil,
+		),
+		since: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "since_timestamp_seconds"),
+			"Timestamp since which the usage of the node has been recorded",
+			[]string{"cluster", "node"}, nil,
+		),
+	}
+}
+
+// Describe add Nodes Usage metrics descriptions
+func (nu *NodesUsage) Describe(ch chan<- *prometheus.Desc) {
+	ch <- nu.restActions
+	ch <- nu.aggregations
+	ch <- nu.since
+	ch <- nu.up.Desc()
+	ch <- nu.totalScrapes.Desc()
+	ch <- nu.jsonParseFailures.Desc()
+}
+
+func (nu *NodesUsage) fetchAndDecodeNodesUsage() (nodesUsageResponse, error) {
+	var nur nodesUsageResponse
+
+	u := *nu.url
+	u.Path = path.Join(u.Path, "/_nodes/usage")
+
+	res, err := nu.client.Get(u.String())
+	if err != nil {
+		return nur, fmt.Errorf("failed to get nodes usage from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(nu.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nur, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&nur); err != nil {
+		nu.jsonParseFailures.Inc()
+		return nur, err
+	}
+	return nur, nil
+}
+
+// Collect gets Nodes Usage metric values
+func (nu *NodesUsage) Collect(ch chan<- prometheus.Metric) {
+	nu.totalScrapes.Inc()
+	defer func() {
+		ch <- nu.up
+		ch <- nu.totalScrapes
+		ch <- nu.jsonParseFailures
+	}()
+
+	nur, err := nu.fetchAndDecodeNodesUsage()
+	if err != nil {
+		nu.up.Set(0)
+		_ = level.Warn(nu.logger).Log(
+			"msg", "failed to fetch and decode nodes usage",
+			"err", err,
+		)
+		return
+	}
+	nu.up.Set(1)
+
+	for node, usage := range nur.Nodes {
+		ch <- prometheus.MustNewConstMetric(
+			nu.since,
+			prometheus.GaugeValue,
+			float64(usage.Since)/1000,
+			nur.ClusterName, node,
+		)
+		for action, count := range usage.RestActions {
+			ch <- prometheus.MustNewConstMetric(
+				nu.restActions,
+				prometheus.CounterValue,
+				float64(count),
+				nur.ClusterName, node, action,
+			)
+		}
+		for aggregation, types := range usage.Aggregations {
+			for typ, count := range types {
+				ch <- prometheus.MustNewConstMetric(
+					nu.aggregations,
+					prometheus.CounterValue,
+					float64(count),
+					nur.ClusterName, node, aggregation, typ,
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package collector
+
+// nodesUsageResponse is a representation of the Elasticsearch _nodes/usage endpoint
+type nodesUsageResponse struct {
+	ClusterName string                            `json:"cluster_name"`
+	Nodes       map[string]nodesUsageNodeResponse `json:"nodes"`
+}
+
+// nodesUsageNodeResponse defines the feature usage of a single node
+type nodesUsageNodeResponse struct {
+	Timestamp    int64                       `json:"timestamp"`
+	Since        int64                       `json:"since"`
+	RestActions  map[string]int64            `json:"rest_actions"`
+	Aggregations map[string]map[string]int64 `json:"aggregations"`
+}
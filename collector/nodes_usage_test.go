@@ -0,0 +1,46 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestNodesUsage(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_search -d '{"aggs":{"by_user":{"terms":{"field":"user"}}}}'
+	//  curl http://localhost:9200/_nodes/usage
+	tcs := map[string]string{
+		"6.8.0":  `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"since":1605733000000,"rest_actions":{"nodes_usage_action":1,"search_action":19}}}}`,
+		"7.10.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"timestamp":1605733345105,"since":1605733000000,"rest_actions":{"nodes_usage_action":1,"search_action":19},"aggregations":{"terms":{"keyword":2,"bytes":1}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		nu := NewNodesUsage(log.NewNopLogger(), http.DefaultClient, u)
+		nur, err := nu.fetchAndDecodeNodesUsage()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode nodes usage: %s", err)
+		}
+		t.Logf("[%s] Nodes Usage Response: %+v", ver, nur)
+		usage := nur.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		if usage.RestActions["search_action"] != 19 || usage.Since != 1605733000000 {
+			t.Errorf("Wrong REST action usage")
+		}
+		if ver == "7.10.0" && usage.Aggregations["terms"]["keyword"] != 2 {
+			t.Errorf("Wrong aggregation usage")
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var pendingTaskPriorities = []string{"IMMEDIATE", "URGENT", "HIGH", "NORMAL", "LOW", "LANGUID"}
+
+// PendingTasks information struct
+type PendingTasks struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	tasks          *prometheus.Desc
+	maxTimeInQueue *prometheus.Desc
+	executing      *prometheus.Desc
+}
+
+func init() {
+	registerCollector("pending_tasks", defaultDisabled, "Export stats for pending cluster tasks.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewPendingTasks(logger, client, url)
+	})
+}
+
+// NewPendingTasks defines Pending Tasks Prometheus metrics
+func NewPendingTasks(logger log.Logger, client *http.Client, url *url.URL) *PendingTasks {
+	subsystem := "cluster_pending_tasks"
+
+	return &PendingTasks{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch pending tasks endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch pending tasks scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		tasks: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "count"),
+			"Number of pending cluster tasks by priority",
+			[]string{"priority"}, nil,
+		),
+		maxTimeInQueue: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "max_time_in_queue_seconds"),
+			"Longest time a pending cluster task of the priority has been waiting in the queue",
+			[]string{"priority"}, nil,
+		),
+		executing: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "executing"),
+			"Number of pending cluster tasks which are currently executing",
+			nil, nil,
+		),
+	}
+}
+
+// Describe add Pending Tasks metrics descriptions
+func (p *PendingTasks) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.tasks
+	ch <- p.maxTimeInQueue
+	ch <- p.executing
+	ch <- p.up.Desc()
+	ch <- p.totalScrapes.Desc()
+	ch <- p.jsonParseFailures.Desc()
+}
+
+func (p *PendingTasks) fetchAndDecodePendingTasks() (pendingTasksResponse, error) {
+	var ptr pendingTasksResponse
+
+	u := *p.url
+	u.Path = path.Join(u.Path, "/_cluster/pending_tasks")
+
+	res, err := p.client.Get(u.String())
+	if err != nil {
+		return ptr, fmt.Errorf("failed to get pending tasks from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(p.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return ptr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&ptr); err != nil {
+		p.jsonParseFailures.Inc()
+		return ptr, err
+	}
+	return ptr, nil
+}
+
+// Collect gets Pending Tasks metric values
+func (p *PendingTasks) Collect(ch chan<- prometheus.Metric) {
+	p.totalScrapes.Inc()
+	defer func() {
+		ch <- p.up
+		ch <- p.totalScrapes
+		ch <- p.jsonParseFailures
+	}()
+
+	ptr, err := p.fetchAndDecodePendingTasks()
+	if err != nil {
+		p.up.Set(0)
+		_ = level.Warn(p.logger).Log(
+			"msg", "failed to fetch and decode pending tasks",
+			"err", err,
+		)
+		return
+	}
+	p.up.Set(1)
+
+	counts := make(map[string]int64, len(pendingTaskPriorities))
+	maxTimeInQueue := make(map[string]int64, len(pendingTaskPriorities))
+	var executing int64
+	for _, task := range ptr.Tasks {
+		counts[task.Priority]++
+		if task.TimeInQueueMillis > maxTimeInQueue[task.Priority] {
+			maxTimeInQueue[task.Priority] = task.TimeInQueueMillis
+		}
+		if task.Executing {
+			executing++
+		}
+	}
+
+	for _, priority := range pendingTaskPriorities {
+		ch <- prometheus.MustNewConstMetric(
+			p.tasks,
+			prometheus.GaugeValue,
+			float64(counts[priority]),
+			priority,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			p.maxTimeInQueue,
+			prometheus.GaugeValue,
+			float64(maxTimeInQueue[priority])/1000,
+			priority,
+		)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		p.executing,
+		prometheus.GaugeValue,
+		float64(executing),
+	)
+}
@@ -0,0 +1,16 @@
+package collector
+
+// pendingTasksResponse is a representation of the Elasticsearch _cluster/pending_tasks endpoint
+type pendingTasksResponse struct {
+	Tasks []pendingTaskResponse `json:"tasks"`
+}
+
+// pendingTaskResponse defines a single pending cluster task
+type pendingTaskResponse struct {
+	InsertOrder       int64  `json:"insert_order"`
+	Priority          string `json:"priority"`
+	Source            string `json:"source"`
+	Executing         bool   `json:"executing"`
+	TimeInQueueMillis int64  `json:"time_in_queue_millis"`
+	TimeInQueue       string `json:"time_in_queue"`
+}
@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestPendingTasks(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_cluster/pending_tasks
+	tcs := map[string]string{
+		"5.4.2":  `{"tasks":[]}`,
+		"7.10.0": `{"tasks":[{"insert_order":101,"priority":"URGENT","source":"create-index [foo_9], cause [api]","executing":true,"time_in_queue_millis":86,"time_in_queue":"86ms"},{"insert_order":46,"priority":"HIGH","source":"shard-started ([foo_2][1], node[tMTocMvQQgGCkj7QDHl3OA], [P], s[INITIALIZING]), reason [after recovery from shard_store]","executing":false,"time_in_queue_millis":842,"time_in_queue":"842ms"},{"insert_order":45,"priority":"HIGH","source":"shard-started ([foo_2][0], node[tMTocMvQQgGCkj7QDHl3OA], [P], s[INITIALIZING]), reason [after recovery from shard_store]","executing":false,"time_in_queue_millis":858,"time_in_queue":"858ms"}]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		p := NewPendingTasks(log.NewNopLogger(), http.DefaultClient, u)
+		ptr, err := p.fetchAndDecodePendingTasks()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode pending tasks: %s", err)
+		}
+		t.Logf("[%s] Pending Tasks Response: %+v", ver, ptr)
+		if ver == "5.4.2" && len(ptr.Tasks) != 0 {
+			t.Errorf("There should be no pending tasks")
+		}
+		if ver == "7.10.0" {
+			if len(ptr.Tasks) != 3 {
+				t.Fatalf("Wrong number of pending tasks")
+			}
+			if ptr.Tasks[0].Priority != "URGENT" || !ptr.Tasks[0].Executing || ptr.Tasks[2].TimeInQueueMillis != 858 {
+				t.Errorf("Wrong pending task")
+			}
+		}
+	}
+}
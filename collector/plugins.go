@@ -0,0 +1,132 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Plugins information struct
+type Plugins struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	pluginInfo *prometheus.Desc
+}
+
+func init() {
+	registerCollector("plugins", defaultDisabled, "Export the plugins installed on every node.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewPlugins(logger, client, url)
+	})
+}
+
+// NewPlugins defines Plugins Prometheus metrics
+func NewPlugins(logger log.Logger, client *http.Client, url *url.URL) *Plugins {
+	subsystem := "plugins"
+
+	return &Plugins{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch cat plugins endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch cat plugins scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		pluginInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "node", "plugin_info"),
+			"Plugin installed on the node, with the version of the plugin as label",
+			[]string{"node", "plugin", "version"}, nil,
+		),
+	}
+}
+
+// Describe add Plugins metrics descriptions
+func (p *Plugins) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.pluginInfo
+	ch <- p.up.Desc()
+	ch <- p.totalScrapes.Desc()
+	ch <- p.jsonParseFailures.Desc()
+}
+
+func (p *Plugins) fetchAndDecodeCatPlugins() (catPluginsResponse, error) {
+	var cpr catPluginsResponse
+
+	u := *p.url
+	u.Path = path.Join(u.Path, "/_cat/plugins")
+	u.RawQuery = "format=json"
+
+	res, err := p.client.Get(u.String())
+	if err != nil {
+		return cpr, fmt.Errorf("failed to get cat plugins from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(p.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return cpr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&cpr); err != nil {
+		p.jsonParseFailures.Inc()
+		return cpr, err
+	}
+	return cpr, nil
+}
+
+// Collect gets Plugins metric values
+func (p *Plugins) Collect(ch chan<- prometheus.Metric) {
+	p.totalScrapes.Inc()
+	defer func() {
+		ch <- p.up
+		ch <- p.totalScrapes
+		ch <- p.jsonParseFailures
+	}()
+
+	cpr, err := p.fetchAndDecodeCatPlugins()
+	if err != nil {
+		p.up.Set(0)
+		_ = level.Warn(p.logger).Log(
+			"msg", "failed to fetch and decode cat plugins",
+			"err", err,
+		)
+		return
+	}
+	p.up.Set(1)
+
+	for _, plugin := range cpr {
+		ch <- prometheus.MustNewConstMetric(
+			p.pluginInfo,
+			prometheus.GaugeValue,
+			1,
+			plugin.Name, plugin.Component, plugin.Version,
+		)
+	}
+}
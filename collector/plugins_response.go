@@ -0,0 +1,11 @@
+package collector
+
+// catPluginsResponse is a representation of the Elasticsearch _cat/plugins endpoint
+type catPluginsResponse []catPluginResponse
+
+// catPluginResponse defines a single plugin installed on a node
+type catPluginResponse struct {
+	Name      string `json:"name"`
+	Component string `json:"component"`
+	Version   string `json:"version"`
+}
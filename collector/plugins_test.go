@@ -0,0 +1,45 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestPlugins(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  docker exec -it CONTAINER bin/elasticsearch-plugin install analysis-icu
+	//  docker exec -it CONTAINER bin/elasticsearch-plugin install repository-s3
+	//  curl http://localhost:9200/_cat/plugins?format=json
+	tcs := map[string]string{
+		"7.10.0": `[{"name":"es01","component":"analysis-icu","version":"7.10.0"},{"name":"es01","component":"repository-s3","version":"7.10.0"},{"name":"es02","component":"analysis-icu","version":"7.9.3"}]`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		p := NewPlugins(log.NewNopLogger(), http.DefaultClient, u)
+		cpr, err := p.fetchAndDecodeCatPlugins()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode cat plugins: %s", err)
+		}
+		t.Logf("[%s] Cat Plugins Response: %+v", ver, cpr)
+		if len(cpr) != 3 {
+			t.Fatalf("Wrong number of plugins")
+		}
+		if plugin := cpr[2]; plugin.Name != "es02" || plugin.Component != "analysis-icu" || plugin.Version != "7.9.3" {
+			t.Errorf("Wrong plugin %+v", plugin)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	defaultRecoveryLabels      = []string{"index", "shard", "primary", "type", "target_node"}
+	defaultRecoveryLabelValues = func(index string, shard recoveryShardResponse) []string {
+		return []string{index, strconv.FormatInt(shard.ID, 10), strconv.FormatBool(shard.Primary), strings.ToLower(shard.Type), shard.Target.Name}
+	}
+
+	recoveryStages = []string{"init", "index", "verify_index", "translog", "finalize", "done"}
+)
+
+type recoveryMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(shard recoveryShardResponse) float64
+}
+
+// Recovery information struct
+type Recovery struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	recoveryMetrics []*recoveryMetric
+	stage           *prometheus.Desc
+	bytesPercent    *prometheus.Desc
+	filesPercent    *prometheus.Desc
+}
+
+func init() {
+	registerCollector("recovery", defaultDisabled, "Export stats for active shard recoveries.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewRecovery(logger, client, url)
+	})
+}
+
+// NewRecovery defines Recovery Prometheus metrics
+func NewRecovery(logger log.Logger, client *http.Client, url *url.URL) *Recovery {
+	subsystem := "recovery"
+
+	return &Recovery{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch recovery endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch recovery scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		recoveryMetrics: []*recoveryMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "bytes_recovered"),
+					"Number of bytes recovered of the shard",
+					defaultRecoveryLabels, nil,
+				),
+				Value: func(shard recoveryShardResponse) float64 {
+					return float64(shard.Index.Size.RecoveredInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "bytes_total"),
+					"Total number of bytes to recover of the shard",
+					defaultRecoveryLabels, nil,
+				),
+				Value: func(shard recoveryShardResponse) float64 {
+					return float64(shard.Index.Size.TotalInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "files_recovered"),
+					"Number of files recovered of the shard",
+					defaultRecoveryLabels, nil,
+				),
+				Value: func(shard recoveryShardResponse) float64 {
+					return float64(shard.Index.Files.Recovered)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "files_total"),
+					"Total number of files to recover of the shard",
+					defaultRecoveryLabels, nil,
+				),
+				Value: func(shard recoveryShardResponse) float64 {
+					return float64(shard.Index.Files.Total)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "bytes_percent"),
+					"Percent of the bytes to copy recovered of the shard, reused bytes are not counted",
+					defaultRecoveryLabels, nil,
+				),
+				Value: func(shard recoveryShardResponse) float64 {
+					return recoveryPercent(shard.Index.Size.RecoveredInBytes, shard.Index.Size.TotalInBytes, shard.Index.Size.ReusedInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "files_percent"),
+					"Percent of the files to copy recovered of the shard, reused files are not counted",
+					defaultRecoveryLabels, nil,
+				),
+				Value: func(shard recoveryShardResponse) float64 {
+					return recoveryPercent(shard.Index.Files.Recovered, shard.Index.Files.Total, shard.Index.Files.Reused)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "translog_ops_recovered"),
+					"Number of translog operations replayed during the shard recovery",
+					defaultRecoveryLabels, nil,
+				),
+				Value: func(shard recoveryShardResponse) float64 {
+					return float64(shard.Translog.Recovered)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "translog_ops_total"),
+					"Total number of translog operations to replay during the shard recovery",
+					defaultRecoveryLabels, nil,
+				),
+				Value: func(shard recoveryShardResponse) float64 {
+					return float64(shard.Translog.Total)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "duration_seconds"),
+					"Time the shard recovery has been running for in seconds",
+					defaultRecoveryLabels, nil,
+				),
+				Value: func(shard recoveryShardResponse) float64 {
+					return float64(shard.TotalTimeInMillis) / 1000
+				},
+			},
+		},
+		stage: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "stage"),
+			"Current stage of the shard recovery",
+			append(defaultRecoveryLabels, "stage"), nil,
+		),
+		bytesPercent: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "overall_bytes_percent"),
+			"Percent of the bytes to copy recovered of all active shard recoveries, reused bytes are not counted",
+			nil, nil,
+		),
+		filesPercent: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "overall_files_percent"),
+			"Percent of the files to copy recovered of all active shard recoveries, reused files are not counted",
+			nil, nil,
+		),
+	}
+}
+
+// Describe add Recovery metrics descriptions
+func (r *Recovery) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range r.recoveryMetrics {
+		ch <- metric.Desc
+	}
+	ch <- r.stage
+	ch <- r.bytesPercent
+	ch <- r.filesPercent
+	ch <- r.up.Desc()
+	ch <- r.totalScrapes.Desc()
+	ch <- r.jsonParseFailures.Desc()
+}
+
+func (r *Recovery) fetchAndDecodeRecovery() (recoveryResponse, error) {
+	var rr recoveryResponse
+
+	u := *r.url
+	u.Path = path.Join(u.Path, "/_recovery")
+	u.RawQuery = "active_only=true"
+
+	res, err := r.client.Get(u.String())
+	if err != nil {
+		return rr, fmt.Errorf("failed to get recovery from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(r.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return rr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&rr); err != nil {
+		r.jsonParseFailures.Inc()
+		return rr, err
+	}
+	return rr, nil
+}
+
+// Collect gets Recovery metric values
+func (r *Recovery) Collect(ch chan<- prometheus.Metric) {
+	r.totalScrapes.Inc()
+	defer func() {
+		ch <- r.up
+		ch <- r.totalScrapes
+		ch <- r.jsonParseFailures
+	}()
+
+	rr, err := r.fetchAndDecodeRecovery()
+	if err != nil {
+		r.up.Set(0)
+		_ = level.Warn(r.logger).Log(
+			"msg", "failed to fetch and decode recovery",
+			"err", err,
+		)
+		return
+	}
+	r.up.Set(1)
+
+	var total recoveryIndexDetails
+	for index, indexRecovery := range rr {
+		for _, shard := range indexRecovery.Shards {
+			total.Size.TotalInBytes += shard.Index.Size.TotalInBytes
+			total.Size.ReusedInBytes += shard.Index.Size.ReusedInBytes
+			total.Size.RecoveredInBytes += shard.Index.Size.RecoveredInBytes
+			total.Files.Total += shard.Index.Files.Total
+			total.Files.Reused += shard.Index.Files.Reused
+			total.Files.Recovered += shard.Index.Files.Recovered
+			for _, metric := range r.recoveryMetrics {
+				ch <- prometheus.MustNewConstMetric(
+					metric.Desc,
+					metric.Type,
+					metric.Value(shard),
+					defaultRecoveryLabelValues(index, shard)...,
+				)
+			}
+			for _, stage := range recoveryStages {
+				var value float64
+				if strings.ToLower(shard.Stage) == stage {
+					value = 1
+				}
+				ch <- prometheus.MustNewConstMetric(
+					r.stage,
+					prometheus.GaugeValue,
+					value,
+					append(defaultRecoveryLabelValues(index, shard), stage)...,
+				)
+			}
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		r.bytesPercent,
+		prometheus.GaugeValue,
+		recoveryPercent(total.Size.RecoveredInBytes, total.Size.TotalInBytes, total.Size.ReusedInBytes),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		r.filesPercent,
+		prometheus.GaugeValue,
+		recoveryPercent(total.Files.Recovered, total.Files.Total, total.Files.Reused),
+	)
+}
@@ -0,0 +1,70 @@
+package collector
+
+// recoveryResponse is a representation of the Elasticsearch _recovery endpoint
+type recoveryResponse map[string]recoveryIndexResponse
+
+// recoveryIndexResponse defines the recoveries of all shards of an index
+type recoveryIndexResponse struct {
+	Shards []recoveryShardResponse `json:"shards"`
+}
+
+// recoveryShardResponse defines the recovery information of a single shard
+type recoveryShardResponse struct {
+	ID                int64                    `json:"id"`
+	Type              string                   `json:"type"`
+	Stage             string                   `json:"stage"`
+	Primary           bool                     `json:"primary"`
+	StartTimeInMillis int64                    `json:"start_time_in_millis"`
+	TotalTimeInMillis int64                    `json:"total_time_in_millis"`
+	Source            recoverySourceResponse   `json:"source"`
+	Target            recoveryNodeResponse     `json:"target"`
+	Index             recoveryIndexDetails     `json:"index"`
+	Translog          recoveryTranslogResponse `json:"translog"`
+}
+
+// recoverySourceResponse defines the source of a recovery, either a node or a snapshot
+type recoverySourceResponse struct {
+	ID         string `json:"id"`
+	Host       string `json:"host"`
+	Name       string `json:"name"`
+	Repository string `json:"repository"`
+	Snapshot   string `json:"snapshot"`
+	Index      string `json:"index"`
+}
+
+// recoveryNodeResponse defines the target node of a recovery
+type recoveryNodeResponse struct {
+	ID   string `json:"id"`
+	Host string `json:"host"`
+	Name string `json:"name"`
+}
+
+// recoveryIndexDetails defines the file and byte progress of a recovery
+type recoveryIndexDetails struct {
+	Size struct {
+		TotalInBytes     int64 `json:"total_in_bytes"`
+		ReusedInBytes    int64 `json:"reused_in_bytes"`
+		RecoveredInBytes int64 `json:"recovered_in_bytes"`
+	} `json:"size"`
+	Files struct {
+		Total     int64 `json:"total"`
+		Reused    int64 `json:"reused"`
+		Recovered int64 `json:"recovered"`
+	} `json:"files"`
+}
+
+// recoveryTranslogResponse defines the translog progress of a recovery
+type recoveryTranslogResponse struct {
+	Recovered    int64 `json:"recovered"`
+	Total        int64 `json:"total"`
+	TotalOnStart int64 `json:"total_on_start"`
+}
+
+// recoveryPercent returns the recovered share of the files or bytes which are not reused in percent,
+// the same way Elasticsearch reports it in _cat/recovery
+func recoveryPercent(recovered, total, reused int64) float64 {
+	if total-reused <= 0 {
+		return 100
+	}
+	return float64(recovered) / float64(total-reused) * 100
+}
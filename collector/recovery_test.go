@@ -0,0 +1,85 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestRecovery(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1 -H "Content-Type: application/json" -d '{"settings":{"number_of_replicas":1}}'
+	//  # start a second node while documents are being indexed into foo_1
+	//  curl http://localhost:9200/_recovery?active_only=true
+	tcs := map[string]string{
+		"5.4.2":  `{}`,
+		"7.10.0": `{"foo_1":{"shards":[{"id":0,"type":"PEER","stage":"INDEX","primary":false,"start_time_in_millis":1610120231047,"total_time_in_millis":12841,"source":{"id":"tMTocMvQQgGCkj7QDHl3OA","host":"172.17.0.2","transport_address":"172.17.0.2:9300","ip":"172.17.0.2","name":"es01"},"target":{"id":"9_P7yui-SN2DLgaw3YHCqA","host":"172.17.0.3","transport_address":"172.17.0.3:9300","ip":"172.17.0.3","name":"es02"},"index":{"size":{"total_in_bytes":104857600,"reused_in_bytes":0,"recovered_in_bytes":52428800,"percent":"50.0%"},"files":{"total":12,"reused":0,"recovered":5,"percent":"41.7%"},"total_time_in_millis":12790,"source_throttle_time_in_millis":0,"target_throttle_time_in_millis":310},"translog":{"recovered":0,"total":1520,"percent":"0.0%","total_on_start":1520,"total_time_in_millis":0},"verify_index":{"check_index_time_in_millis":0,"total_time_in_millis":0}}]}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("active_only") != "true" {
+				t.Errorf("Recovery should only be queried for active recoveries")
+			}
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		r := NewRecovery(log.NewNopLogger(), http.DefaultClient, u)
+		rr, err := r.fetchAndDecodeRecovery()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode recovery: %s", err)
+		}
+		t.Logf("[%s] Recovery Response: %+v", ver, rr)
+		if ver == "5.4.2" && len(rr) != 0 {
+			t.Errorf("There should be no active recoveries")
+		}
+		if ver == "7.10.0" {
+			shards := rr["foo_1"].Shards
+			if len(shards) != 1 {
+				t.Fatalf("Wrong number of recovering shards")
+			}
+			shard := shards[0]
+			if shard.Stage != "INDEX" || shard.Target.Name != "es02" {
+				t.Errorf("Wrong recovery stage or target")
+			}
+			if shard.Index.Size.RecoveredInBytes != 52428800 || shard.Index.Size.TotalInBytes != 104857600 {
+				t.Errorf("Wrong recovered bytes")
+			}
+			if shard.Index.Files.Recovered != 5 || shard.Index.Files.Total != 12 {
+				t.Errorf("Wrong recovered files")
+			}
+			if shard.Translog.Total != 1520 {
+				t.Errorf("Wrong translog operations")
+			}
+			labels := defaultRecoveryLabelValues("foo_1", shard)
+			if labels[1] != "0" || labels[2] != "false" || labels[3] != "peer" || labels[4] != "es02" {
+				t.Errorf("Wrong recovery labels %v", labels)
+			}
+		}
+	}
+}
+
+func TestRecoveryPercent(t *testing.T) {
+	for _, tc := range []struct {
+		recovered, total, reused int64
+		expected                 float64
+	}{
+		{5, 10, 0, 50},
+		{5, 12, 2, 50},
+		{0, 0, 0, 100},
+		{0, 8, 8, 100},
+	} {
+		if got := recoveryPercent(tc.recovered, tc.total, tc.reused); got != tc.expected {
+			t.Errorf("Wrong recovery percent for %+v: %f", tc, got)
+		}
+	}
+}
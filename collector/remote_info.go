@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
+	"strings"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
@@ -13,10 +15,11 @@ import (
 )
 
 // Labels for remote info metrics
-var defaulRemoteInfoLabels = []string{"remote_cluster"}
-var defaultRemoteInfoLabelValues = func(remote_cluster string) []string {
+var defaulRemoteInfoLabels = []string{"remote_cluster", "mode"}
+var defaultRemoteInfoLabelValues = func(remote_cluster string, remoteStats RemoteCluster) []string {
 	return []string{
 		remote_cluster,
+		remoteStats.ConnectionMode(),
 	}
 }
 
@@ -24,7 +27,7 @@ type remoteInfoMetric struct {
 	Type   prometheus.ValueType
 	Desc   *prometheus.Desc
 	Value  func(remoteStats RemoteCluster) float64
-	Labels func(remote_cluster string) []string
+	Labels func(remote_cluster string, remoteStats RemoteCluster) []string
 }
 
 // RemoteInfo information struct
@@ -39,6 +42,12 @@ type RemoteInfo struct {
 	remoteInfoMetrics []*remoteInfoMetric
 }
 
+func init() {
+	registerCollector("remote_info", defaultDisabled, "Export stats associated with configured remote clusters.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewRemoteInfo(logger, client, url)
+	})
+}
+
 // NewClusterSettings defines Cluster Settings Prometheus metrics
 func NewRemoteInfo(logger log.Logger, client *http.Client, url *url.URL) *RemoteInfo {
 
@@ -83,6 +92,103 @@ func NewRemoteInfo(logger log.Logger, client *http.Client, url *url.URL) *Remote
 				},
 				Labels: defaultRemoteInfoLabelValues,
 			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "remote_info", "num_proxy_sockets_connected"),
+					"Number of sockets connected to the proxy of the remote cluster", defaulRemoteInfoLabels, nil,
+				),
+				Value: func(remoteStats RemoteCluster) float64 {
+					return float64(remoteStats.NumProxySocketsConnected)
+				},
+				Labels: defaultRemoteInfoLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "remote_info", "max_proxy_socket_connections"),
+					"Max sockets connected to the proxy of the remote cluster", defaulRemoteInfoLabels, nil,
+				),
+				Value: func(remoteStats RemoteCluster) float64 {
+					return float64(remoteStats.MaxProxySocketConnections)
+				},
+				Labels: defaultRemoteInfoLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "remote_info", "connected"),
+					"Whether the remote cluster is connected", defaulRemoteInfoLabels, nil,
+				),
+				Value: func(remoteStats RemoteCluster) float64 {
+					return bool2Float(remoteStats.Connected)
+				},
+				Labels: defaultRemoteInfoLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "remote_info", "skip_unavailable"),
+					"Whether searches skip the remote cluster if it is unavailable", defaulRemoteInfoLabels, nil,
+				),
+				Value: func(remoteStats RemoteCluster) float64 {
+					return bool2Float(remoteStats.SkipUnavailable)
+				},
+				Labels: defaultRemoteInfoLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "remote_info", "initial_connect_timeout_seconds"),
+					"Timeout for the initial connection to the remote cluster in seconds", defaulRemoteInfoLabels, nil,
+				),
+				Value: func(remoteStats RemoteCluster) float64 {
+					timeout, _ := parseTimeValueSeconds(remoteStats.InitialConnectTimeout)
+					return timeout
+				},
+				Labels: defaultRemoteInfoLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "remote_info", "num_seeds"),
+					"Number of seed nodes configured for the remote cluster", defaulRemoteInfoLabels, nil,
+				),
+				Value: func(remoteStats RemoteCluster) float64 {
+					return float64(len(remoteStats.Seeds))
+				},
+				Labels: defaultRemoteInfoLabelValues,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "remote_info", "config_info"),
+					"Configured seed nodes or proxy address of the remote cluster",
+					append(defaulRemoteInfoLabels, "seeds", "proxy_address"), nil,
+				),
+				Value: func(remoteStats RemoteCluster) float64 {
+					return 1
+				},
+				Labels: func(remote_cluster string, remoteStats RemoteCluster) []string {
+					seeds := append([]string(nil), remoteStats.Seeds...)
+					sort.Strings(seeds)
+					return append(defaultRemoteInfoLabelValues(remote_cluster, remoteStats), strings.Join(seeds, ","), remoteStats.ProxyAddress)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "remote_info", "security_model"),
+					"Whether the connection to the remote cluster is secured with a cross-cluster API key (api_key) or TLS certificates (certificate)",
+					append(defaulRemoteInfoLabels, "security_model"), nil,
+				),
+				Value: func(remoteStats RemoteCluster) float64 {
+					return 1
+				},
+				Labels: func(remote_cluster string, remoteStats RemoteCluster) []string {
+					return append(defaultRemoteInfoLabelValues(remote_cluster, remoteStats), remoteStats.SecurityModel())
+				},
+			},
 		},
 	}
 }
@@ -138,7 +244,6 @@ func (ri *RemoteInfo) Collect(ch chan<- prometheus.Metric) {
 		)
 		return
 	}
-	ri.totalScrapes.Inc()
 	ri.up.Set(1)
 
 	// Remote Info
@@ -148,7 +253,7 @@ func (ri *RemoteInfo) Collect(ch chan<- prometheus.Metric) {
 				metric.Desc,
 				metric.Type,
 				metric.Value(remoteInfo),
-				metric.Labels(remote_cluster)...,
+				metric.Labels(remote_cluster, remoteInfo)...,
 			)
 		}
 	}
@@ -5,10 +5,33 @@ type RemoteInfoResponse map[string]RemoteCluster
 
 // RemoteClsuter defines the struct of the tree for the Remote Cluster
 type RemoteCluster struct {
-	Seeds                    []string `json:"seeds"`
-	Connected                bool     `json:"connected"`
-	NumNodesConnected        int64    `json:"num_nodes_connected"`
-	MaxConnectionsPerCluster int64    `json:"max_connections_per_cluster"`
-	InitialConnectTimeout    string   `json:"initial_connect_timeout"`
-	SkipUnavailable          bool     `json:"skip_unavailable"`
+	Mode                      string   `json:"mode"`
+	Seeds                     []string `json:"seeds"`
+	Connected                 bool     `json:"connected"`
+	NumNodesConnected         int64    `json:"num_nodes_connected"`
+	MaxConnectionsPerCluster  int64    `json:"max_connections_per_cluster"`
+	ProxyAddress              string   `json:"proxy_address"`
+	NumProxySocketsConnected  int64    `json:"num_proxy_sockets_connected"`
+	MaxProxySocketConnections int64    `json:"max_proxy_socket_connections"`
+	InitialConnectTimeout     string   `json:"initial_connect_timeout"`
+	SkipUnavailable           bool     `json:"skip_unavailable"`
+	// ClusterCredentials is only reported (redacted) for remote clusters using the API key security model since 8.10
+	ClusterCredentials string `json:"cluster_credentials"`
+}
+
+// ConnectionMode returns the connection mode of the remote cluster, which is always sniff before 7.6
+func (rc RemoteCluster) ConnectionMode() string {
+	if rc.Mode == "" {
+		return "sniff"
+	}
+	return rc.Mode
+}
+
+// SecurityModel returns how the connection to the remote cluster is secured, either
+// with a cross-cluster API key or with TLS certificates
+func (rc RemoteCluster) SecurityModel() string {
+	if rc.ClusterCredentials != "" {
+		return "api_key"
+	}
+	return "certificate"
 }
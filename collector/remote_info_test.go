@@ -41,3 +41,72 @@ func TestRemoteInfoStats(t *testing.T) {
 		}
 	}
 }
+
+func TestRemoteInfoConnections(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_cluster/settings -H "Content-Type: application/json" -d '{"persistent":{"cluster.remote.sniffed.seeds":["172.17.0.3:9300"],"cluster.remote.sniffed.skip_unavailable":true,"cluster.remote.proxied.mode":"proxy","cluster.remote.proxied.proxy_address":"172.17.0.4:9300"}}'
+	//  curl http://localhost:9200/_remote/info
+	out := `{"sniffed":{"connected":true,"mode":"sniff","seeds":["172.17.0.3:9300"],"num_nodes_connected":1,"max_connections_per_cluster":3,"initial_connect_timeout":"30s","skip_unavailable":true},"proxied":{"connected":false,"mode":"proxy","proxy_address":"172.17.0.4:9300","server_name":"","num_proxy_sockets_connected":0,"max_proxy_socket_connections":18,"initial_connect_timeout":"30s","skip_unavailable":false}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	c := NewRemoteInfo(log.NewNopLogger(), http.DefaultClient, u)
+	rir, err := c.fetchAndDecodeRemoteInfoStats()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode remote info stats: %s", err)
+	}
+
+	sniffed := rir["sniffed"]
+	if sniffed.ConnectionMode() != "sniff" || !sniffed.Connected || !sniffed.SkipUnavailable || sniffed.NumNodesConnected != 1 {
+		t.Errorf("Wrong sniff mode remote cluster %+v", sniffed)
+	}
+	if timeout, err := parseTimeValueSeconds(sniffed.InitialConnectTimeout); err != nil || timeout != 30 {
+		t.Errorf("Wrong initial connect timeout %s", sniffed.InitialConnectTimeout)
+	}
+	proxied := rir["proxied"]
+	if proxied.ConnectionMode() != "proxy" || proxied.Connected || proxied.MaxProxySocketConnections != 18 {
+		t.Errorf("Wrong proxy mode remote cluster %+v", proxied)
+	}
+	if len(sniffed.Seeds) != 1 || sniffed.Seeds[0] != "172.17.0.3:9300" || proxied.ProxyAddress != "172.17.0.4:9300" {
+		t.Errorf("Wrong remote cluster seeds or proxy address")
+	}
+	if (RemoteCluster{}).ConnectionMode() != "sniff" {
+		t.Errorf("Remote clusters before 7.6 should use sniff mode")
+	}
+}
+
+func TestRemoteInfoSecurityModel(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  bin/elasticsearch-keystore add cluster.remote.apikeyed.credentials
+	//  curl -XPUT http://localhost:9200/_cluster/settings -H "Content-Type: application/json" -d '{"persistent":{"cluster.remote.apikeyed.seeds":["172.17.0.3:9443"],"cluster.remote.certified.seeds":["172.17.0.4:9300"]}}'
+	//  curl http://localhost:9200/_remote/info
+	out := `{"apikeyed":{"connected":true,"mode":"sniff","seeds":["172.17.0.3:9443"],"num_nodes_connected":1,"max_connections_per_cluster":3,"initial_connect_timeout":"30s","skip_unavailable":false,"cluster_credentials":"::es_redacted::"},"certified":{"connected":true,"mode":"sniff","seeds":["172.17.0.4:9300"],"num_nodes_connected":1,"max_connections_per_cluster":3,"initial_connect_timeout":"30s","skip_unavailable":false}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	c := NewRemoteInfo(log.NewNopLogger(), http.DefaultClient, u)
+	rir, err := c.fetchAndDecodeRemoteInfoStats()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode remote info stats: %s", err)
+	}
+	if model := rir["apikeyed"].SecurityModel(); model != "api_key" {
+		t.Errorf("Wrong security model for remote cluster apikeyed: %s", model)
+	}
+	if model := rir["certified"].SecurityModel(); model != "certificate" {
+		t.Errorf("Wrong security model for remote cluster certified: %s", model)
+	}
+}
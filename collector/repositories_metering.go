@@ -0,0 +1,140 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RepositoriesMetering information struct
+type RepositoriesMetering struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	requestCount *prometheus.Desc
+}
+
+func init() {
+	registerCollector("repositories_metering", defaultDisabled, "Export blob store request counts of snapshot repositories.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewRepositoriesMetering(logger, client, url)
+	})
+}
+
+// NewRepositoriesMetering defines Repositories Metering Prometheus metrics
+func NewRepositoriesMetering(logger log.Logger, client *http.Client, url *url.URL) *RepositoriesMetering {
+	subsystem := "repositories_metering"
+
+	return &RepositoriesMetering{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch repositories metering endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch repositories metering scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		requestCount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "request_count"),
+			"Number of blob store requests by repository and request type",
+			[]string{"cluster", "node", "repository", "repository_type", "request"}, nil,
+		),
+	}
+}
+
+// Describe add Repositories Metering metrics descriptions
+func (rm *RepositoriesMetering) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rm.requestCount
+	ch <- rm.up.Desc()
+	ch <- rm.totalScrapes.Desc()
+	ch <- rm.jsonParseFailures.Desc()
+}
+
+func (rm *RepositoriesMetering) fetchAndDecodeRepositoriesMetering() (repositoriesMeteringResponse, error) {
+	var rmr repositoriesMeteringResponse
+
+	u := *rm.url
+	u.Path = path.Join(u.Path, "/_nodes/_all/_repositories_metering")
+
+	res, err := rm.client.Get(u.String())
+	if err != nil {
+		return rmr, fmt.Errorf("failed to get repositories metering from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(rm.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return rmr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&rmr); err != nil {
+		rm.jsonParseFailures.Inc()
+		return rmr, err
+	}
+	return rmr, nil
+}
+
+// Collect gets Repositories Metering metric values
+func (rm *RepositoriesMetering) Collect(ch chan<- prometheus.Metric) {
+	rm.totalScrapes.Inc()
+	defer func() {
+		ch <- rm.up
+		ch <- rm.totalScrapes
+		ch <- rm.jsonParseFailures
+	}()
+
+	rmr, err := rm.fetchAndDecodeRepositoriesMetering()
+	if err != nil {
+		rm.up.Set(0)
+		_ = level.Warn(rm.logger).Log(
+			"msg", "failed to fetch and decode repositories metering",
+			"err", err,
+		)
+		return
+	}
+	rm.up.Set(1)
+
+	for node, repositories := range rmr.Nodes {
+		for _, repository := range repositories {
+			// archived entries describe repositories which have been stopped
+			// or replaced and would produce duplicate series
+			if repository.Archived {
+				continue
+			}
+			for request, count := range repository.RequestCounts {
+				ch <- prometheus.MustNewConstMetric(
+					rm.requestCount,
+					prometheus.CounterValue,
+					float64(count),
+					rmr.ClusterName, node, repository.RepositoryName, repository.RepositoryType, request,
+				)
+			}
+		}
+	}
+}
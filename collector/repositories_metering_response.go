@@ -0,0 +1,17 @@
+package collector
+
+// repositoriesMeteringResponse is a representation of the Elasticsearch _nodes/_repositories_metering endpoint
+type repositoriesMeteringResponse struct {
+	ClusterName string                                              `json:"cluster_name"`
+	Nodes       map[string][]repositoriesMeteringRepositoryResponse `json:"nodes"`
+}
+
+// repositoriesMeteringRepositoryResponse defines the blob store request counts of a repository on a node
+type repositoriesMeteringRepositoryResponse struct {
+	RepositoryName        string           `json:"repository_name"`
+	RepositoryType        string           `json:"repository_type"`
+	RepositoryEphemeralID string           `json:"repository_ephemeral_id"`
+	RepositoryStartedAt   int64            `json:"repository_started_at"`
+	Archived              bool             `json:"archived"`
+	RequestCounts         map[string]int64 `json:"request_counts"`
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestRepositoriesMetering(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_snapshot/my_s3 -d '{"type":"s3","settings":{"bucket":"my-bucket"}}'
+	//  curl http://localhost:9200/_nodes/_all/_repositories_metering
+	tcs := map[string]string{
+		"7.16.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":[{"repository_name":"my_s3","repository_type":"s3","repository_location":{"base_path":"","bucket":"my-bucket"},"repository_ephemeral_id":"bbNnMDbcR2S2G0utR0jDGw","repository_started_at":1638453813391,"repository_stopped_at":null,"archived":false,"request_counts":{"GetObject":25,"ListObjects":12,"PutObject":41,"PutMultipartObject":0}},{"repository_name":"my_s3","repository_type":"s3","repository_location":{"base_path":"","bucket":"my-bucket"},"repository_ephemeral_id":"y2de44r4TM6R5p3h4UZbIQ","repository_started_at":1638450000000,"repository_stopped_at":1638453813000,"archived":true,"cluster_version":2,"request_counts":{"GetObject":3}}]}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		rm := NewRepositoriesMetering(log.NewNopLogger(), http.DefaultClient, u)
+		rmr, err := rm.fetchAndDecodeRepositoriesMetering()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode repositories metering: %s", err)
+		}
+		t.Logf("[%s] Repositories Metering Response: %+v", ver, rmr)
+		repositories := rmr.Nodes["Bu9Dc0qkQVmPSbRx9B0KjQ"]
+		if len(repositories) != 2 {
+			t.Fatalf("Wrong number of repositories")
+		}
+		if repositories[0].RepositoryName != "my_s3" || repositories[0].RepositoryType != "s3" {
+			t.Errorf("Wrong repository")
+		}
+		if repositories[0].RequestCounts["PutObject"] != 41 {
+			t.Errorf("Wrong PutObject request count")
+		}
+		if !repositories[1].Archived {
+			t.Errorf("Repository should be archived")
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// repositoryCleanup sums up the cleanup runs of a single repository
+type repositoryCleanup struct {
+	success      bool
+	runs         int64
+	deletedBytes int64
+	deletedBlobs int64
+	ts           time.Time
+}
+
+type repositoryCleanupMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(cleanup repositoryCleanup) float64
+}
+
+// RepositoryCleanup removes unreferenced data from snapshot repositories on demand. Cleanup deletes
+// blobs from the repository, which is why it only runs when triggered through ServeHTTP
+type RepositoryCleanup struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	mutex    sync.RWMutex
+	cleanups map[string]repositoryCleanup
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	metrics []*repositoryCleanupMetric
+}
+
+func init() {
+	registerRenamedCollector("repository_cleanup", "es.snapshots.cleanup", "ES_SNAPSHOTS_CLEANUP", defaultDisabled, "Clean up snapshot repositories when triggered through the /-/snapshot_repository_cleanup endpoint.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewRepositoryCleanup(logger, client, url)
+	})
+}
+
+// NewRepositoryCleanup defines Repository Cleanup Prometheus metrics
+func NewRepositoryCleanup(logger log.Logger, client *http.Client, url *url.URL) *RepositoryCleanup {
+	subsystem := "snapshot_repository_cleanup"
+
+	return &RepositoryCleanup{
+		logger:   logger,
+		client:   client,
+		url:      url,
+		cleanups: make(map[string]repositoryCleanup),
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last triggered ElasticSearch snapshot repository cleanup successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch snapshot repository cleanup triggers.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		metrics: []*repositoryCleanupMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "success"),
+					"Whether the last cleanup of the repository succeeded",
+					defaultSnapshotRepositoryLabels, nil,
+				),
+				Value: func(cleanup repositoryCleanup) float64 {
+					return bool2Float(cleanup.success)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "runs_total"),
+					"Total number of cleanups of the repository triggered through the exporter",
+					defaultSnapshotRepositoryLabels, nil,
+				),
+				Value: func(cleanup repositoryCleanup) float64 {
+					return float64(cleanup.runs)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deleted_bytes_total"),
+					"Total bytes of unreferenced data removed from the repository by cleanups",
+					defaultSnapshotRepositoryLabels, nil,
+				),
+				Value: func(cleanup repositoryCleanup) float64 {
+					return float64(cleanup.deletedBytes)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deleted_blobs_total"),
+					"Total number of unreferenced blobs removed from the repository by cleanups",
+					defaultSnapshotRepositoryLabels, nil,
+				),
+				Value: func(cleanup repositoryCleanup) float64 {
+					return float64(cleanup.deletedBlobs)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "last_run_timestamp_seconds"),
+					"Timestamp of the last cleanup of the repository",
+					defaultSnapshotRepositoryLabels, nil,
+				),
+				Value: func(cleanup repositoryCleanup) float64 {
+					return float64(cleanup.ts.Unix())
+				},
+			},
+		},
+	}
+}
+
+// Describe add Repository Cleanup metrics descriptions
+func (rc *RepositoryCleanup) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range rc.metrics {
+		ch <- metric.Desc
+	}
+	ch <- rc.up.Desc()
+	ch <- rc.totalScrapes.Desc()
+	ch <- rc.jsonParseFailures.Desc()
+}
+
+func (rc *RepositoryCleanup) doAndParse(method string, u *url.URL, data interface{}) error {
+	req, err := http.NewRequest(method, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	res, err := rc.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to %s %s://%s:%s%s: %s",
+			method, u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(rc.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		rc.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+// cleanupRepositories cleans up the given repository, or all snapshot repositories if repository is empty,
+// and adds the results to the stored totals
+func (rc *RepositoryCleanup) cleanupRepositories(repository string) error {
+	rc.totalScrapes.Inc()
+
+	repositories := []string{repository}
+	if repository == "" {
+		u := *rc.url
+		u.Path = path.Join(u.Path, "/_snapshot")
+		var srr SnapshotRepositoriesResponse
+		if err := rc.doAndParse(http.MethodGet, &u, &srr); err != nil {
+			rc.up.Set(0)
+			return err
+		}
+		repositories = repositories[:0]
+		for repository := range srr {
+			repositories = append(repositories, repository)
+		}
+	}
+
+	var failed int
+	for _, repository := range repositories {
+		u := *rc.url
+		u.Path = path.Join(u.Path, "/_snapshot", repository, "/_cleanup")
+		var rcr repositoryCleanupResponse
+		start := time.Now()
+		err := rc.doAndParse(http.MethodPost, &u, &rcr)
+		if err != nil {
+			failed++
+			_ = level.Warn(rc.logger).Log(
+				"msg", "failed to clean up snapshot repository",
+				"repository", repository,
+				"err", err,
+			)
+		}
+
+		rc.mutex.Lock()
+		cleanup := rc.cleanups[repository]
+		cleanup.success = err == nil
+		cleanup.runs++
+		cleanup.deletedBytes += rcr.Results.DeletedBytes
+		cleanup.deletedBlobs += rcr.Results.DeletedBlobs
+		cleanup.ts = start
+		rc.cleanups[repository] = cleanup
+		rc.mutex.Unlock()
+	}
+
+	if failed > 0 {
+		rc.up.Set(0)
+		return fmt.Errorf("failed to clean up %d of %d snapshot repositories", failed, len(repositories))
+	}
+	rc.up.Set(1)
+	return nil
+}
+
+// ServeHTTP triggers a cleanup of the repository given by the repository query parameter, or of all
+// snapshot repositories if it is missing. Only POST requests are accepted
+func (rc *RepositoryCleanup) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	repository := r.URL.Query().Get("repository")
+	// the name becomes part of the request path and must not leave the _snapshot endpoint
+	if strings.Contains(repository, "/") || strings.Contains(repository, "..") {
+		http.Error(w, fmt.Sprintf("invalid repository name %q", repository), http.StatusBadRequest)
+		return
+	}
+	if err := rc.cleanupRepositories(repository); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusOK), http.StatusOK)
+}
+
+// Collect gets Repository Cleanup metric values
+func (rc *RepositoryCleanup) Collect(ch chan<- prometheus.Metric) {
+	ch <- rc.up
+	ch <- rc.totalScrapes
+	ch <- rc.jsonParseFailures
+
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+	for repository, cleanup := range rc.cleanups {
+		for _, metric := range rc.metrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(cleanup),
+				repository,
+			)
+		}
+	}
+}
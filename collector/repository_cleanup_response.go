@@ -0,0 +1,9 @@
+package collector
+
+// repositoryCleanupResponse is a representation of the Elasticsearch _snapshot/<repository>/_cleanup endpoint
+type repositoryCleanupResponse struct {
+	Results struct {
+		DeletedBytes int64 `json:"deleted_bytes"`
+		DeletedBlobs int64 `json:"deleted_blobs"`
+	} `json:"results"`
+}
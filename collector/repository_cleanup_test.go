@@ -0,0 +1,84 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestRepositoryCleanup(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e path.repo=/tmp elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_snapshot/test1 -d '{"type": "fs","settings":{"location": "/tmp/test1"}}'
+	//  curl -XPUT http://localhost:9200/_snapshot/test2 -d '{"type": "fs","settings":{"location": "/tmp/test2"}}'
+	//  chmod 000 /tmp/test2
+	//  curl -XPOST http://localhost:9200/_snapshot/test1/_cleanup
+	tcs := map[string][]string{
+		"7.8.0": {
+			`{"test1":{"type":"fs","settings":{"location":"/tmp/test1"}},"test2":{"type":"fs","settings":{"location":"/tmp/test2"}}}`,
+			`{"results":{"deleted_bytes":20480,"deleted_blobs":5}}`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.RequestURI {
+			case "/_snapshot":
+				fmt.Fprint(w, out[0])
+			case "/_snapshot/test1/_cleanup":
+				if r.Method != http.MethodPost {
+					t.Errorf("Repository cleanup must use POST")
+				}
+				fmt.Fprint(w, out[1])
+			default:
+				http.Error(w, `{"error":{"type":"repository_exception"}}`, http.StatusInternalServerError)
+			}
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		rc := NewRepositoryCleanup(log.NewNopLogger(), http.DefaultClient, u)
+
+		// only POST requests trigger a cleanup
+		w := httptest.NewRecorder()
+		rc.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/-/snapshot_repository_cleanup", nil))
+		if w.Code != http.StatusMethodNotAllowed || len(rc.cleanups) != 0 {
+			t.Errorf("GET requests should not trigger a cleanup")
+		}
+
+		w = httptest.NewRecorder()
+		rc.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/-/snapshot_repository_cleanup", nil))
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("Failed cleanup of repository test2 should be reported")
+		}
+		w = httptest.NewRecorder()
+		rc.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/-/snapshot_repository_cleanup?repository=test1", nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("Cleanup of repository test1 should succeed")
+		}
+		// repository names must not escape the _snapshot endpoint
+		for _, repository := range []string{"..", "../_cluster/settings", "test1%2F_cleanup"} {
+			w = httptest.NewRecorder()
+			rc.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/-/snapshot_repository_cleanup?repository="+repository, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Repository name %s should be rejected", repository)
+			}
+		}
+		t.Logf("[%s] Repository Cleanups: %+v", ver, rc.cleanups)
+		if len(rc.cleanups) != 2 {
+			t.Fatalf("Wrong number of cleaned up repositories")
+		}
+		if c := rc.cleanups["test1"]; !c.success || c.runs != 2 || c.deletedBytes != 40960 || c.deletedBlobs != 10 {
+			t.Errorf("Wrong cleanup totals of repository test1 %+v", c)
+		}
+		if c := rc.cleanups["test2"]; c.success || c.runs != 1 {
+			t.Errorf("Cleanup of repository test2 should fail")
+		}
+	}
+}
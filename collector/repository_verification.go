@@ -0,0 +1,274 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// repositoryVerification is the result of the last verification of a single repository
+type repositoryVerification struct {
+	success  bool
+	duration time.Duration
+	nodes    int
+	ts       time.Time
+}
+
+type repositoryVerificationMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(verification repositoryVerification) float64
+}
+
+// RepositoryVerification periodically verifies all snapshot repositories. Verification
+// writes to the repository, which is why it runs on its own interval instead of on every scrape
+type RepositoryVerification struct {
+	logger   log.Logger
+	client   *http.Client
+	url      *url.URL
+	interval time.Duration
+
+	mutex         sync.RWMutex
+	verifications map[string]repositoryVerification
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	metrics []*repositoryVerificationMetric
+}
+
+var (
+	repositoryVerificationInterval = kingpin.Flag("es.snapshots.verify.interval",
+		"Snapshot repository verification interval").
+		Default("1h").Envar("ES_SNAPSHOTS_VERIFY_INTERVAL").Duration()
+)
+
+func init() {
+	registerRenamedCollector("repository_verification", "es.snapshots.verify", "ES_SNAPSHOTS_VERIFY", defaultDisabled, "Periodically verify all snapshot repositories.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewRepositoryVerification(logger, client, url, *repositoryVerificationInterval)
+	})
+}
+
+// NewRepositoryVerification defines Repository Verification Prometheus metrics
+func NewRepositoryVerification(logger log.Logger, client *http.Client, url *url.URL, interval time.Duration) *RepositoryVerification {
+	subsystem := "snapshot_repository_verify"
+
+	return &RepositoryVerification{
+		logger:        logger,
+		client:        client,
+		url:           url,
+		interval:      interval,
+		verifications: make(map[string]repositoryVerification),
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last listing of the ElasticSearch snapshot repositories successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch snapshot repository verification runs.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		metrics: []*repositoryVerificationMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "success"),
+					"Whether the last verification of the repository succeeded",
+					defaultSnapshotRepositoryLabels, nil,
+				),
+				Value: func(verification repositoryVerification) float64 {
+					if verification.success {
+						return 1
+					}
+					return 0
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "duration_seconds"),
+					"Duration of the last verification of the repository in seconds",
+					defaultSnapshotRepositoryLabels, nil,
+				),
+				Value: func(verification repositoryVerification) float64 {
+					return verification.duration.Seconds()
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "nodes"),
+					"Number of nodes which verified the repository during the last verification",
+					defaultSnapshotRepositoryLabels, nil,
+				),
+				Value: func(verification repositoryVerification) float64 {
+					return float64(verification.nodes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "last_run_timestamp_seconds"),
+					"Timestamp of the last verification of the repository",
+					defaultSnapshotRepositoryLabels, nil,
+				),
+				Value: func(verification repositoryVerification) float64 {
+					return float64(verification.ts.Unix())
+				},
+			},
+		},
+	}
+}
+
+// Describe add Repository Verification metrics descriptions
+func (rv *RepositoryVerification) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range rv.metrics {
+		ch <- metric.Desc
+	}
+	ch <- rv.up.Desc()
+	ch <- rv.totalScrapes.Desc()
+	ch <- rv.jsonParseFailures.Desc()
+}
+
+func (rv *RepositoryVerification) doAndParse(method string, u *url.URL, data interface{}) error {
+	req, err := http.NewRequest(method, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	res, err := rv.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to %s %s://%s:%s%s: %s",
+			method, u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(rv.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		rv.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+// verifyRepositories verifies every snapshot repository once and stores the results
+func (rv *RepositoryVerification) verifyRepositories() error {
+	rv.totalScrapes.Inc()
+
+	u := *rv.url
+	u.Path = path.Join(u.Path, "/_snapshot")
+	var srr SnapshotRepositoriesResponse
+	if err := rv.doAndParse(http.MethodGet, &u, &srr); err != nil {
+		rv.up.Set(0)
+		return err
+	}
+	rv.up.Set(1)
+
+	verifications := make(map[string]repositoryVerification)
+	for repository := range srr {
+		u := *rv.url
+		u.Path = path.Join(u.Path, "/_snapshot", repository, "/_verify")
+		var rvr repositoryVerifyResponse
+		start := time.Now()
+		err := rv.doAndParse(http.MethodPost, &u, &rvr)
+		if err != nil {
+			_ = level.Warn(rv.logger).Log(
+				"msg", "failed to verify snapshot repository",
+				"repository", repository,
+				"err", err,
+			)
+		}
+		verifications[repository] = repositoryVerification{
+			success:  err == nil,
+			duration: time.Since(start),
+			nodes:    len(rvr.Nodes),
+			ts:       start,
+		}
+	}
+
+	rv.mutex.Lock()
+	rv.verifications = verifications
+	rv.mutex.Unlock()
+	return nil
+}
+
+// Run starts the verification loop. The loop is terminated upon ctx cancellation, without a positive interval
+// the repositories are verified once
+func (rv *RepositoryVerification) Run(ctx context.Context) {
+	verify := func() {
+		if err := rv.verifyRepositories(); err != nil {
+			_ = level.Warn(rv.logger).Log(
+				"msg", "failed to list snapshot repositories for verification",
+				"err", err,
+			)
+		}
+	}
+	go func() {
+		verify()
+		if rv.interval <= 0 {
+			_ = level.Info(rv.logger).Log(
+				"msg", "no periodic repository verification requested",
+			)
+			return
+		}
+		ticker := time.NewTicker(rv.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				_ = level.Info(rv.logger).Log(
+					"msg", "context cancelled, exiting repository verification loop",
+					"err", ctx.Err(),
+				)
+				return
+			case <-ticker.C:
+			}
+			verify()
+		}
+	}()
+}
+
+// Collect gets Repository Verification metric values
+func (rv *RepositoryVerification) Collect(ch chan<- prometheus.Metric) {
+	ch <- rv.up
+	ch <- rv.totalScrapes
+	ch <- rv.jsonParseFailures
+
+	rv.mutex.RLock()
+	defer rv.mutex.RUnlock()
+	for repository, verification := range rv.verifications {
+		for _, metric := range rv.metrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(verification),
+				repository,
+			)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package collector
+
+// repositoryVerifyResponse is a representation of the Elasticsearch _snapshot/<repository>/_verify endpoint
+type repositoryVerifyResponse struct {
+	Nodes map[string]struct {
+		Name string `json:"name"`
+	} `json:"nodes"`
+}
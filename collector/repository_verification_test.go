@@ -0,0 +1,62 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestRepositoryVerification(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e path.repo=/tmp elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_snapshot/test1 -d '{"type": "fs","settings":{"location": "/tmp/test1"}}'
+	//  curl -XPUT http://localhost:9200/_snapshot/test2 -d '{"type": "fs","settings":{"location": "/tmp/test2"}}'
+	//  chmod 000 /tmp/test2
+	//  curl -XPOST http://localhost:9200/_snapshot/test1/_verify
+	tcs := map[string][]string{
+		"7.8.0": {
+			`{"test1":{"type":"fs","settings":{"location":"/tmp/test1"}},"test2":{"type":"fs","settings":{"location":"/tmp/test2"}}}`,
+			`{"nodes":{"Bu9Dc0qkQVmPSbRx9B0KjQ":{"name":"8b3c5c2f6a54"}}}`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.RequestURI {
+			case "/_snapshot":
+				fmt.Fprint(w, out[0])
+			case "/_snapshot/test1/_verify":
+				if r.Method != http.MethodPost {
+					t.Errorf("Repository verification must use POST")
+				}
+				fmt.Fprint(w, out[1])
+			default:
+				http.Error(w, `{"error":{"type":"repository_verification_exception"}}`, http.StatusInternalServerError)
+			}
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		rv := NewRepositoryVerification(log.NewNopLogger(), http.DefaultClient, u, time.Hour)
+		if err := rv.verifyRepositories(); err != nil {
+			t.Fatalf("Failed to verify repositories: %s", err)
+		}
+		t.Logf("[%s] Repository Verifications: %+v", ver, rv.verifications)
+		if len(rv.verifications) != 2 {
+			t.Fatalf("Wrong number of verified repositories")
+		}
+		if v := rv.verifications["test1"]; !v.success || v.nodes != 1 {
+			t.Errorf("Verification of repository test1 should succeed")
+		}
+		if rv.verifications["test2"].success {
+			t.Errorf("Verification of repository test2 should fail")
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	defaultRollupJobLabels = []string{"job"}
+
+	rollupJobStates = []string{"started", "indexing", "stopping", "stopped", "aborting"}
+)
+
+type rollupJobMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(job rollupJobResponse) float64
+}
+
+// RollupJobs information struct
+type RollupJobs struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	rollupJobMetrics []*rollupJobMetric
+	state            *prometheus.Desc
+}
+
+func init() {
+	registerCollector("rollup_jobs", defaultDisabled, "Export stats for rollup jobs.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewRollupJobs(logger, client, url)
+	})
+}
+
+// NewRollupJobs defines Rollup Jobs Prometheus metrics
+func NewRollupJobs(logger log.Logger, client *http.Client, url *url.URL) *RollupJobs {
+	subsystem := "rollup_job"
+
+	return &RollupJobs{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch rollup jobs endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch rollup jobs scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		rollupJobMetrics: []*rollupJobMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "documents_processed_total"),
+					"Total number of documents read from the source indices",
+					defaultRollupJobLabels, nil,
+				),
+				Value: func(job rollupJobResponse) float64 {
+					return float64(job.Stats.DocumentsProcessed)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "pages_processed_total"),
+					"Total number of search or bulk index pages processed",
+					defaultRollupJobLabels, nil,
+				),
+				Value: func(job rollupJobResponse) float64 {
+					return float64(job.Stats.PagesProcessed)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "rollups_indexed_total"),
+					"Total number of rollup documents indexed into the rollup index",
+					defaultRollupJobLabels, nil,
+				),
+				Value: func(job rollupJobResponse) float64 {
+					return float64(job.Stats.RollupsIndexed)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "trigger_count_total"),
+					"Total number of times the rollup job has been triggered",
+					defaultRollupJobLabels, nil,
+				),
+				Value: func(job rollupJobResponse) float64 {
+					return float64(job.Stats.TriggerCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "search_failures_total"),
+					"Total number of search failures of the rollup job",
+					defaultRollupJobLabels, nil,
+				),
+				Value: func(job rollupJobResponse) float64 {
+					return float64(job.Stats.SearchFailures)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_failures_total"),
+					"Total number of indexing failures of the rollup job",
+					defaultRollupJobLabels, nil,
+				),
+				Value: func(job rollupJobResponse) float64 {
+					return float64(job.Stats.IndexFailures)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "search_time_seconds_total"),
+					"Total time spent searching the source indices in seconds",
+					defaultRollupJobLabels, nil,
+				),
+				Value: func(job rollupJobResponse) float64 {
+					return float64(job.Stats.SearchTimeInMs) / 1000
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_time_seconds_total"),
+					"Total time spent indexing into the rollup index in seconds",
+					defaultRollupJobLabels, nil,
+				),
+				Value: func(job rollupJobResponse) float64 {
+					return float64(job.Stats.IndexTimeInMs) / 1000
+				},
+			},
+		},
+		state: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "state"),
+			"State of the rollup job",
+			append(defaultRollupJobLabels, "state"), nil,
+		),
+	}
+}
+
+// Describe add Rollup Jobs metrics descriptions
+func (rj *RollupJobs) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range rj.rollupJobMetrics {
+		ch <- metric.Desc
+	}
+	ch <- rj.state
+	ch <- rj.up.Desc()
+	ch <- rj.totalScrapes.Desc()
+	ch <- rj.jsonParseFailures.Desc()
+}
+
+func (rj *RollupJobs) fetchAndDecodeRollupJobs() (rollupJobsResponse, error) {
+	var rjr rollupJobsResponse
+
+	u := *rj.url
+	u.Path = path.Join(u.Path, "/_rollup/job/_all")
+
+	res, err := rj.client.Get(u.String())
+	if err != nil {
+		return rjr, fmt.Errorf("failed to get rollup jobs from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(rj.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return rjr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&rjr); err != nil {
+		rj.jsonParseFailures.Inc()
+		return rjr, err
+	}
+	return rjr, nil
+}
+
+// Collect gets Rollup Jobs metric values
+func (rj *RollupJobs) Collect(ch chan<- prometheus.Metric) {
+	rj.totalScrapes.Inc()
+	defer func() {
+		ch <- rj.up
+		ch <- rj.totalScrapes
+		ch <- rj.jsonParseFailures
+	}()
+
+	rjr, err := rj.fetchAndDecodeRollupJobs()
+	if err != nil {
+		rj.up.Set(0)
+		_ = level.Warn(rj.logger).Log(
+			"msg", "failed to fetch and decode rollup jobs",
+			"err", err,
+		)
+		return
+	}
+	rj.up.Set(1)
+
+	for _, job := range rjr.Jobs {
+		for _, metric := range rj.rollupJobMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(job),
+				job.Config.ID,
+			)
+		}
+		for _, state := range rollupJobStates {
+			var value float64
+			if job.Status.JobState == state {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				rj.state,
+				prometheus.GaugeValue,
+				value,
+				job.Config.ID, state,
+			)
+		}
+	}
+}
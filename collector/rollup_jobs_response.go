@@ -0,0 +1,35 @@
+package collector
+
+// rollupJobsResponse is a representation of the Elasticsearch _rollup/job endpoint
+type rollupJobsResponse struct {
+	Jobs []rollupJobResponse `json:"jobs"`
+}
+
+// rollupJobResponse defines the config, status and stats of a single rollup job
+type rollupJobResponse struct {
+	Config struct {
+		ID           string `json:"id"`
+		IndexPattern string `json:"index_pattern"`
+		RollupIndex  string `json:"rollup_index"`
+	} `json:"config"`
+	Status struct {
+		JobState string `json:"job_state"`
+	} `json:"status"`
+	Stats rollupJobStatsResponse `json:"stats"`
+}
+
+// rollupJobStatsResponse defines the indexer stats of a rollup job
+type rollupJobStatsResponse struct {
+	PagesProcessed     int64 `json:"pages_processed"`
+	DocumentsProcessed int64 `json:"documents_processed"`
+	RollupsIndexed     int64 `json:"rollups_indexed"`
+	TriggerCount       int64 `json:"trigger_count"`
+	IndexTimeInMs      int64 `json:"index_time_in_ms"`
+	IndexTotal         int64 `json:"index_total"`
+	IndexFailures      int64 `json:"index_failures"`
+	SearchTimeInMs     int64 `json:"search_time_in_ms"`
+	SearchTotal        int64 `json:"search_total"`
+	SearchFailures     int64 `json:"search_failures"`
+	ProcessingTimeInMs int64 `json:"processing_time_in_ms"`
+	ProcessingTotal    int64 `json:"processing_total"`
+}
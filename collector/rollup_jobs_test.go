@@ -0,0 +1,52 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestRollupJobs(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_rollup/job/sensor -d '{"index_pattern":"sensor-*","rollup_index":"sensor_rollup","cron":"*/30 * * * * ?","page_size":1000,"groups":{"date_histogram":{"field":"timestamp","fixed_interval":"1h"}},"metrics":[{"field":"temperature","metrics":["min","max"]}]}'
+	//  curl -XPOST http://localhost:9200/_rollup/job/sensor/_start
+	//  curl http://localhost:9200/_rollup/job/_all
+	tcs := map[string]string{
+		"7.10.0": `{"jobs":[{"config":{"id":"sensor","index_pattern":"sensor-*","rollup_index":"sensor_rollup","cron":"*/30 * * * * ?","groups":{"date_histogram":{"fixed_interval":"1h","field":"timestamp","time_zone":"UTC"}},"metrics":[{"field":"temperature","metrics":["min","max"]}],"timeout":"20s","page_size":1000},"status":{"job_state":"started","current_position":{"timestamp.date_histogram":1605729600000},"upgraded_doc_id":true},"stats":{"pages_processed":8,"documents_processed":24000,"rollups_indexed":48,"trigger_count":5,"index_time_in_ms":34,"index_total":4,"index_failures":0,"search_time_in_ms":86,"search_total":8,"search_failures":1,"processing_time_in_ms":5,"processing_total":8}},{"config":{"id":"old","index_pattern":"old-*","rollup_index":"old_rollup"},"status":{"job_state":"stopped","upgraded_doc_id":true},"stats":{"pages_processed":0,"documents_processed":0,"rollups_indexed":0,"trigger_count":0,"index_time_in_ms":0,"index_total":0,"index_failures":0,"search_time_in_ms":0,"search_total":0,"search_failures":0,"processing_time_in_ms":0,"processing_total":0}}]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		rj := NewRollupJobs(log.NewNopLogger(), http.DefaultClient, u)
+		rjr, err := rj.fetchAndDecodeRollupJobs()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode rollup jobs: %s", err)
+		}
+		t.Logf("[%s] Rollup Jobs Response: %+v", ver, rjr)
+		if len(rjr.Jobs) != 2 {
+			t.Fatalf("Wrong number of rollup jobs")
+		}
+		job := rjr.Jobs[0]
+		if job.Config.ID != "sensor" || job.Config.RollupIndex != "sensor_rollup" || job.Status.JobState != "started" {
+			t.Errorf("Wrong rollup job %+v", job)
+		}
+		if job.Stats.DocumentsProcessed != 24000 || job.Stats.PagesProcessed != 8 || job.Stats.RollupsIndexed != 48 || job.Stats.TriggerCount != 5 || job.Stats.SearchFailures != 1 {
+			t.Errorf("Wrong rollup job stats %+v", job.Stats)
+		}
+		if rjr.Jobs[1].Status.JobState != "stopped" {
+			t.Errorf("Rollup job old should be stopped")
+		}
+	}
+}
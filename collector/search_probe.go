@@ -0,0 +1,274 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var defaultSearchProbeLabels = []string{"index"}
+
+// searchProbe is the result of the last probe search against a single index pattern
+type searchProbe struct {
+	success bool
+	shards  int64
+	ts      time.Time
+}
+
+type searchProbeMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(probe searchProbe) float64
+}
+
+// SearchProbe periodically runs a lightweight search against the configured indices and
+// records the time Elasticsearch took to execute it, independent of application traffic
+type SearchProbe struct {
+	logger   log.Logger
+	client   *http.Client
+	url      *url.URL
+	indices  []string
+	query    string
+	interval time.Duration
+
+	mutex  sync.RWMutex
+	probes map[string]searchProbe
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	took    *prometheus.HistogramVec
+	metrics []*searchProbeMetric
+}
+
+var (
+	searchProbeInterval = kingpin.Flag("es.search_probe.interval",
+		"Search probe interval").
+		Default("1m").Envar("ES_SEARCH_PROBE_INTERVAL").Duration()
+	searchProbeIndices = kingpin.Flag("es.search_probe.indices",
+		"Comma-separated list of index patterns the probe search runs against, each pattern is probed separately.").
+		Default("_all").Envar("ES_SEARCH_PROBE_INDICES").String()
+	searchProbeQuery = kingpin.Flag("es.search_probe.query",
+		"Request body of the probe search.").
+		Default(`{"query":{"match_none":{}}}`).Envar("ES_SEARCH_PROBE_QUERY").String()
+)
+
+func init() {
+	registerCollector("search_probe", defaultDisabled, "Periodically run a probe search against the configured indices.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewSearchProbe(logger, client, url, strings.Split(*searchProbeIndices, ","), *searchProbeQuery, *searchProbeInterval)
+	})
+}
+
+// NewSearchProbe defines Search Probe Prometheus metrics
+func NewSearchProbe(logger log.Logger, client *http.Client, url *url.URL, indices []string, query string, interval time.Duration) *SearchProbe {
+	subsystem := "search_probe"
+
+	return &SearchProbe{
+		logger:   logger,
+		client:   client,
+		url:      url,
+		indices:  indices,
+		query:    query,
+		interval: interval,
+		probes:   make(map[string]searchProbe),
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Were all searches of the last ElasticSearch search probe run successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch search probe runs.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		took: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    prometheus.BuildFQName(namespace, subsystem, "took_seconds"),
+			Help:    "Time Elasticsearch took to execute the probe search in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+		}, defaultSearchProbeLabels),
+		metrics: []*searchProbeMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "success"),
+					"Whether the last probe search completed on all shards without timing out",
+					defaultSearchProbeLabels, nil,
+				),
+				Value: func(probe searchProbe) float64 {
+					return bool2Float(probe.success)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "shards"),
+					"Number of shards searched by the last probe search",
+					defaultSearchProbeLabels, nil,
+				),
+				Value: func(probe searchProbe) float64 {
+					return float64(probe.shards)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "last_run_timestamp_seconds"),
+					"Timestamp of the last probe search",
+					defaultSearchProbeLabels, nil,
+				),
+				Value: func(probe searchProbe) float64 {
+					return float64(probe.ts.Unix())
+				},
+			},
+		},
+	}
+}
+
+// Describe add Search Probe metrics descriptions
+func (sp *SearchProbe) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range sp.metrics {
+		ch <- metric.Desc
+	}
+	sp.took.Describe(ch)
+	ch <- sp.up.Desc()
+	ch <- sp.totalScrapes.Desc()
+	ch <- sp.jsonParseFailures.Desc()
+}
+
+func (sp *SearchProbe) search(index string) (searchProbeResponse, error) {
+	var spr searchProbeResponse
+
+	u := *sp.url
+	u.Path = path.Join(u.Path, index, "/_search")
+	// bypass the shard request cache, the probe should measure an actual search
+	u.RawQuery = "request_cache=false"
+
+	req, err := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(sp.query))
+	if err != nil {
+		return spr, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := sp.client.Do(req)
+	if err != nil {
+		return spr, fmt.Errorf("failed to search %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(sp.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return spr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&spr); err != nil {
+		sp.jsonParseFailures.Inc()
+		return spr, err
+	}
+	return spr, nil
+}
+
+// probe runs the probe search once against every configured index pattern and stores the results
+func (sp *SearchProbe) probe() {
+	sp.totalScrapes.Inc()
+
+	up := 1.0
+	probes := make(map[string]searchProbe, len(sp.indices))
+	for _, index := range sp.indices {
+		start := time.Now()
+		spr, err := sp.search(index)
+		if err != nil {
+			_ = level.Warn(sp.logger).Log(
+				"msg", "failed to run probe search",
+				"index", index,
+				"err", err,
+			)
+		} else {
+			sp.took.WithLabelValues(index).Observe(float64(spr.Took) / 1000)
+		}
+		success := err == nil && !spr.TimedOut && spr.Shards.Failed == 0
+		if !success {
+			up = 0
+		}
+		probes[index] = searchProbe{
+			success: success,
+			shards:  spr.Shards.Total,
+			ts:      start,
+		}
+	}
+	sp.up.Set(up)
+
+	sp.mutex.Lock()
+	sp.probes = probes
+	sp.mutex.Unlock()
+}
+
+// Run starts the probe loop. The loop is terminated upon ctx cancellation, without a positive interval
+// the indices are probed once
+func (sp *SearchProbe) Run(ctx context.Context) {
+	go func() {
+		sp.probe()
+		if sp.interval <= 0 {
+			_ = level.Info(sp.logger).Log(
+				"msg", "no periodic search probe requested",
+			)
+			return
+		}
+		ticker := time.NewTicker(sp.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				_ = level.Info(sp.logger).Log(
+					"msg", "context cancelled, exiting search probe loop",
+					"err", ctx.Err(),
+				)
+				return
+			case <-ticker.C:
+			}
+			sp.probe()
+		}
+	}()
+}
+
+// Collect gets Search Probe metric values
+func (sp *SearchProbe) Collect(ch chan<- prometheus.Metric) {
+	ch <- sp.up
+	ch <- sp.totalScrapes
+	ch <- sp.jsonParseFailures
+	sp.took.Collect(ch)
+
+	sp.mutex.RLock()
+	defer sp.mutex.RUnlock()
+	for index, probe := range sp.probes {
+		for _, metric := range sp.metrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(probe),
+				index,
+			)
+		}
+	}
+}
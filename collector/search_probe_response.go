@@ -0,0 +1,13 @@
+package collector
+
+// searchProbeResponse is a representation of the parts of the Elasticsearch _search response used by the search probe
+type searchProbeResponse struct {
+	Took     int64 `json:"took"`
+	TimedOut bool  `json:"timed_out"`
+	Shards   struct {
+		Total      int64 `json:"total"`
+		Successful int64 `json:"successful"`
+		Skipped    int64 `json:"skipped"`
+		Failed     int64 `json:"failed"`
+	} `json:"_shards"`
+}
@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestSearchProbe(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPOST http://localhost:9200/foo_1/_search?request_cache=false -H "Content-Type: application/json" -d '{"query":{"match_none":{}}}'
+	tcs := map[string]string{
+		"7.10.0": `{"took":12,"timed_out":false,"_shards":{"total":5,"successful":5,"skipped":0,"failed":0},"hits":{"total":{"value":0,"relation":"eq"},"max_score":null,"hits":[]}}`,
+	}
+	query := `{"query":{"match_none":{}}}`
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/foo_1/_search":
+				if r.Method != http.MethodPost || r.URL.Query().Get("request_cache") != "false" {
+					t.Errorf("Probe search must be a POST bypassing the request cache")
+				}
+				if body, _ := ioutil.ReadAll(r.Body); string(body) != query {
+					t.Errorf("Wrong probe search body %s", body)
+				}
+				fmt.Fprint(w, out)
+			default:
+				http.Error(w, `{"error":{"type":"index_not_found_exception"}}`, http.StatusNotFound)
+			}
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		sp := NewSearchProbe(log.NewNopLogger(), http.DefaultClient, u, []string{"foo_1", "missing"}, query, time.Minute)
+		sp.probe()
+		t.Logf("[%s] Search Probes: %+v", ver, sp.probes)
+		if len(sp.probes) != 2 {
+			t.Fatalf("Wrong number of probed indices")
+		}
+		if p := sp.probes["foo_1"]; !p.success || p.shards != 5 {
+			t.Errorf("Probe search of foo_1 should succeed")
+		}
+		if sp.probes["missing"].success {
+			t.Errorf("Probe search of missing should fail")
+		}
+	}
+}
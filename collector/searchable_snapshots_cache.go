@@ -0,0 +1,229 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var defaultSearchableSnapshotsCacheLabels = []string{"node"}
+
+type searchableSnapshotsCacheMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(cache searchableSnapshotsSharedCacheResponse) float64
+}
+
+// SearchableSnapshotsCache information struct
+type SearchableSnapshotsCache struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	cacheMetrics []*searchableSnapshotsCacheMetric
+}
+
+func init() {
+	registerCollector("searchable_snapshots_cache", defaultDisabled, "Export stats for the searchable snapshots shared cache.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewSearchableSnapshotsCache(logger, client, url)
+	})
+}
+
+// NewSearchableSnapshotsCache defines Searchable Snapshots Cache Prometheus metrics
+func NewSearchableSnapshotsCache(logger log.Logger, client *http.Client, url *url.URL) *SearchableSnapshotsCache {
+	subsystem := "searchable_snapshots_shared_cache"
+
+	return &SearchableSnapshotsCache{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch searchable snapshots cache endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch searchable snapshots cache scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		cacheMetrics: []*searchableSnapshotsCacheMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "reads_total"),
+					"Total number of reads served by the shared cache",
+					defaultSearchableSnapshotsCacheLabels, nil,
+				),
+				Value: func(cache searchableSnapshotsSharedCacheResponse) float64 {
+					return float64(cache.Reads)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "read_bytes_total"),
+					"Total number of bytes read from the shared cache",
+					defaultSearchableSnapshotsCacheLabels, nil,
+				),
+				Value: func(cache searchableSnapshotsSharedCacheResponse) float64 {
+					return float64(cache.BytesReadInBytes)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "writes_total"),
+					"Total number of writes to the shared cache from cache misses fetched from the blob store",
+					defaultSearchableSnapshotsCacheLabels, nil,
+				),
+				Value: func(cache searchableSnapshotsSharedCacheResponse) float64 {
+					return float64(cache.Writes)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "written_bytes_total"),
+					"Total number of bytes fetched from the blob store and written to the shared cache",
+					defaultSearchableSnapshotsCacheLabels, nil,
+				),
+				Value: func(cache searchableSnapshotsSharedCacheResponse) float64 {
+					return float64(cache.BytesWrittenInBytes)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "evictions_total"),
+					"Total number of regions evicted from the shared cache",
+					defaultSearchableSnapshotsCacheLabels, nil,
+				),
+				Value: func(cache searchableSnapshotsSharedCacheResponse) float64 {
+					return float64(cache.Evictions)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "size_bytes"),
+					"Size of the shared cache in bytes",
+					defaultSearchableSnapshotsCacheLabels, nil,
+				),
+				Value: func(cache searchableSnapshotsSharedCacheResponse) float64 {
+					return float64(cache.SizeInBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "regions"),
+					"Number of regions in the shared cache",
+					defaultSearchableSnapshotsCacheLabels, nil,
+				),
+				Value: func(cache searchableSnapshotsSharedCacheResponse) float64 {
+					return float64(cache.NumRegions)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "region_size_bytes"),
+					"Size of a single region of the shared cache in bytes",
+					defaultSearchableSnapshotsCacheLabels, nil,
+				),
+				Value: func(cache searchableSnapshotsSharedCacheResponse) float64 {
+					return float64(cache.RegionSizeInBytes)
+				},
+			},
+		},
+	}
+}
+
+// Describe add Searchable Snapshots Cache metrics descriptions
+func (s *SearchableSnapshotsCache) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range s.cacheMetrics {
+		ch <- metric.Desc
+	}
+	ch <- s.up.Desc()
+	ch <- s.totalScrapes.Desc()
+	ch <- s.jsonParseFailures.Desc()
+}
+
+func (s *SearchableSnapshotsCache) fetchAndDecodeSearchableSnapshotsCacheStats() (searchableSnapshotsCacheStatsResponse, error) {
+	var sscr searchableSnapshotsCacheStatsResponse
+
+	u := *s.url
+	u.Path = path.Join(u.Path, "/_searchable_snapshots/cache/stats")
+
+	res, err := s.client.Get(u.String())
+	if err != nil {
+		return sscr, fmt.Errorf("failed to get searchable snapshots cache from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(s.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return sscr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&sscr); err != nil {
+		s.jsonParseFailures.Inc()
+		return sscr, err
+	}
+	return sscr, nil
+}
+
+// Collect gets Searchable Snapshots Cache metric values
+func (s *SearchableSnapshotsCache) Collect(ch chan<- prometheus.Metric) {
+	s.totalScrapes.Inc()
+	defer func() {
+		ch <- s.up
+		ch <- s.totalScrapes
+		ch <- s.jsonParseFailures
+	}()
+
+	sscr, err := s.fetchAndDecodeSearchableSnapshotsCacheStats()
+	if err != nil {
+		s.up.Set(0)
+		_ = level.Warn(s.logger).Log(
+			"msg", "failed to fetch and decode searchable snapshots cache",
+			"err", err,
+		)
+		return
+	}
+	s.up.Set(1)
+
+	// the cache stats API only reports node ids
+	for nodeID, node := range sscr.Nodes {
+		for _, metric := range s.cacheMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(node.SharedCache),
+				nodeID,
+			)
+		}
+	}
+}
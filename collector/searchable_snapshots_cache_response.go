@@ -0,0 +1,23 @@
+package collector
+
+// searchableSnapshotsCacheStatsResponse is a representation of the Elasticsearch _searchable_snapshots/cache/stats endpoint
+type searchableSnapshotsCacheStatsResponse struct {
+	Nodes map[string]searchableSnapshotsNodeCacheResponse `json:"nodes"`
+}
+
+// searchableSnapshotsNodeCacheResponse defines the searchable snapshots cache stats of a single node
+type searchableSnapshotsNodeCacheResponse struct {
+	SharedCache searchableSnapshotsSharedCacheResponse `json:"shared_cache"`
+}
+
+// searchableSnapshotsSharedCacheResponse defines the stats of the shared cache used by partially mounted indices
+type searchableSnapshotsSharedCacheResponse struct {
+	Reads               int64 `json:"reads"`
+	BytesReadInBytes    int64 `json:"bytes_read_in_bytes"`
+	Writes              int64 `json:"writes"`
+	BytesWrittenInBytes int64 `json:"bytes_written_in_bytes"`
+	Evictions           int64 `json:"evictions"`
+	NumRegions          int64 `json:"num_regions"`
+	SizeInBytes         int64 `json:"size_in_bytes"`
+	RegionSizeInBytes   int64 `json:"region_size_in_bytes"`
+}
@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestSearchableSnapshotsCache(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e xpack.searchable.snapshot.shared_cache.size=1gb elasticsearch:VERSION
+	//  curl -XPOST http://localhost:9200/_snapshot/repo/snap/_mount?storage=shared_cache -H "Content-Type: application/json" -d '{"index":"foo_1"}'
+	//  curl http://localhost:9200/foo_1/_search
+	//  curl http://localhost:9200/_searchable_snapshots/cache/stats
+	tcs := map[string]string{
+		"7.13.0": `{"nodes":{"eerrtBMtQEisohZzxBLUSw":{"shared_cache":{"reads":6,"bytes_read_in_bytes":5886,"writes":1,"bytes_written_in_bytes":16777216,"evictions":0,"num_regions":64,"size_in_bytes":1073741824,"region_size_in_bytes":16777216}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		s := NewSearchableSnapshotsCache(log.NewNopLogger(), http.DefaultClient, u)
+		sscr, err := s.fetchAndDecodeSearchableSnapshotsCacheStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode searchable snapshots cache stats: %s", err)
+		}
+		t.Logf("[%s] Searchable Snapshots Cache Stats Response: %+v", ver, sscr)
+		cache := sscr.Nodes["eerrtBMtQEisohZzxBLUSw"].SharedCache
+		if cache.Reads != 6 || cache.BytesReadInBytes != 5886 {
+			t.Errorf("Wrong shared cache reads")
+		}
+		if cache.Writes != 1 || cache.BytesWrittenInBytes != 16777216 {
+			t.Errorf("Wrong shared cache writes")
+		}
+		if cache.NumRegions != 64 || cache.SizeInBytes != 1073741824 || cache.RegionSizeInBytes != 16777216 {
+			t.Errorf("Wrong shared cache size")
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var defaultSegmentReplicationLabels = []string{"index", "shard", "target_node"}
+
+type segmentReplicationMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(shard catSegmentReplicationShardResponse) string
+	Scale float64
+}
+
+// parseShardID splits a shard id of the form [index][0] into the index and the shard number
+func parseShardID(shardID string) (index, shard string) {
+	shardID = strings.TrimSuffix(strings.TrimPrefix(shardID, "["), "]")
+	i := strings.LastIndex(shardID, "][")
+	if i < 0 {
+		return shardID, ""
+	}
+	return shardID[:i], shardID[i+2:]
+}
+
+// SegmentReplication information struct
+type SegmentReplication struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	metrics []*segmentReplicationMetric
+}
+
+func init() {
+	registerCollector("segment_replication", defaultDisabled, "Export the segment replication lag of all replicas. Requires OpenSearch.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewSegmentReplication(logger, client, url)
+	})
+}
+
+// NewSegmentReplication defines Segment Replication Prometheus metrics
+func NewSegmentReplication(logger log.Logger, client *http.Client, url *url.URL) *SegmentReplication {
+	subsystem := "segment_replication"
+
+	return &SegmentReplication{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch segment replication endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch segment replication scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		metrics: []*segmentReplicationMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "checkpoints_behind"),
+					"Number of checkpoints the replica is behind the primary",
+					defaultSegmentReplicationLabels, nil,
+				),
+				Value: func(shard catSegmentReplicationShardResponse) string {
+					return shard.CheckpointsBehind
+				},
+				Scale: 1,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "bytes_behind"),
+					"Number of bytes the replica is behind the primary",
+					defaultSegmentReplicationLabels, nil,
+				),
+				Value: func(shard catSegmentReplicationShardResponse) string {
+					return shard.BytesBehind
+				},
+				Scale: 1,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "current_lag_seconds"),
+					"Time the running replication event of the replica has taken so far in seconds",
+					defaultSegmentReplicationLabels, nil,
+				),
+				Value: func(shard catSegmentReplicationShardResponse) string {
+					return shard.CurrentLag
+				},
+				Scale: 0.001,
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "last_completed_lag_seconds"),
+					"Time the last completed replication event of the replica took in seconds",
+					defaultSegmentReplicationLabels, nil,
+				),
+				Value: func(shard catSegmentReplicationShardResponse) string {
+					return shard.LastCompletedLag
+				},
+				Scale: 0.001,
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "rejected_requests_total"),
+					"Total number of replication requests rejected for the replica as it fell too far behind",
+					defaultSegmentReplicationLabels, nil,
+				),
+				Value: func(shard catSegmentReplicationShardResponse) string {
+					return shard.RejectedRequests
+				},
+				Scale: 1,
+			},
+		},
+	}
+}
+
+// Describe add Segment Replication metrics descriptions
+func (sr *SegmentReplication) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range sr.metrics {
+		ch <- metric.Desc
+	}
+	ch <- sr.up.Desc()
+	ch <- sr.totalScrapes.Desc()
+	ch <- sr.jsonParseFailures.Desc()
+}
+
+func (sr *SegmentReplication) fetchAndDecodeSegmentReplication() (catSegmentReplicationResponse, error) {
+	var csr catSegmentReplicationResponse
+
+	u := *sr.url
+	u.Path = path.Join(u.Path, "/_cat/segment_replication")
+	u.RawQuery = "format=json&bytes=b&time=ms"
+
+	res, err := sr.client.Get(u.String())
+	if err != nil {
+		return csr, fmt.Errorf("failed to get segment replication from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(sr.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return csr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&csr); err != nil {
+		sr.jsonParseFailures.Inc()
+		return csr, err
+	}
+	return csr, nil
+}
+
+// Collect gets Segment Replication metric values
+func (sr *SegmentReplication) Collect(ch chan<- prometheus.Metric) {
+	sr.totalScrapes.Inc()
+	defer func() {
+		ch <- sr.up
+		ch <- sr.totalScrapes
+		ch <- sr.jsonParseFailures
+	}()
+
+	csr, err := sr.fetchAndDecodeSegmentReplication()
+	if err != nil {
+		sr.up.Set(0)
+		_ = level.Warn(sr.logger).Log(
+			"msg", "failed to fetch and decode segment replication",
+			"err", err,
+		)
+		return
+	}
+	sr.up.Set(1)
+
+	for _, shard := range csr {
+		index, id := parseShardID(shard.ShardID)
+		for _, metric := range sr.metrics {
+			value, err := strconv.ParseFloat(metric.Value(shard), 64)
+			if err != nil {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				value*metric.Scale,
+				index, id, shard.TargetNode,
+			)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package collector
+
+// catSegmentReplicationResponse is a representation of the OpenSearch _cat/segment_replication endpoint
+type catSegmentReplicationResponse []catSegmentReplicationShardResponse
+
+// catSegmentReplicationShardResponse defines the segment replication status of a single replica
+type catSegmentReplicationShardResponse struct {
+	ShardID           string `json:"shardId"`
+	TargetNode        string `json:"target_node"`
+	TargetHost        string `json:"target_host"`
+	CheckpointsBehind string `json:"checkpoints_behind"`
+	BytesBehind       string `json:"bytes_behind"`
+	CurrentLag        string `json:"current_lag"`
+	LastCompletedLag  string `json:"last_completed_lag"`
+	RejectedRequests  string `json:"rejected_requests"`
+}
@@ -0,0 +1,59 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestSegmentReplication(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e plugins.security.disabled=true opensearchproject/opensearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1 -d '{"settings":{"index.replication.type":"SEGMENT","index.number_of_replicas":1}}'
+	//  curl http://localhost:9200/_cat/segment_replication?format=json&bytes=b&time=ms
+	tcs := map[string]string{
+		"2.7.0": `[{"shardId":"[foo_1][0]","target_node":"opensearch-node2","target_host":"172.18.0.3","checkpoints_behind":"1","bytes_behind":"4803","current_lag":"12","last_completed_lag":"215","rejected_requests":"0"},{"shardId":"[foo_1][1]","target_node":"opensearch-node1","target_host":"172.18.0.2","checkpoints_behind":"0","bytes_behind":"0","current_lag":"0","last_completed_lag":"37","rejected_requests":"2"}]`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		sr := NewSegmentReplication(log.NewNopLogger(), http.DefaultClient, u)
+		csr, err := sr.fetchAndDecodeSegmentReplication()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode segment replication: %s", err)
+		}
+		t.Logf("[%s] Segment Replication Response: %+v", ver, csr)
+		if len(csr) != 2 {
+			t.Fatalf("Wrong number of replicas")
+		}
+		if shard := csr[0]; shard.TargetNode != "opensearch-node2" || shard.BytesBehind != "4803" || shard.CurrentLag != "12" || shard.LastCompletedLag != "215" {
+			t.Errorf("Wrong segment replication %+v", shard)
+		}
+		if csr[1].RejectedRequests != "2" {
+			t.Errorf("Wrong rejected requests %+v", csr[1])
+		}
+	}
+}
+
+func TestParseShardID(t *testing.T) {
+	for shardID, expected := range map[string][2]string{
+		"[foo_1][0]":     {"foo_1", "0"},
+		"[logs-[x]][12]": {"logs-[x]", "12"},
+		"foo_1":          {"foo_1", ""},
+	} {
+		if index, shard := parseShardID(shardID); index != expected[0] || shard != expected[1] {
+			t.Errorf("Wrong index %s or shard %s for shard id %s", index, shard, shardID)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var defaultSegmentLabels = []string{"index", "size_tier"}
+
+// segmentSizeTiers are the upper bounds of the segment size tiers. 5gb is the default
+// maximum size of merged segments, larger segments are only created by force merges
+var segmentSizeTiers = []struct {
+	name       string
+	upperBound float64
+}{
+	{"0-10mb", 10 << 20},
+	{"10mb-100mb", 100 << 20},
+	{"100mb-1gb", 1 << 30},
+	{"1gb-5gb", 5 << 30},
+	{"5gb+", math.Inf(1)},
+}
+
+// segmentSizeTier returns the name of the size tier of a segment
+func segmentSizeTier(size float64) string {
+	for _, tier := range segmentSizeTiers {
+		if size < tier.upperBound {
+			return tier.name
+		}
+	}
+	return segmentSizeTiers[len(segmentSizeTiers)-1].name
+}
+
+// segmentTier sums up the segments of an index within a single size tier
+type segmentTier struct {
+	count       float64
+	docsDeleted float64
+}
+
+// Segments information struct
+type Segments struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	count       *prometheus.Desc
+	docsDeleted *prometheus.Desc
+}
+
+func init() {
+	registerCollector("segments", defaultDisabled, "Export segment counts and deleted documents per index and segment size tier.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewSegments(logger, client, url)
+	})
+}
+
+// NewSegments defines Segments Prometheus metrics
+func NewSegments(logger log.Logger, client *http.Client, url *url.URL) *Segments {
+	subsystem := "segments"
+
+	return &Segments{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch segments endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch segments scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		count: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "count"),
+			"Number of segments of the primary shards of the index within the size tier",
+			defaultSegmentLabels, nil,
+		),
+		docsDeleted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "deleted_docs"),
+			"Number of deleted documents in segments of the primary shards of the index within the size tier",
+			defaultSegmentLabels, nil,
+		),
+	}
+}
+
+// Describe add Segments metrics descriptions
+func (s *Segments) Describe(ch chan<- *prometheus.Desc) {
+	ch <- s.count
+	ch <- s.docsDeleted
+	ch <- s.up.Desc()
+	ch <- s.totalScrapes.Desc()
+	ch <- s.jsonParseFailures.Desc()
+}
+
+func (s *Segments) fetchAndDecodeSegments() (catSegmentsResponse, error) {
+	var csr catSegmentsResponse
+
+	u := *s.url
+	u.Path = path.Join(u.Path, "/_cat/segments")
+	u.RawQuery = "format=json&bytes=b&h=index,shard,prirep,segment,docs.count,docs.deleted,size"
+
+	res, err := s.client.Get(u.String())
+	if err != nil {
+		return csr, fmt.Errorf("failed to get segments from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(s.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return csr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&csr); err != nil {
+		s.jsonParseFailures.Inc()
+		return csr, err
+	}
+	return csr, nil
+}
+
+// Collect gets Segments metric values
+func (s *Segments) Collect(ch chan<- prometheus.Metric) {
+	s.totalScrapes.Inc()
+	defer func() {
+		ch <- s.up
+		ch <- s.totalScrapes
+		ch <- s.jsonParseFailures
+	}()
+
+	csr, err := s.fetchAndDecodeSegments()
+	if err != nil {
+		s.up.Set(0)
+		_ = level.Warn(s.logger).Log(
+			"msg", "failed to fetch and decode segments",
+			"err", err,
+		)
+		return
+	}
+	s.up.Set(1)
+
+	// replicas have the same documents, only the segments of primaries are summed up
+	indices := make(map[string]map[string]*segmentTier)
+	for _, segment := range csr {
+		if segment.Prirep != "p" {
+			continue
+		}
+		size, err := strconv.ParseFloat(segment.Size, 64)
+		if err != nil {
+			continue
+		}
+		tiers, ok := indices[segment.Index]
+		if !ok {
+			tiers = make(map[string]*segmentTier, len(segmentSizeTiers))
+			for _, tier := range segmentSizeTiers {
+				tiers[tier.name] = &segmentTier{}
+			}
+			indices[segment.Index] = tiers
+		}
+		tier := tiers[segmentSizeTier(size)]
+		tier.count++
+		if deleted, err := strconv.ParseFloat(segment.DocsDeleted, 64); err == nil {
+			tier.docsDeleted += deleted
+		}
+	}
+
+	for index, tiers := range indices {
+		for name, tier := range tiers {
+			ch <- prometheus.MustNewConstMetric(
+				s.count,
+				prometheus.GaugeValue,
+				tier.count,
+				index, name,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				s.docsDeleted,
+				prometheus.GaugeValue,
+				tier.docsDeleted,
+				index, name,
+			)
+		}
+	}
+}
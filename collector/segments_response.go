@@ -0,0 +1,15 @@
+package collector
+
+// catSegmentsResponse is a representation of the Elasticsearch _cat/segments endpoint
+type catSegmentsResponse []catSegmentResponse
+
+// catSegmentResponse defines a single segment of a shard copy of the _cat/segments endpoint
+type catSegmentResponse struct {
+	Index       string `json:"index"`
+	Shard       string `json:"shard"`
+	Prirep      string `json:"prirep"`
+	Segment     string `json:"segment"`
+	DocsCount   string `json:"docs.count"`
+	DocsDeleted string `json:"docs.deleted"`
+	Size        string `json:"size"`
+}
@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestSegments(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_cat/segments?format=json&bytes=b&h=index,shard,prirep,segment,docs.count,docs.deleted,size
+	tcs := map[string]string{
+		"7.10.0": `[{"index":"foo_1","shard":"0","prirep":"p","segment":"_0","docs.count":"120000","docs.deleted":"30000","size":"6442450944"},{"index":"foo_1","shard":"0","prirep":"p","segment":"_1","docs.count":"100","docs.deleted":"2","size":"5120"},{"index":"foo_1","shard":"0","prirep":"p","segment":"_2","docs.count":"50","docs.deleted":"0","size":"2048"},{"index":"foo_1","shard":"0","prirep":"r","segment":"_1","docs.count":"100","docs.deleted":"2","size":"5120"}]`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewSegments(log.NewNopLogger(), http.DefaultClient, u)
+		csr, err := c.fetchAndDecodeSegments()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode segments: %s", err)
+		}
+		t.Logf("[%s] Segments Response: %+v", ver, csr)
+		if len(csr) != 4 {
+			t.Fatalf("Wrong number of segments")
+		}
+		if csr[0].DocsDeleted != "30000" || csr[3].Prirep != "r" {
+			t.Errorf("Wrong segment %+v", csr[0])
+		}
+	}
+}
+
+func TestSegmentSizeTier(t *testing.T) {
+	for size, tier := range map[float64]string{
+		0:        "0-10mb",
+		5120:     "0-10mb",
+		10 << 20: "10mb-100mb",
+		1 << 30:  "1gb-5gb",
+		5 << 30:  "5gb+",
+	} {
+		if got := segmentSizeTier(size); got != tier {
+			t.Errorf("Wrong size tier for %f: expected %s, got %s", size, tier, got)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ShardStores information struct
+type ShardStores struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	unhealthyShards *prometheus.Desc
+	storeExceptions *prometheus.Desc
+}
+
+func init() {
+	registerCollector("shard_stores", defaultDisabled, "Export store exceptions of unhealthy shards.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewShardStores(logger, client, url)
+	})
+}
+
+// NewShardStores defines Shard Stores Prometheus metrics
+func NewShardStores(logger log.Logger, client *http.Client, url *url.URL) *ShardStores {
+	subsystem := "shard_stores"
+
+	return &ShardStores{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch shard stores endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch shard stores scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		unhealthyShards: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "unhealthy_shards"),
+			"Number of shards of the index which are not fully allocated",
+			[]string{"index"}, nil,
+		),
+		storeExceptions: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "store_exception_shards"),
+			"Number of shards of the index with at least one copy failing to open its store, for example due to corruption",
+			[]string{"index"}, nil,
+		),
+	}
+}
+
+// Describe add Shard Stores metrics descriptions
+func (s *ShardStores) Describe(ch chan<- *prometheus.Desc) {
+	ch <- s.unhealthyShards
+	ch <- s.storeExceptions
+	ch <- s.up.Desc()
+	ch <- s.totalScrapes.Desc()
+	ch <- s.jsonParseFailures.Desc()
+}
+
+func (s *ShardStores) fetchAndDecodeShardStores() (shardStoresResponse, error) {
+	var ssr shardStoresResponse
+
+	u := *s.url
+	u.Path = path.Join(u.Path, "/_shard_stores")
+	u.RawQuery = "status=red,yellow"
+
+	res, err := s.client.Get(u.String())
+	if err != nil {
+		return ssr, fmt.Errorf("failed to get shard stores from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(s.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return ssr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&ssr); err != nil {
+		s.jsonParseFailures.Inc()
+		return ssr, err
+	}
+	return ssr, nil
+}
+
+// Collect gets Shard Stores metric values
+func (s *ShardStores) Collect(ch chan<- prometheus.Metric) {
+	s.totalScrapes.Inc()
+	defer func() {
+		ch <- s.up
+		ch <- s.totalScrapes
+		ch <- s.jsonParseFailures
+	}()
+
+	ssr, err := s.fetchAndDecodeShardStores()
+	if err != nil {
+		s.up.Set(0)
+		_ = level.Warn(s.logger).Log(
+			"msg", "failed to fetch and decode shard stores",
+			"err", err,
+		)
+		return
+	}
+	s.up.Set(1)
+
+	for index, indexStores := range ssr.Indices {
+		var storeExceptions int
+		for _, shard := range indexStores.Shards {
+			for _, store := range shard.Stores {
+				if store.StoreException != nil {
+					storeExceptions++
+					break
+				}
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(
+			s.unhealthyShards,
+			prometheus.GaugeValue,
+			float64(len(indexStores.Shards)),
+			index,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			s.storeExceptions,
+			prometheus.GaugeValue,
+			float64(storeExceptions),
+			index,
+		)
+	}
+}
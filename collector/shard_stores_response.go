@@ -0,0 +1,29 @@
+package collector
+
+// shardStoresResponse is a representation of the Elasticsearch _shard_stores endpoint
+type shardStoresResponse struct {
+	Indices map[string]shardStoresIndexResponse `json:"indices"`
+}
+
+// shardStoresIndexResponse defines the store information of the shards of an index, keyed by shard number
+type shardStoresIndexResponse struct {
+	Shards map[string]shardStoresShardResponse `json:"shards"`
+}
+
+// shardStoresShardResponse defines the copies of a single shard
+type shardStoresShardResponse struct {
+	Stores []shardStoreResponse `json:"stores"`
+}
+
+// shardStoreResponse defines a single copy of a shard. The node holding the copy is keyed by its id and not decoded.
+type shardStoreResponse struct {
+	AllocationID   string                       `json:"allocation_id"`
+	Allocation     string                       `json:"allocation"`
+	StoreException *shardStoreExceptionResponse `json:"store_exception"`
+}
+
+// shardStoreExceptionResponse defines the error encountered while opening a shard copy
+type shardStoreExceptionResponse struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
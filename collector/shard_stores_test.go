@@ -0,0 +1,59 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestShardStores(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1 -H "Content-Type: application/json" -d '{"settings":{"number_of_shards":2,"number_of_replicas":1}}'
+	//  # corrupt a segment file of shard 0 of foo_1 on disk
+	//  curl http://localhost:9200/_shard_stores?status=red,yellow
+	tcs := map[string]string{
+		"5.4.2":  `{"indices":{}}`,
+		"7.10.0": `{"indices":{"foo_1":{"shards":{"0":{"stores":[{"tMTocMvQQgGCkj7QDHl3OA":{"name":"es01","ephemeral_id":"9Ox-MToRTjutJx9Nrmt8Rw","transport_address":"172.17.0.2:9300","attributes":{}},"allocation_id":"gVHMSUC_RmuKAMO0aJ_4bw","allocation":"primary","store_exception":{"type":"corrupt_index_exception","reason":"failed engine (reason: [corrupt file (source: [index])]) (resource=preexisting_corruption)"}}]},"1":{"stores":[{"tMTocMvQQgGCkj7QDHl3OA":{"name":"es01","ephemeral_id":"9Ox-MToRTjutJx9Nrmt8Rw","transport_address":"172.17.0.2:9300","attributes":{}},"allocation_id":"2iNySv_OQVePRX-yaRH_ng","allocation":"primary"}]}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("status") != "red,yellow" {
+				t.Errorf("Shard stores should only be queried for red and yellow shards")
+			}
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		s := NewShardStores(log.NewNopLogger(), http.DefaultClient, u)
+		ssr, err := s.fetchAndDecodeShardStores()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode shard stores: %s", err)
+		}
+		t.Logf("[%s] Shard Stores Response: %+v", ver, ssr)
+		if ver == "5.4.2" && len(ssr.Indices) != 0 {
+			t.Errorf("There should be no unhealthy shards")
+		}
+		if ver == "7.10.0" {
+			shards := ssr.Indices["foo_1"].Shards
+			if len(shards) != 2 {
+				t.Fatalf("Wrong number of unhealthy shards")
+			}
+			exception := shards["0"].Stores[0].StoreException
+			if exception == nil || exception.Type != "corrupt_index_exception" {
+				t.Errorf("Shard 0 should have a store exception")
+			}
+			if shards["1"].Stores[0].StoreException != nil || shards["1"].Stores[0].Allocation != "primary" {
+				t.Errorf("Shard 1 should not have a store exception")
+			}
+		}
+	}
+}
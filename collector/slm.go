@@ -0,0 +1,391 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	defaultSLMPolicyLabels = []string{"policy"}
+)
+
+type slmMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(slmStats slmStatsResponse) float64
+}
+
+type slmPolicyStatsMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(policyStats slmPolicyStatsResponse) float64
+}
+
+type slmPolicyMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(policy slmPolicyInfoResponse, now time.Time) float64
+}
+
+// SLM information struct
+type SLM struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	slmMetrics            []*slmMetric
+	slmPolicyStatsMetrics []*slmPolicyStatsMetric
+	slmPolicyMetrics      []*slmPolicyMetric
+}
+
+func init() {
+	registerCollector("slm", defaultDisabled, "Export stats for snapshot lifecycle management.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewSLM(logger, client, url)
+	})
+}
+
+// NewSLM defines SLM Prometheus metrics
+func NewSLM(logger log.Logger, client *http.Client, url *url.URL) *SLM {
+	subsystem := "slm_stats"
+
+	return &SLM{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch SLM endpoints successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch SLM scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		slmMetrics: []*slmMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "retention_runs_total"),
+					"Total retention runs",
+					nil, nil,
+				),
+				Value: func(slmStats slmStatsResponse) float64 {
+					return float64(slmStats.RetentionRuns)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "retention_failed_total"),
+					"Total failed retention runs",
+					nil, nil,
+				),
+				Value: func(slmStats slmStatsResponse) float64 {
+					return float64(slmStats.RetentionFailed)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "retention_timed_out_total"),
+					"Total timed out retention runs",
+					nil, nil,
+				),
+				Value: func(slmStats slmStatsResponse) float64 {
+					return float64(slmStats.RetentionTimedOut)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "retention_deletion_time_seconds"),
+					"Retention run deletion time",
+					nil, nil,
+				),
+				Value: func(slmStats slmStatsResponse) float64 {
+					return float64(slmStats.RetentionDeletionTimeMillis) / 1000
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "total_snapshots_taken_total"),
+					"Total snapshots taken",
+					nil, nil,
+				),
+				Value: func(slmStats slmStatsResponse) float64 {
+					return float64(slmStats.TotalSnapshotsTaken)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "total_snapshots_failed_total"),
+					"Total snapshots failed",
+					nil, nil,
+				),
+				Value: func(slmStats slmStatsResponse) float64 {
+					return float64(slmStats.TotalSnapshotsFailed)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "total_snapshots_deleted_total"),
+					"Total snapshots deleted",
+					nil, nil,
+				),
+				Value: func(slmStats slmStatsResponse) float64 {
+					return float64(slmStats.TotalSnapshotsDeleted)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "total_snapshot_deletion_failures_total"),
+					"Total snapshot deletion failures",
+					nil, nil,
+				),
+				Value: func(slmStats slmStatsResponse) float64 {
+					return float64(slmStats.TotalSnapshotDeletionFailures)
+				},
+			},
+		},
+		slmPolicyStatsMetrics: []*slmPolicyStatsMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "snapshots_taken_total"),
+					"Total snapshots taken by policy",
+					defaultSLMPolicyLabels, nil,
+				),
+				Value: func(policyStats slmPolicyStatsResponse) float64 {
+					return float64(policyStats.SnapshotsTaken)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "snapshots_failed_total"),
+					"Total snapshots failed by policy",
+					defaultSLMPolicyLabels, nil,
+				),
+				Value: func(policyStats slmPolicyStatsResponse) float64 {
+					return float64(policyStats.SnapshotsFailed)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "snapshots_deleted_total"),
+					"Total snapshots deleted by policy",
+					defaultSLMPolicyLabels, nil,
+				),
+				Value: func(policyStats slmPolicyStatsResponse) float64 {
+					return float64(policyStats.SnapshotsDeleted)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "snapshot_deletion_failures_total"),
+					"Total snapshot deletion failures by policy",
+					defaultSLMPolicyLabels, nil,
+				),
+				Value: func(policyStats slmPolicyStatsResponse) float64 {
+					return float64(policyStats.SnapshotDeletionFailures)
+				},
+			},
+		},
+		slmPolicyMetrics: []*slmPolicyMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "last_success_timestamp_seconds"),
+					"Timestamp of the last successful snapshot of the policy",
+					defaultSLMPolicyLabels, nil,
+				),
+				Value: func(policy slmPolicyInfoResponse, now time.Time) float64 {
+					return float64(policy.LastSuccess.Time / 1000)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "last_failure_timestamp_seconds"),
+					"Timestamp of the last failed snapshot of the policy",
+					defaultSLMPolicyLabels, nil,
+				),
+				Value: func(policy slmPolicyInfoResponse, now time.Time) float64 {
+					return float64(policy.LastFailure.Time / 1000)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "seconds_since_last_success"),
+					"Time since the last successful snapshot of the policy in seconds",
+					defaultSLMPolicyLabels, nil,
+				),
+				Value: func(policy slmPolicyInfoResponse, now time.Time) float64 {
+					// a policy without successful snapshot has to trigger alerts on this metric
+					if policy.LastSuccess.Time == 0 {
+						return math.Inf(1)
+					}
+					return millisSince(now, policy.LastSuccess.Time)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "next_execution_timestamp_seconds"),
+					"Timestamp of the next scheduled execution of the policy",
+					defaultSLMPolicyLabels, nil,
+				),
+				Value: func(policy slmPolicyInfoResponse, now time.Time) float64 {
+					return float64(policy.NextExecutionMillis / 1000)
+				},
+			},
+		},
+	}
+}
+
+// Describe add SLM metrics descriptions
+func (s *SLM) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range s.slmMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range s.slmPolicyStatsMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range s.slmPolicyMetrics {
+		ch <- metric.Desc
+	}
+	ch <- s.up.Desc()
+	ch <- s.totalScrapes.Desc()
+	ch <- s.jsonParseFailures.Desc()
+}
+
+func (s *SLM) getAndParseURL(u *url.URL, data interface{}) error {
+	res, err := s.client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(s.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		s.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+func (s *SLM) fetchAndDecodeSLMStats() (slmStatsResponse, error) {
+	var ssr slmStatsResponse
+
+	u := *s.url
+	u.Path = path.Join(u.Path, "/_slm/stats")
+	err := s.getAndParseURL(&u, &ssr)
+	return ssr, err
+}
+
+func (s *SLM) fetchAndDecodeSLMPolicies() (slmPolicyResponse, error) {
+	var spr slmPolicyResponse
+
+	u := *s.url
+	u.Path = path.Join(u.Path, "/_slm/policy")
+	err := s.getAndParseURL(&u, &spr)
+	return spr, err
+}
+
+// Collect gets SLM metric values
+func (s *SLM) Collect(ch chan<- prometheus.Metric) {
+	s.totalScrapes.Inc()
+	defer func() {
+		ch <- s.up
+		ch <- s.totalScrapes
+		ch <- s.jsonParseFailures
+	}()
+
+	slmStatsResp, err := s.fetchAndDecodeSLMStats()
+	if err != nil {
+		s.up.Set(0)
+		_ = level.Warn(s.logger).Log(
+			"msg", "failed to fetch and decode SLM stats",
+			"err", err,
+		)
+		return
+	}
+
+	slmPolicyResp, err := s.fetchAndDecodeSLMPolicies()
+	if err != nil {
+		s.up.Set(0)
+		_ = level.Warn(s.logger).Log(
+			"msg", "failed to fetch and decode SLM policies",
+			"err", err,
+		)
+		return
+	}
+	s.up.Set(1)
+
+	for _, metric := range s.slmMetrics {
+		ch <- prometheus.MustNewConstMetric(
+			metric.Desc,
+			metric.Type,
+			metric.Value(slmStatsResp),
+		)
+	}
+
+	for _, policyStats := range slmStatsResp.PolicyStats {
+		for _, metric := range s.slmPolicyStatsMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(policyStats),
+				policyStats.Policy,
+			)
+		}
+	}
+
+	now := time.Now()
+	for policyID, policy := range slmPolicyResp {
+		for _, metric := range s.slmPolicyMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(policy, now),
+				policyID,
+			)
+		}
+	}
+}
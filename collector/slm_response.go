@@ -0,0 +1,49 @@
+package collector
+
+// slmStatsResponse is a representation of the Elasticsearch _slm/stats endpoint
+type slmStatsResponse struct {
+	RetentionRuns                 int64                    `json:"retention_runs"`
+	RetentionFailed               int64                    `json:"retention_failed"`
+	RetentionTimedOut             int64                    `json:"retention_timed_out"`
+	RetentionDeletionTimeMillis   int64                    `json:"retention_deletion_time_millis"`
+	TotalSnapshotsTaken           int64                    `json:"total_snapshots_taken"`
+	TotalSnapshotsFailed          int64                    `json:"total_snapshots_failed"`
+	TotalSnapshotsDeleted         int64                    `json:"total_snapshots_deleted"`
+	TotalSnapshotDeletionFailures int64                    `json:"total_snapshot_deletion_failures"`
+	PolicyStats                   []slmPolicyStatsResponse `json:"policy_stats"`
+}
+
+// slmPolicyStatsResponse defines the SLM stats of a single policy
+type slmPolicyStatsResponse struct {
+	Policy                   string `json:"policy"`
+	SnapshotsTaken           int64  `json:"snapshots_taken"`
+	SnapshotsFailed          int64  `json:"snapshots_failed"`
+	SnapshotsDeleted         int64  `json:"snapshots_deleted"`
+	SnapshotDeletionFailures int64  `json:"snapshot_deletion_failures"`
+}
+
+// slmPolicyResponse is a representation of the Elasticsearch _slm/policy endpoint
+type slmPolicyResponse map[string]slmPolicyInfoResponse
+
+// slmPolicyInfoResponse defines the definition and execution state of a single SLM policy
+type slmPolicyInfoResponse struct {
+	Version             int64                       `json:"version"`
+	ModifiedDateMillis  int64                       `json:"modified_date_millis"`
+	Policy              slmPolicyDefinitionResponse `json:"policy"`
+	LastSuccess         slmPolicyExecutionResponse  `json:"last_success"`
+	LastFailure         slmPolicyExecutionResponse  `json:"last_failure"`
+	NextExecutionMillis int64                       `json:"next_execution_millis"`
+}
+
+// slmPolicyDefinitionResponse defines the configuration of a SLM policy
+type slmPolicyDefinitionResponse struct {
+	Name       string `json:"name"`
+	Schedule   string `json:"schedule"`
+	Repository string `json:"repository"`
+}
+
+// slmPolicyExecutionResponse defines a single SLM policy execution
+type slmPolicyExecutionResponse struct {
+	SnapshotName string `json:"snapshot_name"`
+	Time         int64  `json:"time"`
+}
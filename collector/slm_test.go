@@ -0,0 +1,92 @@
+package collector
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestSLM(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e path.repo=/tmp elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_snapshot/my_repository -d '{"type":"fs","settings":{"location":"/tmp/my_repository"}}'
+	//  curl -XPUT http://localhost:9200/_slm/policy/nightly-snapshots -d '{"schedule":"0 30 1 * * ?","name":"<nightly-snap-{now/d}>","repository":"my_repository"}'
+	//  curl -XPOST http://localhost:9200/_slm/policy/nightly-snapshots/_execute
+	//  curl -XPUT http://localhost:9200/_slm/policy/weekly-snapshots -d '{"schedule":"0 30 2 ? * SUN","name":"<weekly-snap-{now/d}>","repository":"my_repository"}'
+	//  curl http://localhost:9200/_slm/stats
+	//  curl http://localhost:9200/_slm/policy
+	tcs := map[string][]string{
+		"7.8.0": {
+			`{"retention_runs":2,"retention_failed":0,"retention_timed_out":0,"retention_deletion_time":"1.4s","retention_deletion_time_millis":1404,"total_snapshots_taken":3,"total_snapshots_failed":1,"total_snapshots_deleted":1,"total_snapshot_deletion_failures":0,"policy_stats":[{"policy":"nightly-snapshots","snapshots_taken":3,"snapshots_failed":1,"snapshots_deleted":1,"snapshot_deletion_failures":0}]}`,
+			`{"nightly-snapshots":{"version":1,"modified_date_millis":1594635724850,"policy":{"name":"<nightly-snap-{now/d}>","schedule":"0 30 1 * * ?","repository":"my_repository"},"last_success":{"snapshot_name":"nightly-snap-2020.07.13-msdgrdj-qtwcs_uwhdrpgg","time":1594635737887},"last_failure":{"snapshot_name":"nightly-snap-2020.07.12-dkjfnbwislmaklsd","time":1594549337887,"details":"{\"type\":\"snapshot_exception\"}"},"next_execution_millis":1594690200000,"stats":{"policy":"nightly-snapshots","snapshots_taken":3,"snapshots_failed":1,"snapshots_deleted":1,"snapshot_deletion_failures":0}},"weekly-snapshots":{"version":1,"modified_date_millis":1594636017192,"policy":{"name":"<weekly-snap-{now/d}>","schedule":"0 30 2 ? * SUN","repository":"my_repository"},"next_execution_millis":1594866600000,"stats":{"policy":"weekly-snapshots","snapshots_taken":0,"snapshots_failed":0,"snapshots_deleted":0,"snapshot_deletion_failures":0}}}`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.RequestURI == "/_slm/stats" {
+				fmt.Fprint(w, out[0])
+				return
+			}
+			fmt.Fprint(w, out[1])
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		s := NewSLM(log.NewNopLogger(), http.DefaultClient, u)
+		ssr, err := s.fetchAndDecodeSLMStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode SLM stats: %s", err)
+		}
+		t.Logf("[%s] SLM Stats Response: %+v", ver, ssr)
+		if ssr.TotalSnapshotsTaken != 3 || ssr.RetentionDeletionTimeMillis != 1404 {
+			t.Errorf("Wrong SLM stats")
+		}
+		if len(ssr.PolicyStats) != 1 || ssr.PolicyStats[0].SnapshotsFailed != 1 {
+			t.Errorf("Wrong SLM policy stats")
+		}
+
+		spr, err := s.fetchAndDecodeSLMPolicies()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode SLM policies: %s", err)
+		}
+		t.Logf("[%s] SLM Policy Response: %+v", ver, spr)
+		policy, ok := spr["nightly-snapshots"]
+		if !ok {
+			t.Fatalf("Missing SLM policy nightly-snapshots")
+		}
+		if policy.LastSuccess.Time != 1594635737887 {
+			t.Errorf("Wrong last success time")
+		}
+		if policy.Policy.Repository != "my_repository" {
+			t.Errorf("Wrong SLM policy repository")
+		}
+
+		// a policy which never succeeded is infinitely overdue
+		weekly, ok := spr["weekly-snapshots"]
+		if !ok {
+			t.Fatalf("Missing SLM policy weekly-snapshots")
+		}
+		now := time.Now()
+		for _, metric := range s.slmPolicyMetrics {
+			if !strings.Contains(metric.Desc.String(), "seconds_since_last_success") {
+				continue
+			}
+			if v := metric.Value(weekly, now); !math.IsInf(v, 1) {
+				t.Errorf("Wrong seconds since last success %v of policy weekly-snapshots", v)
+			}
+			if v := metric.Value(policy, now); math.IsInf(v, 0) || v <= 0 {
+				t.Errorf("Wrong seconds since last success %v of policy nightly-snapshots", v)
+			}
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package collector
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	defaultSlowlogLabels = []string{"type", "index", "level"}
+
+	// slowlogTextLine matches the plain text slowlog format used before Elasticsearch 7.0, e.g.
+	// [2019-01-08T10:03:40,335][WARN ][index.search.slowlog.query] [es01] [foo_1][0] took[1.2s], took_millis[1200], ...
+	slowlogTextLine = regexp.MustCompile(`^\[[^\]]*\]\[(\w+)\s*\]\[([\w.]+)\s*\]\s*\[[^\]]*\]\s*\[([^\]]+)\].*took_millis\[(\d+)\]`)
+)
+
+// slowlogEntry is a single parsed slowlog line
+type slowlogEntry struct {
+	Type   string
+	Index  string
+	Level  string
+	Millis float64
+}
+
+// slowlogFile is the read position within a single slowlog file
+type slowlogFile struct {
+	path   string
+	info   os.FileInfo
+	offset int64
+}
+
+// Slowlog tails the search and indexing slowlog files of the local node, since the number
+// of slow operations is not available from any API
+type Slowlog struct {
+	logger log.Logger
+
+	mutex sync.Mutex
+	files []*slowlogFile
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	took *prometheus.HistogramVec
+}
+
+var (
+	slowlogPaths = kingpin.Flag("es.slowlog.paths",
+		"Comma-separated list of search and indexing slowlog files to tail.").
+		Default("").Envar("ES_SLOWLOG_PATHS").String()
+)
+
+func init() {
+	// the slowlog files are read from the local node instead of the cluster API
+	registerCollector("slowlog", defaultDisabled, "Export counts and latencies of slow operations from the slowlog files of the local node.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewSlowlog(logger, strings.Split(*slowlogPaths, ","))
+	})
+}
+
+// NewSlowlog defines Slowlog Prometheus metrics
+func NewSlowlog(logger log.Logger, paths []string) *Slowlog {
+	subsystem := "slowlog"
+
+	files := make([]*slowlogFile, 0, len(paths))
+	for _, p := range paths {
+		files = append(files, &slowlogFile{path: p})
+	}
+
+	return &Slowlog{
+		logger: logger,
+		files:  files,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Were all ElasticSearch slowlog files readable during the last scrape.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch slowlog scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing slowlog lines.",
+		}),
+		took: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    prometheus.BuildFQName(namespace, subsystem, "took_seconds"),
+			Help:    "Time taken by operations logged to the slowlog in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 10),
+		}, defaultSlowlogLabels),
+	}
+}
+
+// Describe add Slowlog metrics descriptions
+func (s *Slowlog) Describe(ch chan<- *prometheus.Desc) {
+	s.took.Describe(ch)
+	ch <- s.up.Desc()
+	ch <- s.totalScrapes.Desc()
+	ch <- s.jsonParseFailures.Desc()
+}
+
+// slowlogType maps the logger of a slowlog line to the type label
+func slowlogType(logger string) string {
+	switch {
+	case strings.Contains(logger, "search"):
+		return "search"
+	case strings.Contains(logger, "indexing"):
+		return "indexing"
+	}
+	return logger
+}
+
+// slowlogIndex extracts the index name from a slowlog message such as [foo_1][0] or [foo_1/uuid]
+func slowlogIndex(message string) string {
+	message = strings.TrimPrefix(message, "[")
+	if i := strings.IndexAny(message, "]/"); i >= 0 {
+		message = message[:i]
+	}
+	return message
+}
+
+// slowlogField returns the string value of the first of the keys present in a JSON log line
+func slowlogField(line map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		switch v := line[key].(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return ""
+}
+
+// parseSlowlogLine parses a slowlog line in the JSON formats of Elasticsearch 7.x and 8.x
+// or in the plain text format of earlier versions
+func parseSlowlogLine(line string) (slowlogEntry, error) {
+	var entry slowlogEntry
+	if !strings.HasPrefix(line, "{") {
+		m := slowlogTextLine.FindStringSubmatch(line)
+		if m == nil {
+			return entry, fmt.Errorf("unknown slowlog line format")
+		}
+		millis, err := strconv.ParseFloat(m[4], 64)
+		if err != nil {
+			return entry, err
+		}
+		return slowlogEntry{
+			Type:   slowlogType(m[2]),
+			Index:  slowlogIndex("[" + m[3]),
+			Level:  strings.ToLower(m[1]),
+			Millis: millis,
+		}, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return entry, err
+	}
+	millis, err := strconv.ParseFloat(slowlogField(fields, "elasticsearch.slowlog.took_millis", "took_millis"), 64)
+	if err != nil {
+		return entry, fmt.Errorf("slowlog line without took_millis: %s", err)
+	}
+	index := slowlogField(fields, "elasticsearch.index.name")
+	if index == "" {
+		index = slowlogIndex(slowlogField(fields, "elasticsearch.slowlog.message", "message"))
+	}
+	return slowlogEntry{
+		Type:   slowlogType(slowlogField(fields, "log.logger", "type")),
+		Index:  index,
+		Level:  strings.ToLower(slowlogField(fields, "log.level", "level")),
+		Millis: millis,
+	}, nil
+}
+
+// readLines returns the complete lines appended to the file since the last call. The first call
+// only records the end of the file, a truncated or replaced file is read from the beginning
+func (f *slowlogFile) readLines() ([]string, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case f.info == nil:
+		f.info, f.offset = info, info.Size()
+		return nil, nil
+	case !os.SameFile(f.info, info) || info.Size() < f.offset:
+		f.offset = 0
+	}
+	f.info = info
+
+	if _, err := file.Seek(f.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	var lines []string
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF {
+			// a partial line is read again once it is complete
+			return lines, nil
+		}
+		if err != nil {
+			return lines, err
+		}
+		f.offset += int64(len(line))
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+}
+
+// Collect gets Slowlog metric values
+func (s *Slowlog) Collect(ch chan<- prometheus.Metric) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.totalScrapes.Inc()
+	defer func() {
+		ch <- s.up
+		ch <- s.totalScrapes
+		ch <- s.jsonParseFailures
+	}()
+
+	up := 1.0
+	for _, f := range s.files {
+		lines, err := f.readLines()
+		if err != nil {
+			up = 0
+			_ = level.Warn(s.logger).Log(
+				"msg", "failed to read slowlog",
+				"path", f.path,
+				"err", err,
+			)
+		}
+		for _, line := range lines {
+			entry, err := parseSlowlogLine(line)
+			if err != nil {
+				s.jsonParseFailures.Inc()
+				_ = level.Debug(s.logger).Log(
+					"msg", "failed to parse slowlog line",
+					"path", f.path,
+					"err", err,
+				)
+				continue
+			}
+			s.took.WithLabelValues(entry.Type, entry.Index, entry.Level).Observe(entry.Millis / 1000)
+		}
+	}
+	s.up.Set(up)
+
+	s.took.Collect(ch)
+}
@@ -0,0 +1,86 @@
+package collector
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestSlowlogParse(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1/_settings -H "Content-Type: application/json" -d '{"index.search.slowlog.threshold.query.warn":"0ms","index.indexing.slowlog.threshold.index.info":"0ms"}'
+	//  docker exec CONTAINER tail /usr/share/elasticsearch/logs/docker-cluster_index_search_slowlog.json
+	tcs := map[string]struct {
+		line  string
+		entry slowlogEntry
+	}{
+		"6.8.0": {
+			`[2019-01-08T10:03:40,335][WARN ][index.search.slowlog.query] [es01] [foo_1][0] took[1.2s], took_millis[1200], total_hits[0], types[], stats[], search_type[QUERY_THEN_FETCH], total_shards[5], source[{"query":{"match_all":{"boost":1.0}}}], `,
+			slowlogEntry{Type: "search", Index: "foo_1", Level: "warn", Millis: 1200},
+		},
+		"7.10.0": {
+			`{"type": "index_indexing_slowlog", "timestamp": "2021-01-08T15:37:11,047Z", "level": "INFO", "component": "i.i.s.index", "cluster.name": "docker-cluster", "node.name": "es01", "message": "[foo_1/Q9DUd6CvSBCEbQ3RGVFX3Q]", "took": "5ms", "took_millis": "5", "doc_type": "_doc", "id": "1", "routing": "", "source": "{\"title\":\"abc\"}", "cluster.uuid": "00Dl3H5mTa2glx1dLEuJxg", "node.id": "tMTocMvQQgGCkj7QDHl3OA"  }`,
+			slowlogEntry{Type: "indexing", Index: "foo_1", Level: "info", Millis: 5},
+		},
+		"8.5.0": {
+			`{"@timestamp":"2022-11-09T13:23:51.541Z", "log.level": "WARN", "elasticsearch.slowlog.id":null,"elasticsearch.slowlog.message":"[foo_1][0]","elasticsearch.slowlog.search_type":"QUERY_THEN_FETCH","elasticsearch.slowlog.source":"{\"query\":{\"match_all\":{\"boost\":1.0}}}","elasticsearch.slowlog.took":"2.1s","elasticsearch.slowlog.took_millis":2100,"elasticsearch.slowlog.total_hits":"0 hits","elasticsearch.slowlog.total_shards":1 , "ecs.version": "1.2.0","service.name":"ES_ECS","event.dataset":"elasticsearch.index_search_slowlog","process.thread.name":"elasticsearch[es01][search][T#1]","log.logger":"index.search.slowlog.query","elasticsearch.cluster.uuid":"00Dl3H5mTa2glx1dLEuJxg","elasticsearch.node.id":"tMTocMvQQgGCkj7QDHl3OA","elasticsearch.node.name":"es01","elasticsearch.cluster.name":"docker-cluster"}`,
+			slowlogEntry{Type: "search", Index: "foo_1", Level: "warn", Millis: 2100},
+		},
+	}
+	for ver, tc := range tcs {
+		entry, err := parseSlowlogLine(tc.line)
+		if err != nil {
+			t.Fatalf("[%s] Failed to parse slowlog line: %s", ver, err)
+		}
+		if entry != tc.entry {
+			t.Errorf("[%s] Wrong slowlog entry %+v, expected %+v", ver, entry, tc.entry)
+		}
+	}
+	if _, err := parseSlowlogLine("not a slowlog line"); err == nil {
+		t.Errorf("Parsing an unknown line format should fail")
+	}
+}
+
+func TestSlowlogTail(t *testing.T) {
+	dir, err := ioutil.TempDir("", "slowlog")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "index_search_slowlog.log")
+	line := "[2019-01-08T10:03:40,335][WARN ][index.search.slowlog.query] [es01] [foo_1][0] took[1.2s], took_millis[1200], total_hits[0]\n"
+	if err := ioutil.WriteFile(p, []byte(line), 0644); err != nil {
+		t.Fatalf("Failed to write slowlog: %s", err)
+	}
+
+	s := NewSlowlog(log.NewNopLogger(), []string{p})
+	f := s.files[0]
+	lines, err := f.readLines()
+	if err != nil || len(lines) != 0 {
+		t.Fatalf("Existing slowlog lines should be skipped")
+	}
+
+	file, err := os.OpenFile(p, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open slowlog: %s", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(line + line + "[2019-01-08T10:03:41"); err != nil {
+		t.Fatalf("Failed to append to slowlog: %s", err)
+	}
+	if lines, err = f.readLines(); err != nil || len(lines) != 2 {
+		t.Errorf("Only complete appended lines should be read, got %d", len(lines))
+	}
+
+	if err := ioutil.WriteFile(p, []byte(line), 0644); err != nil {
+		t.Fatalf("Failed to truncate slowlog: %s", err)
+	}
+	if lines, err = f.readLines(); err != nil || len(lines) != 1 {
+		t.Errorf("A truncated slowlog should be read from the beginning, got %d", len(lines))
+	}
+}
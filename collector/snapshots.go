@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"sync"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
@@ -19,6 +20,46 @@ type snapshotMetric struct {
 	Labels func(repositoryName string, snapshotStats SnapshotStatDataResponse) []string
 }
 
+type snapshotStatusMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(snapshotStatus SnapshotStatusDataResponse) float64
+}
+
+type snapshotRestoreMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(restore snapshotRestore) float64
+}
+
+// snapshotRestore sums up the restore progress of all shards of an index
+type snapshotRestore struct {
+	repository     string
+	snapshot       string
+	recoveredBytes int64
+	totalBytes     int64
+}
+
+type latestSnapshotMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(latest latestSnapshot) float64
+}
+
+// latestSnapshot is the most recent SUCCESS or PARTIAL snapshot of a repository and snapshot lifecycle policy
+type latestSnapshot struct {
+	repository string
+	policy     string
+	snapshot   SnapshotStatDataResponse
+	stats      SnapshotStatusStatsResponse
+}
+
+type repositoryStateMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(snapshotsStats SnapshotStatsResponse, state string) float64
+}
+
 type repositoryMetric struct {
 	Type   prometheus.ValueType
 	Desc   *prometheus.Desc
@@ -27,10 +68,14 @@ type repositoryMetric struct {
 }
 
 var (
-	defaultSnapshotLabels      = []string{"repository", "state", "version"}
+	snapshotStates             = []string{"SUCCESS", "IN_PROGRESS", "PARTIAL", "FAILED", "INCOMPATIBLE"}
+	defaultSnapshotLabels      = []string{"repository", "state", "version", "snapshot"}
 	defaultSnapshotLabelValues = func(repositoryName string, snapshotStats SnapshotStatDataResponse) []string {
-		return []string{repositoryName, snapshotStats.State, snapshotStats.Version}
+		return []string{repositoryName, snapshotStats.State, snapshotStats.Version, snapshotStats.Snapshot}
 	}
+	defaultSnapshotStatusLabels          = []string{"repository", "snapshot", "state"}
+	defaultSnapshotRestoreLabels         = []string{"index", "repository", "snapshot"}
+	defaultLatestSnapshotLabels          = []string{"repository", "policy"}
 	defaultSnapshotRepositoryLabels      = []string{"repository"}
 	defaultSnapshotRepositoryLabelValues = func(repositoryName string) []string {
 		return []string{repositoryName}
@@ -46,8 +91,22 @@ type Snapshots struct {
 	up                              prometheus.Gauge
 	totalScrapes, jsonParseFailures prometheus.Counter
 
-	snapshotMetrics   []*snapshotMetric
-	repositoryMetrics []*repositoryMetric
+	snapshotMetrics        []*snapshotMetric
+	repositoryMetrics      []*repositoryMetric
+	repositoryStateMetric  *repositoryStateMetric
+	snapshotStatusMetrics  []*snapshotStatusMetric
+	snapshotRestoreMetrics []*snapshotRestoreMetric
+	latestSnapshotMetrics  []*latestSnapshotMetric
+
+	// the size of a completed snapshot never changes, cache it to query the status of every snapshot only once
+	mutex               sync.Mutex
+	latestSnapshotStats map[string]SnapshotStatusStatsResponse
+}
+
+func init() {
+	registerCollector("snapshots", defaultDisabled, "Export stats for the cluster snapshots.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewSnapshots(logger, client, url)
+	})
 }
 
 // NewSnapshots defines Snapshots Prometheus metrics
@@ -57,6 +116,8 @@ func NewSnapshots(logger log.Logger, client *http.Client, url *url.URL) *Snapsho
 		client: client,
 		url:    url,
 
+		latestSnapshotStats: make(map[string]SnapshotStatusStatsResponse),
+
 		up: prometheus.NewGauge(prometheus.GaugeOpts{
 			Name: prometheus.BuildFQName(namespace, "snapshot_stats", "up"),
 			Help: "Was the last scrape of the ElasticSearch snapshots endpoint successful.",
@@ -106,6 +167,18 @@ func NewSnapshots(logger log.Logger, client *http.Client, url *url.URL) *Snapsho
 				},
 				Labels: defaultSnapshotLabelValues,
 			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "snapshot_duration_seconds"),
+					"Last snapshot duration in seconds",
+					defaultSnapshotLabels, nil,
+				),
+				Value: func(snapshotStats SnapshotStatDataResponse) float64 {
+					return float64(snapshotStats.DurationInMillis) / 1000
+				},
+				Labels: defaultSnapshotLabelValues,
+			},
 			{
 				Type: prometheus.GaugeValue,
 				Desc: prometheus.NewDesc(
@@ -202,6 +275,178 @@ func NewSnapshots(logger log.Logger, client *http.Client, url *url.URL) *Snapsho
 				Labels: defaultSnapshotRepositoryLabelValues,
 			},
 		},
+		repositoryStateMetric: &repositoryStateMetric{
+			Type: prometheus.GaugeValue,
+			Desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, "snapshot_stats", "number_of_snapshots_by_state"),
+				"Number of snapshots in a repository by state",
+				[]string{"repository", "state"}, nil,
+			),
+			Value: func(snapshotsStats SnapshotStatsResponse, state string) float64 {
+				var count int
+				for _, snap := range snapshotsStats.Snapshots {
+					if snap.State == state {
+						count++
+					}
+				}
+				return float64(count)
+			},
+		},
+		snapshotStatusMetrics: []*snapshotStatusMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "in_progress_shards_done"),
+					"Number of shards done of a running snapshot",
+					defaultSnapshotStatusLabels, nil,
+				),
+				Value: func(snapshotStatus SnapshotStatusDataResponse) float64 {
+					return float64(snapshotStatus.ShardsStats.Done)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "in_progress_shards_failed"),
+					"Number of shards failed of a running snapshot",
+					defaultSnapshotStatusLabels, nil,
+				),
+				Value: func(snapshotStatus SnapshotStatusDataResponse) float64 {
+					return float64(snapshotStatus.ShardsStats.Failed)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "in_progress_shards_total"),
+					"Total number of shards of a running snapshot",
+					defaultSnapshotStatusLabels, nil,
+				),
+				Value: func(snapshotStatus SnapshotStatusDataResponse) float64 {
+					return float64(snapshotStatus.ShardsStats.Total)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "in_progress_processed_bytes"),
+					"Bytes processed of a running snapshot",
+					defaultSnapshotStatusLabels, nil,
+				),
+				Value: func(snapshotStatus SnapshotStatusDataResponse) float64 {
+					return float64(snapshotStatus.Stats.ProcessedBytes())
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "in_progress_total_bytes"),
+					"Total bytes of a running snapshot",
+					defaultSnapshotStatusLabels, nil,
+				),
+				Value: func(snapshotStatus SnapshotStatusDataResponse) float64 {
+					return float64(snapshotStatus.Stats.TotalBytes())
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "in_progress_start_time_timestamp"),
+					"Start timestamp of a running snapshot",
+					defaultSnapshotStatusLabels, nil,
+				),
+				Value: func(snapshotStatus SnapshotStatusDataResponse) float64 {
+					return float64(snapshotStatus.Stats.StartTimeInMillis / 1000)
+				},
+			},
+		},
+		snapshotRestoreMetrics: []*snapshotRestoreMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "restore_recovered_bytes"),
+					"Bytes recovered of a running snapshot restore",
+					defaultSnapshotRestoreLabels, nil,
+				),
+				Value: func(restore snapshotRestore) float64 {
+					return float64(restore.recoveredBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "restore_total_bytes"),
+					"Total bytes of a running snapshot restore",
+					defaultSnapshotRestoreLabels, nil,
+				),
+				Value: func(restore snapshotRestore) float64 {
+					return float64(restore.totalBytes)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "restore_percent"),
+					"Percent of bytes recovered of a running snapshot restore",
+					defaultSnapshotRestoreLabels, nil,
+				),
+				Value: func(restore snapshotRestore) float64 {
+					if restore.totalBytes == 0 {
+						return 0
+					}
+					return float64(restore.recoveredBytes) / float64(restore.totalBytes) * 100
+				},
+			},
+		},
+		latestSnapshotMetrics: []*latestSnapshotMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "latest_snapshot_duration_seconds"),
+					"Duration of the latest SUCCESS or PARTIAL snapshot in seconds",
+					defaultLatestSnapshotLabels, nil,
+				),
+				Value: func(latest latestSnapshot) float64 {
+					return float64(latest.snapshot.DurationInMillis) / 1000
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "latest_snapshot_size_bytes"),
+					"Total size of the files referenced by the latest SUCCESS or PARTIAL snapshot",
+					defaultLatestSnapshotLabels, nil,
+				),
+				Value: func(latest latestSnapshot) float64 {
+					return float64(latest.stats.TotalBytes())
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "latest_snapshot_incremental_size_bytes"),
+					"Size of the files copied to the repository by the latest SUCCESS or PARTIAL snapshot",
+					defaultLatestSnapshotLabels, nil,
+				),
+				Value: func(latest latestSnapshot) float64 {
+					return float64(latest.stats.IncrementalBytes())
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "snapshot_stats", "latest_snapshot_throughput_bytes_per_second"),
+					"Bytes copied to the repository per second by the latest SUCCESS or PARTIAL snapshot",
+					defaultLatestSnapshotLabels, nil,
+				),
+				Value: func(latest latestSnapshot) float64 {
+					if latest.snapshot.DurationInMillis == 0 {
+						return 0
+					}
+					return float64(latest.stats.IncrementalBytes()) / (float64(latest.snapshot.DurationInMillis) / 1000)
+				},
+			},
+		},
 	}
 }
 
@@ -210,6 +455,19 @@ func (s *Snapshots) Describe(ch chan<- *prometheus.Desc) {
 	for _, metric := range s.snapshotMetrics {
 		ch <- metric.Desc
 	}
+	for _, metric := range s.repositoryMetrics {
+		ch <- metric.Desc
+	}
+	ch <- s.repositoryStateMetric.Desc
+	for _, metric := range s.snapshotStatusMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range s.snapshotRestoreMetrics {
+		ch <- metric.Desc
+	}
+	for _, metric := range s.latestSnapshotMetrics {
+		ch <- metric.Desc
+	}
 	ch <- s.up.Desc()
 	ch <- s.totalScrapes.Desc()
 	ch <- s.jsonParseFailures.Desc()
@@ -267,6 +525,99 @@ func (s *Snapshots) fetchAndDecodeSnapshotsStats() (map[string]SnapshotStatsResp
 	return mssr, nil
 }
 
+func (s *Snapshots) fetchAndDecodeSnapshotStatus() (SnapshotStatusResponse, error) {
+	var ssr SnapshotStatusResponse
+
+	u := *s.url
+	u.Path = path.Join(u.Path, "/_snapshot/_status")
+	err := s.getAndParseURL(&u, &ssr)
+	return ssr, err
+}
+
+func (s *Snapshots) fetchAndDecodeSnapshotRestores() (map[string]snapshotRestore, error) {
+	var rr recoveryResponse
+
+	u := *s.url
+	u.Path = path.Join(u.Path, "/_recovery")
+	u.RawQuery = "active_only=true"
+	err := s.getAndParseURL(&u, &rr)
+	if err != nil {
+		return nil, err
+	}
+
+	restores := make(map[string]snapshotRestore)
+	for index, recovery := range rr {
+		for _, shard := range recovery.Shards {
+			if shard.Type != "SNAPSHOT" {
+				continue
+			}
+			restore := restores[index]
+			restore.repository = shard.Source.Repository
+			restore.snapshot = shard.Source.Snapshot
+			restore.recoveredBytes += shard.Index.Size.RecoveredInBytes
+			restore.totalBytes += shard.Index.Size.TotalInBytes
+			restores[index] = restore
+		}
+	}
+	return restores, nil
+}
+
+// fetchAndDecodeLatestSnapshots finds the latest SUCCESS or PARTIAL snapshot of every repository and
+// snapshot lifecycle policy and adds the size reported by its status
+func (s *Snapshots) fetchAndDecodeLatestSnapshots(mssr map[string]SnapshotStatsResponse) []latestSnapshot {
+	var latest []latestSnapshot
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats := make(map[string]SnapshotStatusStatsResponse)
+	for repository, ssr := range mssr {
+		// snapshots are sorted by start time, the last snapshot of a policy is the latest one
+		byPolicy := make(map[string]SnapshotStatDataResponse)
+		for _, snap := range ssr.Snapshots {
+			if snap.State == "SUCCESS" || snap.State == "PARTIAL" {
+				byPolicy[snap.Policy()] = snap
+			}
+		}
+		for policy, snap := range byPolicy {
+			key := repository + "/" + snap.Snapshot
+			status, ok := s.latestSnapshotStats[key]
+			if !ok {
+				u := *s.url
+				u.Path = path.Join(u.Path, "/_snapshot", repository, snap.Snapshot, "/_status")
+				var statusResp SnapshotStatusResponse
+				if err := s.getAndParseURL(&u, &statusResp); err != nil {
+					_ = level.Warn(s.logger).Log(
+						"msg", "failed to fetch and decode status of the latest snapshot",
+						"repository", repository,
+						"snapshot", snap.Snapshot,
+						"err", err,
+					)
+					continue
+				}
+				if len(statusResp.Snapshots) == 0 {
+					_ = level.Warn(s.logger).Log(
+						"msg", "empty snapshot status response",
+						"repository", repository,
+						"snapshot", snap.Snapshot,
+					)
+					continue
+				}
+				status = statusResp.Snapshots[0].Stats
+			}
+			stats[key] = status
+			latest = append(latest, latestSnapshot{
+				repository: repository,
+				policy:     policy,
+				snapshot:   snap,
+				stats:      status,
+			})
+		}
+	}
+	// only keep the stats of the current latest snapshots
+	s.latestSnapshotStats = stats
+	return latest
+}
+
 // Collect gets Snapshots metric values
 func (s *Snapshots) Collect(ch chan<- prometheus.Metric) {
 	s.totalScrapes.Inc()
@@ -286,8 +637,64 @@ func (s *Snapshots) Collect(ch chan<- prometheus.Metric) {
 		)
 		return
 	}
+
+	snapshotStatusResp, err := s.fetchAndDecodeSnapshotStatus()
+	if err != nil {
+		s.up.Set(0)
+		_ = level.Warn(s.logger).Log(
+			"msg", "failed to fetch and decode snapshot status",
+			"err", err,
+		)
+		return
+	}
+
+	snapshotRestores, err := s.fetchAndDecodeSnapshotRestores()
+	if err != nil {
+		s.up.Set(0)
+		_ = level.Warn(s.logger).Log(
+			"msg", "failed to fetch and decode snapshot restores",
+			"err", err,
+		)
+		return
+	}
 	s.up.Set(1)
 
+	// Running snapshot restores
+	for index, restore := range snapshotRestores {
+		for _, metric := range s.snapshotRestoreMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(restore),
+				index, restore.repository, restore.snapshot,
+			)
+		}
+	}
+
+	// Running snapshots
+	for _, snapshotStatus := range snapshotStatusResp.Snapshots {
+		for _, metric := range s.snapshotStatusMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(snapshotStatus),
+				snapshotStatus.Repository, snapshotStatus.Snapshot, snapshotStatus.State,
+			)
+		}
+	}
+
+	// Latest snapshots per repository and policy
+	for _, latest := range s.fetchAndDecodeLatestSnapshots(snapshotsStatsResp) {
+		for _, metric := range s.latestSnapshotMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(latest),
+				latest.repository, latest.policy,
+			)
+		}
+	}
+
 	// Snapshots stats
 	for repositoryName, snapshotStats := range snapshotsStatsResp {
 		for _, metric := range s.repositoryMetrics {
@@ -298,6 +705,14 @@ func (s *Snapshots) Collect(ch chan<- prometheus.Metric) {
 				metric.Labels(repositoryName)...,
 			)
 		}
+		for _, state := range snapshotStates {
+			ch <- prometheus.MustNewConstMetric(
+				s.repositoryStateMetric.Desc,
+				s.repositoryStateMetric.Type,
+				s.repositoryStateMetric.Value(snapshotStats, state),
+				repositoryName, state,
+			)
+		}
 		if len(snapshotStats.Snapshots) == 0 {
 			continue
 		}
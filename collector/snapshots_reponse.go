@@ -9,18 +9,19 @@ type SnapshotStatsResponse struct {
 
 // SnapshotStatDataResponse is a representation of the single snapshot stat
 type SnapshotStatDataResponse struct {
-	Snapshot          string        `json:"snapshot"`
-	UUID              string        `json:"uuid"`
-	VersionID         int64         `json:"version_id"`
-	Version           string        `json:"version"`
-	Indices           []string      `json:"indices"`
-	State             string        `json:"state"`
-	StartTime         time.Time     `json:"start_time"`
-	StartTimeInMillis int64         `json:"start_time_in_millis"`
-	EndTime           time.Time     `json:"end_time"`
-	EndTimeInMillis   int64         `json:"end_time_in_millis"`
-	DurationInMillis  int64         `json:"duration_in_millis"`
-	Failures          []interface{} `json:"failures"`
+	Snapshot          string                 `json:"snapshot"`
+	UUID              string                 `json:"uuid"`
+	VersionID         int64                  `json:"version_id"`
+	Version           string                 `json:"version"`
+	Indices           []string               `json:"indices"`
+	State             string                 `json:"state"`
+	StartTime         time.Time              `json:"start_time"`
+	StartTimeInMillis int64                  `json:"start_time_in_millis"`
+	EndTime           time.Time              `json:"end_time"`
+	EndTimeInMillis   int64                  `json:"end_time_in_millis"`
+	DurationInMillis  int64                  `json:"duration_in_millis"`
+	Failures          []interface{}          `json:"failures"`
+	Metadata          map[string]interface{} `json:"metadata"`
 	Shards            struct {
 		Total      int64 `json:"total"`
 		Failed     int64 `json:"failed"`
@@ -28,8 +29,81 @@ type SnapshotStatDataResponse struct {
 	} `json:"shards"`
 }
 
+// Policy returns the snapshot lifecycle policy that took the snapshot, empty for manual snapshots
+func (s SnapshotStatDataResponse) Policy() string {
+	policy, _ := s.Metadata["policy"].(string)
+	return policy
+}
+
 // SnapshotRepositoriesResponse is a representation snapshots repositories
 type SnapshotRepositoriesResponse map[string]struct {
 	Type     string            `json:"type"`
 	Settings map[string]string `json:"settings"`
 }
+
+// SnapshotStatusResponse is a representation of the currently running snapshots
+type SnapshotStatusResponse struct {
+	Snapshots []SnapshotStatusDataResponse `json:"snapshots"`
+}
+
+// SnapshotStatusDataResponse is a representation of the status of a single running snapshot
+type SnapshotStatusDataResponse struct {
+	Snapshot    string `json:"snapshot"`
+	Repository  string `json:"repository"`
+	UUID        string `json:"uuid"`
+	State       string `json:"state"`
+	ShardsStats struct {
+		Initializing int64 `json:"initializing"`
+		Started      int64 `json:"started"`
+		Finalizing   int64 `json:"finalizing"`
+		Done         int64 `json:"done"`
+		Failed       int64 `json:"failed"`
+		Total        int64 `json:"total"`
+	} `json:"shards_stats"`
+	Stats SnapshotStatusStatsResponse `json:"stats"`
+}
+
+// SnapshotStatusStatsResponse is a representation of the file and byte progress of a running snapshot
+type SnapshotStatusStatsResponse struct {
+	Incremental       SnapshotStatusFilesResponse `json:"incremental"`
+	Processed         SnapshotStatusFilesResponse `json:"processed"`
+	Total             SnapshotStatusFilesResponse `json:"total"`
+	StartTimeInMillis int64                       `json:"start_time_in_millis"`
+	TimeInMillis      int64                       `json:"time_in_millis"`
+	// pre 7.4 releases report the progress in flat fields
+	NumberOfFiles        int64 `json:"number_of_files"`
+	ProcessedFiles       int64 `json:"processed_files"`
+	TotalSizeInBytes     int64 `json:"total_size_in_bytes"`
+	ProcessedSizeInBytes int64 `json:"processed_size_in_bytes"`
+}
+
+// SnapshotStatusFilesResponse is a representation of a snapshot file count and size
+type SnapshotStatusFilesResponse struct {
+	FileCount   int64 `json:"file_count"`
+	SizeInBytes int64 `json:"size_in_bytes"`
+}
+
+// ProcessedBytes returns the processed bytes independent of the ES release
+func (s SnapshotStatusStatsResponse) ProcessedBytes() int64 {
+	if s.Processed.SizeInBytes > 0 {
+		return s.Processed.SizeInBytes
+	}
+	return s.ProcessedSizeInBytes
+}
+
+// IncrementalBytes returns the bytes copied to the repository independent of the ES release,
+// pre 7.4 releases only report the files which had to be copied
+func (s SnapshotStatusStatsResponse) IncrementalBytes() int64 {
+	if s.Incremental.SizeInBytes > 0 {
+		return s.Incremental.SizeInBytes
+	}
+	return s.TotalSizeInBytes
+}
+
+// TotalBytes returns the total bytes independent of the ES release
+func (s SnapshotStatusStatsResponse) TotalBytes() int64 {
+	if s.Total.SizeInBytes > 0 {
+		return s.Total.SizeInBytes
+	}
+	return s.TotalSizeInBytes
+}
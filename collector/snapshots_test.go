@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -65,9 +66,165 @@ func TestSnapshots(t *testing.T) {
 		if snapshotStats.Shards.Successful != 10 {
 			t.Errorf("Bad number of snapshot shards successful")
 		}
+		if snapshotStats.Snapshot != "snapshot_1" {
+			t.Errorf("Bad snapshot name")
+		}
+		if snapshotStats.DurationInMillis == 0 {
+			t.Errorf("Bad snapshot duration")
+		}
 		if len(repositoryStats.Snapshots) != 1 {
 			t.Errorf("Bad number of repository snapshots")
 		}
 	}
 
 }
+
+func TestSnapshotStatus(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e path.repo=/tmp elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_snapshot/test1 -d '{"type": "fs","settings":{"location": "/tmp/test1"}}'
+	//  curl -XPUT http://localhost:9200/_snapshot/test1/snapshot_1
+	//  curl http://localhost:9200/_snapshot/_status
+	tcs := map[string]string{
+		"6.8.0": `{"snapshots":[{"snapshot":"snapshot_1","repository":"test1","uuid":"oaA8mIJpRXm4-rxxoaIoKw","state":"STARTED","include_global_state":true,"shards_stats":{"initializing":0,"started":1,"finalizing":0,"done":4,"failed":0,"total":5},"stats":{"number_of_files":40,"processed_files":32,"total_size_in_bytes":204800,"processed_size_in_bytes":102400,"start_time_in_millis":1594636186514,"time_in_millis":2034}}]}`,
+		"7.8.0": `{"snapshots":[{"snapshot":"snapshot_1","repository":"test1","uuid":"oaA8mIJpRXm4-rxxoaIoKw","state":"STARTED","include_global_state":true,"shards_stats":{"initializing":0,"started":1,"finalizing":0,"done":4,"failed":0,"total":5},"stats":{"incremental":{"file_count":40,"size_in_bytes":204800},"processed":{"file_count":32,"size_in_bytes":102400},"total":{"file_count":40,"size_in_bytes":204800},"start_time_in_millis":1594636186514,"time_in_millis":2034}}]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		s := NewSnapshots(log.NewNopLogger(), http.DefaultClient, u)
+		ssr, err := s.fetchAndDecodeSnapshotStatus()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode snapshot status: %s", err)
+		}
+		t.Logf("[%s] Snapshot Status Response: %+v", ver, ssr)
+		if len(ssr.Snapshots) != 1 {
+			t.Fatalf("Bad number of running snapshots")
+		}
+		status := ssr.Snapshots[0]
+		if status.ShardsStats.Done != 4 || status.ShardsStats.Total != 5 {
+			t.Errorf("Bad number of running snapshot shards")
+		}
+		if status.Stats.ProcessedBytes() != 102400 || status.Stats.TotalBytes() != 204800 {
+			t.Errorf("Bad running snapshot progress")
+		}
+	}
+}
+
+func TestSnapshotRestores(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e path.repo=/tmp elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_snapshot/test1 -d '{"type": "fs","settings":{"location": "/tmp/test1"}}'
+	//  curl -XPUT "http://localhost:9200/_snapshot/test1/snapshot_1?wait_for_completion=true"
+	//  curl -XDELETE http://localhost:9200/foo_1
+	//  curl -XPOST http://localhost:9200/_snapshot/test1/snapshot_1/_restore -d '{"indices":"foo_1"}'
+	//  curl "http://localhost:9200/_recovery?active_only=true"
+	tcs := map[string]string{
+		"7.8.0": `{"foo_1":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"INDEX","primary":true,"start_time_in_millis":1594636913570,"total_time_in_millis":1029,"source":{"repository":"test1","snapshot":"snapshot_1","version":"7.8.0","index":"foo_1","restoreUUID":"1v0Aq4gVTcS5V6gYxFuB0A"},"target":{"id":"Bu9Dc0qkQVmPSbRx9B0KjQ","host":"172.17.0.2","transport_address":"172.17.0.2:9300","ip":"172.17.0.2","name":"8b3c5c2f6a54"},"index":{"size":{"total_in_bytes":4000,"reused_in_bytes":0,"recovered_in_bytes":1000,"percent":"25.0%"},"files":{"total":4,"reused":0,"recovered":1,"percent":"25.0%"},"total_time_in_millis":1020,"source_throttle_time_in_millis":0,"target_throttle_time_in_millis":0},"translog":{"recovered":0,"total":0,"percent":"100.0%","total_on_start":0,"total_time_in_millis":0},"verify_index":{"check_index_time_in_millis":0,"total_time_in_millis":0}},{"id":1,"type":"SNAPSHOT","stage":"INDEX","primary":true,"start_time_in_millis":1594636913570,"total_time_in_millis":1029,"source":{"repository":"test1","snapshot":"snapshot_1","version":"7.8.0","index":"foo_1"},"index":{"size":{"total_in_bytes":4000,"reused_in_bytes":0,"recovered_in_bytes":3000,"percent":"75.0%"},"files":{"total":4,"reused":0,"recovered":3,"percent":"75.0%"}}}]},"foo_2":{"shards":[{"id":0,"type":"PEER","stage":"INDEX","primary":false,"source":{"id":"Bu9Dc0qkQVmPSbRx9B0KjQ","host":"172.17.0.2","name":"8b3c5c2f6a54"},"index":{"size":{"total_in_bytes":4000,"reused_in_bytes":0,"recovered_in_bytes":1000,"percent":"25.0%"}}}]}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		s := NewSnapshots(log.NewNopLogger(), http.DefaultClient, u)
+		restores, err := s.fetchAndDecodeSnapshotRestores()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode snapshot restores: %s", err)
+		}
+		t.Logf("[%s] Snapshot Restores: %+v", ver, restores)
+		if len(restores) != 1 {
+			t.Fatalf("Bad number of snapshot restores")
+		}
+		restore := restores["foo_1"]
+		if restore.repository != "test1" || restore.snapshot != "snapshot_1" {
+			t.Errorf("Bad snapshot restore source")
+		}
+		if restore.recoveredBytes != 4000 || restore.totalBytes != 8000 {
+			t.Errorf("Bad snapshot restore progress")
+		}
+	}
+}
+
+func TestLatestSnapshots(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e path.repo=/tmp elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_snapshot/test1 -d '{"type": "fs","settings":{"location": "/tmp/test1"}}'
+	//  curl -XPUT http://localhost:9200/_slm/policy/nightly -d '{"schedule":"0 30 1 * * ?","name":"<nightly-{now/d}>","repository":"test1"}'
+	//  curl -XPOST http://localhost:9200/_slm/policy/nightly/_execute
+	//  curl -XPUT "http://localhost:9200/_snapshot/test1/snapshot_1?wait_for_completion=true"
+	//  curl http://localhost:9200/_snapshot/test1/_all
+	//  curl http://localhost:9200/_snapshot/test1/nightly-2020.07.13-yndi0ascshgrwmarjugcxq/_status
+	tcs := map[string][]string{
+		"7.8.0": {
+			`{"snapshots":[{"snapshot":"nightly-2020.07.12-8ofwqavfsmy6kchsrsgi0q","uuid":"5ae3FTOwQwOzIbNDtG7Y3g","version_id":7080099,"version":"7.8.0","indices":["foo_1"],"include_global_state":true,"metadata":{"policy":"nightly"},"state":"SUCCESS","start_time":"2020-07-12T01:30:00.018Z","start_time_in_millis":1594517400018,"end_time":"2020-07-12T01:30:02.019Z","end_time_in_millis":1594517402019,"duration_in_millis":2001,"failures":[],"shards":{"total":1,"failed":0,"successful":1}},{"snapshot":"nightly-2020.07.13-yndi0ascshgrwmarjugcxq","uuid":"dnbA5gmrSTCNNyV1eMEvTA","version_id":7080099,"version":"7.8.0","indices":["foo_1"],"include_global_state":true,"metadata":{"policy":"nightly"},"state":"SUCCESS","start_time":"2020-07-13T01:30:00.012Z","start_time_in_millis":1594603800012,"end_time":"2020-07-13T01:30:04.012Z","end_time_in_millis":1594603804012,"duration_in_millis":4000,"failures":[],"shards":{"total":1,"failed":0,"successful":1}},{"snapshot":"snapshot_1","uuid":"oaA8mIJpRXm4-rxxoaIoKw","version_id":7080099,"version":"7.8.0","indices":["foo_1"],"include_global_state":true,"state":"SUCCESS","start_time":"2020-07-13T10:29:46.514Z","start_time_in_millis":1594636186514,"end_time":"2020-07-13T10:29:47.515Z","end_time_in_millis":1594636187515,"duration_in_millis":1001,"failures":[],"shards":{"total":1,"failed":0,"successful":1}},{"snapshot":"nightly-2020.07.14-bbtidfjhq2a1ud0rvxuyyq","uuid":"W6yBxXIdRDCjtIyhgU7fzQ","version_id":7080099,"version":"7.8.0","indices":["foo_1"],"include_global_state":true,"metadata":{"policy":"nightly"},"state":"FAILED","start_time":"2020-07-14T01:30:00.011Z","start_time_in_millis":1594690200011,"end_time":"2020-07-14T01:30:00.111Z","end_time_in_millis":1594690200111,"duration_in_millis":100,"failures":[],"shards":{"total":1,"failed":1,"successful":0}}]}`,
+			`{"snapshots":[{"snapshot":"nightly-2020.07.13-yndi0ascshgrwmarjugcxq","repository":"test1","uuid":"dnbA5gmrSTCNNyV1eMEvTA","state":"SUCCESS","include_global_state":true,"shards_stats":{"initializing":0,"started":0,"finalizing":0,"done":1,"failed":0,"total":1},"stats":{"incremental":{"file_count":4,"size_in_bytes":40000},"total":{"file_count":12,"size_in_bytes":120000},"start_time_in_millis":1594603800012,"time_in_millis":4000}}]}`,
+			`{"snapshots":[{"snapshot":"snapshot_1","repository":"test1","uuid":"oaA8mIJpRXm4-rxxoaIoKw","state":"SUCCESS","include_global_state":true,"shards_stats":{"initializing":0,"started":0,"finalizing":0,"done":1,"failed":0,"total":1},"stats":{"incremental":{"file_count":1,"size_in_bytes":1000},"total":{"file_count":12,"size_in_bytes":121000},"start_time_in_millis":1594636186514,"time_in_millis":1001}}]}`,
+		},
+	}
+	for ver, out := range tcs {
+		statusRequests := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/_snapshot/test1/nightly-2020.07.13-yndi0ascshgrwmarjugcxq/_status":
+				statusRequests++
+				fmt.Fprint(w, out[1])
+			case "/_snapshot/test1/snapshot_1/_status":
+				statusRequests++
+				fmt.Fprint(w, out[2])
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		var ssr SnapshotStatsResponse
+		if err := json.Unmarshal([]byte(out[0]), &ssr); err != nil {
+			t.Fatalf("Failed to decode snapshots stats: %s", err)
+		}
+		s := NewSnapshots(log.NewNopLogger(), http.DefaultClient, u)
+		latest := s.fetchAndDecodeLatestSnapshots(map[string]SnapshotStatsResponse{"test1": ssr})
+		t.Logf("[%s] Latest Snapshots: %+v", ver, latest)
+		if len(latest) != 2 {
+			t.Fatalf("Bad number of latest snapshots")
+		}
+		for _, l := range latest {
+			switch l.policy {
+			case "nightly":
+				if l.snapshot.Snapshot != "nightly-2020.07.13-yndi0ascshgrwmarjugcxq" {
+					t.Errorf("Bad latest snapshot %s of the nightly policy", l.snapshot.Snapshot)
+				}
+				if l.stats.TotalBytes() != 120000 || l.stats.IncrementalBytes() != 40000 {
+					t.Errorf("Bad size of the latest nightly snapshot")
+				}
+			case "":
+				if l.snapshot.Snapshot != "snapshot_1" || l.stats.TotalBytes() != 121000 {
+					t.Errorf("Bad latest manual snapshot")
+				}
+			default:
+				t.Errorf("Unexpected policy %s", l.policy)
+			}
+		}
+		// the status of completed snapshots is only queried once
+		s.fetchAndDecodeLatestSnapshots(map[string]SnapshotStatsResponse{"test1": ssr})
+		if statusRequests != 2 {
+			t.Errorf("Bad number of snapshot status requests %d", statusRequests)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	defaultSQLQueryLabels = []string{"node", "client"}
+
+	// sqlQueryAggregates are the keys of the queries usage which do not describe a client type
+	sqlQueryAggregates = map[string]bool{
+		"_all":      true,
+		"translate": true,
+	}
+)
+
+type sqlQueryMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(queries sqlQueriesResponse) float64
+}
+
+// SQL information struct
+type SQL struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	queryMetrics []*sqlQueryMetric
+
+	featureUsage *prometheus.Desc
+	translations *prometheus.Desc
+}
+
+func init() {
+	registerCollector("sql", defaultDisabled, "Export stats for SQL usage.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewSQL(logger, client, url)
+	})
+}
+
+// NewSQL defines SQL Prometheus metrics
+func NewSQL(logger log.Logger, client *http.Client, url *url.URL) *SQL {
+	subsystem := "sql"
+
+	return &SQL{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch sql stats endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch sql stats scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		queryMetrics: []*sqlQueryMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "queries_total"),
+					"Number of SQL queries run by the client type",
+					defaultSQLQueryLabels, nil,
+				),
+				Value: func(queries sqlQueriesResponse) float64 {
+					return float64(queries.Total)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "queries_paging_total"),
+					"Number of SQL queries paging through a cursor run by the client type",
+					defaultSQLQueryLabels, nil,
+				),
+				Value: func(queries sqlQueriesResponse) float64 {
+					return float64(queries.Paging)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "queries_failed_total"),
+					"Number of failed SQL queries run by the client type",
+					defaultSQLQueryLabels, nil,
+				),
+				Value: func(queries sqlQueriesResponse) float64 {
+					return float64(queries.Failed)
+				},
+			},
+		},
+		featureUsage: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "feature_usage_total"),
+			"Number of SQL queries using the feature",
+			[]string{"node", "feature"}, nil,
+		),
+		translations: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "translate_requests_total"),
+			"Number of SQL queries translated to the query DSL",
+			[]string{"node"}, nil,
+		),
+	}
+}
+
+// Describe add SQL metrics descriptions
+func (s *SQL) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range s.queryMetrics {
+		ch <- metric.Desc
+	}
+	ch <- s.featureUsage
+	ch <- s.translations
+	ch <- s.up.Desc()
+	ch <- s.totalScrapes.Desc()
+	ch <- s.jsonParseFailures.Desc()
+}
+
+func (s *SQL) fetchAndDecodeSQLStats() (sqlStatsResponse, error) {
+	var ssr sqlStatsResponse
+
+	u := *s.url
+	u.Path = path.Join(u.Path, "/_sql/stats")
+
+	res, err := s.client.Get(u.String())
+	if err != nil {
+		return ssr, fmt.Errorf("failed to get sql stats from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(s.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return ssr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&ssr); err != nil {
+		s.jsonParseFailures.Inc()
+		return ssr, err
+	}
+	return ssr, nil
+}
+
+// Collect gets SQL metric values
+func (s *SQL) Collect(ch chan<- prometheus.Metric) {
+	s.totalScrapes.Inc()
+	defer func() {
+		ch <- s.up
+		ch <- s.totalScrapes
+		ch <- s.jsonParseFailures
+	}()
+
+	ssr, err := s.fetchAndDecodeSQLStats()
+	if err != nil {
+		s.up.Set(0)
+		_ = level.Warn(s.logger).Log(
+			"msg", "failed to fetch and decode sql stats",
+			"err", err,
+		)
+		return
+	}
+	s.up.Set(1)
+
+	// the SQL stats API only reports node ids
+	for _, node := range ssr.Stats {
+		for client, queries := range node.Stats.Queries {
+			if sqlQueryAggregates[client] {
+				continue
+			}
+			for _, metric := range s.queryMetrics {
+				ch <- prometheus.MustNewConstMetric(
+					metric.Desc,
+					metric.Type,
+					metric.Value(queries),
+					node.NodeID, client,
+				)
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(
+			s.translations,
+			prometheus.CounterValue,
+			float64(node.Stats.Queries["translate"].Count),
+			node.NodeID,
+		)
+		for feature, count := range node.Stats.Features {
+			ch <- prometheus.MustNewConstMetric(
+				s.featureUsage,
+				prometheus.CounterValue,
+				float64(count),
+				node.NodeID, feature,
+			)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package collector
+
+// sqlStatsResponse is a representation of the Elasticsearch _sql/stats endpoint
+type sqlStatsResponse struct {
+	ClusterName string                 `json:"cluster_name"`
+	Stats       []sqlNodeStatsResponse `json:"stats"`
+}
+
+// sqlNodeStatsResponse defines the SQL usage of a single node
+type sqlNodeStatsResponse struct {
+	NodeID string `json:"node_id"`
+	Stats  struct {
+		Features map[string]int64              `json:"features"`
+		Queries  map[string]sqlQueriesResponse `json:"queries"`
+	} `json:"stats"`
+}
+
+// sqlQueriesResponse defines the SQL queries run by a single client type
+type sqlQueriesResponse struct {
+	Total  int64 `json:"total"`
+	Paging int64 `json:"paging"`
+	Failed int64 `json:"failed"`
+	// Count is only set for translate requests
+	Count int64 `json:"count"`
+}
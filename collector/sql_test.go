@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestSQL(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPOST http://localhost:9200/_sql -H "Content-Type: application/json" -d '{"query":"SELECT COUNT(*) FROM foo_1 GROUP BY title HAVING COUNT(*) > 1"}'
+	//  curl http://localhost:9200/_sql/stats
+	tcs := map[string]string{
+		"7.10.0": `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"elasticsearch","stats":[{"node_id":"tMTocMvQQgGCkj7QDHl3OA","stats":{"features":{"having":1,"subselect":0,"limit":0,"orderby":0,"where":0,"join":0,"groupby":1,"command":0,"local":0},"queries":{"rest":{"total":5,"paging":1,"failed":2},"cli":{"total":0,"paging":0,"failed":0},"canvas":{"total":0,"paging":0,"failed":0},"odbc32":{"total":0,"paging":0,"failed":0},"_all":{"total":8,"paging":1,"failed":2},"jdbc":{"total":3,"paging":0,"failed":0},"translate":{"count":4},"odbc64":{"total":0,"paging":0,"failed":0},"odbc":{"total":0,"paging":0,"failed":0}}}}]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewSQL(log.NewNopLogger(), http.DefaultClient, u)
+		ssr, err := c.fetchAndDecodeSQLStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode sql stats: %s", err)
+		}
+		t.Logf("[%s] SQL Stats Response: %+v", ver, ssr)
+		if len(ssr.Stats) != 1 || ssr.Stats[0].NodeID != "tMTocMvQQgGCkj7QDHl3OA" {
+			t.Fatalf("Wrong sql node stats")
+		}
+		stats := ssr.Stats[0].Stats
+		if rest := stats.Queries["rest"]; rest.Total != 5 || rest.Paging != 1 || rest.Failed != 2 {
+			t.Errorf("Wrong rest queries %+v", rest)
+		}
+		if stats.Queries["jdbc"].Total != 3 {
+			t.Errorf("Wrong jdbc queries")
+		}
+		if stats.Queries["translate"].Count != 4 {
+			t.Errorf("Wrong number of translate requests")
+		}
+		if stats.Features["having"] != 1 || stats.Features["groupby"] != 1 {
+			t.Errorf("Wrong feature usage")
+		}
+	}
+}
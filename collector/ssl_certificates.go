@@ -0,0 +1,148 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var defaultSSLCertificateLabels = []string{"path", "alias", "subject_dn"}
+
+// SSLCertificates information struct
+type SSLCertificates struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	expiry     *prometheus.Desc
+	expiryDays *prometheus.Desc
+}
+
+func init() {
+	registerCollector("ssl_certificates", defaultDisabled, "Export the expiry of the SSL certificates used by the transport and HTTP layers.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewSSLCertificates(logger, client, url)
+	})
+}
+
+// NewSSLCertificates defines SSL certificates Prometheus metrics
+func NewSSLCertificates(logger log.Logger, client *http.Client, url *url.URL) *SSLCertificates {
+	subsystem := "ssl_certificate"
+
+	return &SSLCertificates{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch ssl certificates endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch ssl certificates scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		expiry: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "expiry_timestamp_seconds"),
+			"Expiry of the certificate as unix timestamp",
+			defaultSSLCertificateLabels, nil,
+		),
+		expiryDays: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "expiry_days"),
+			"Number of days until the certificate expires, negative once it has expired",
+			defaultSSLCertificateLabels, nil,
+		),
+	}
+}
+
+// Describe add SSL certificates metrics descriptions
+func (s *SSLCertificates) Describe(ch chan<- *prometheus.Desc) {
+	ch <- s.expiry
+	ch <- s.expiryDays
+	ch <- s.up.Desc()
+	ch <- s.totalScrapes.Desc()
+	ch <- s.jsonParseFailures.Desc()
+}
+
+func (s *SSLCertificates) fetchAndDecodeSSLCertificates() (sslCertificatesResponse, error) {
+	var scr sslCertificatesResponse
+
+	u := *s.url
+	u.Path = path.Join(u.Path, "/_ssl/certificates")
+
+	res, err := s.client.Get(u.String())
+	if err != nil {
+		return scr, fmt.Errorf("failed to get ssl certificates from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(s.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return scr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&scr); err != nil {
+		s.jsonParseFailures.Inc()
+		return scr, err
+	}
+	return scr, nil
+}
+
+// Collect gets SSL certificates metric values
+func (s *SSLCertificates) Collect(ch chan<- prometheus.Metric) {
+	s.totalScrapes.Inc()
+	defer func() {
+		ch <- s.up
+		ch <- s.totalScrapes
+		ch <- s.jsonParseFailures
+	}()
+
+	scr, err := s.fetchAndDecodeSSLCertificates()
+	if err != nil {
+		s.up.Set(0)
+		_ = level.Warn(s.logger).Log(
+			"msg", "failed to fetch and decode ssl certificates",
+			"err", err,
+		)
+		return
+	}
+	s.up.Set(1)
+
+	now := time.Now()
+	for _, cert := range scr {
+		ch <- prometheus.MustNewConstMetric(
+			s.expiry,
+			prometheus.GaugeValue,
+			float64(cert.Expiry.Unix()),
+			cert.Path, cert.Alias, cert.SubjectDN,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			s.expiryDays,
+			prometheus.GaugeValue,
+			cert.Expiry.Sub(now).Hours()/24,
+			cert.Path, cert.Alias, cert.SubjectDN,
+		)
+	}
+}
@@ -0,0 +1,17 @@
+package collector
+
+import "time"
+
+// sslCertificatesResponse is a representation of the Elasticsearch _ssl/certificates endpoint
+type sslCertificatesResponse []sslCertificateResponse
+
+// sslCertificateResponse defines a single certificate used by the transport or HTTP layer
+type sslCertificateResponse struct {
+	Path          string    `json:"path"`
+	Format        string    `json:"format"`
+	Alias         string    `json:"alias"`
+	SubjectDN     string    `json:"subject_dn"`
+	SerialNumber  string    `json:"serial_number"`
+	HasPrivateKey bool      `json:"has_private_key"`
+	Expiry        time.Time `json:"expiry"`
+}
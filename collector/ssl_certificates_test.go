@@ -0,0 +1,50 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestSSLCertificates(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e xpack.security.enabled=true -e xpack.security.transport.ssl.enabled=true ... elasticsearch:VERSION
+	//  curl -u elastic:changeme http://localhost:9200/_ssl/certificates
+	tcs := map[string]string{
+		"7.10.0": `[{"path":"certs/elastic-certificates.p12","format":"PKCS12","alias":"instance","subject_dn":"CN=instance","serial_number":"a20f0f1c7de7e1283e86da8e2fb0f9e2cba607f3","has_private_key":true,"expiry":"2023-11-18T21:09:47.000Z"},{"path":"certs/elastic-certificates.p12","format":"PKCS12","alias":"ca","subject_dn":"CN=Elastic Certificate Tool Autogenerated CA","serial_number":"c1dd5e4e4ae3ad2fedd05e7f2db8f7d28af5d8a0","has_private_key":false,"expiry":"2023-11-18T21:09:17.000Z"},{"path":"certs/ca.crt","format":"PEM","alias":null,"subject_dn":"CN=Elastic Certificate Tool Autogenerated CA","serial_number":"c1dd5e4e4ae3ad2fedd05e7f2db8f7d28af5d8a0","has_private_key":false,"expiry":"2023-11-18T21:09:17.000Z"}]`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewSSLCertificates(log.NewNopLogger(), http.DefaultClient, u)
+		scr, err := c.fetchAndDecodeSSLCertificates()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode ssl certificates: %s", err)
+		}
+		t.Logf("[%s] SSL Certificates Response: %+v", ver, scr)
+		if len(scr) != 3 {
+			t.Fatalf("Wrong number of certificates")
+		}
+		if cert := scr[0]; cert.Path != "certs/elastic-certificates.p12" || cert.Alias != "instance" || cert.SubjectDN != "CN=instance" || !cert.HasPrivateKey {
+			t.Errorf("Wrong certificate %+v", cert)
+		}
+		if expiry := time.Date(2023, 11, 18, 21, 9, 47, 0, time.UTC); !scr[0].Expiry.Equal(expiry) {
+			t.Errorf("Wrong expiry %s", scr[0].Expiry)
+		}
+		if cert := scr[2]; cert.Alias != "" || cert.Format != "PEM" {
+			t.Errorf("Wrong PEM certificate %+v", cert)
+		}
+	}
+}
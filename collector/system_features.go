@@ -0,0 +1,157 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var systemFeatureMigrationStatuses = []string{"no_migration_needed", "migration_needed", "in_progress", "error"}
+
+// SystemFeatures information struct
+type SystemFeatures struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	migrationStatus        *prometheus.Desc
+	featureMigrationStatus *prometheus.Desc
+	featureIndices         *prometheus.Desc
+}
+
+func init() {
+	registerCollector("system_features", defaultDisabled, "Export the migration status of system features.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewSystemFeatures(logger, client, url)
+	})
+}
+
+// NewSystemFeatures defines System Features Prometheus metrics
+func NewSystemFeatures(logger log.Logger, client *http.Client, url *url.URL) *SystemFeatures {
+	subsystem := "system_features"
+
+	return &SystemFeatures{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch system features migration endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch system features migration scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		migrationStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "migration_status"),
+			"Whether the status is the overall migration status of the system indices",
+			[]string{"status"}, nil,
+		),
+		featureMigrationStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "feature_migration_status"),
+			"Whether the status is the migration status of the system indices of the feature",
+			[]string{"feature", "status"}, nil,
+		),
+		featureIndices: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "feature_indices"),
+			"Number of system indices of the feature",
+			[]string{"feature"}, nil,
+		),
+	}
+}
+
+// Describe add System Features metrics descriptions
+func (sf *SystemFeatures) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sf.migrationStatus
+	ch <- sf.featureMigrationStatus
+	ch <- sf.featureIndices
+	ch <- sf.up.Desc()
+	ch <- sf.totalScrapes.Desc()
+	ch <- sf.jsonParseFailures.Desc()
+}
+
+func (sf *SystemFeatures) fetchAndDecodeSystemFeatures() (systemFeaturesResponse, error) {
+	var sfr systemFeaturesResponse
+
+	u := *sf.url
+	u.Path = path.Join(u.Path, "/_migration/system_features")
+
+	res, err := sf.client.Get(u.String())
+	if err != nil {
+		return sfr, fmt.Errorf("failed to get system features migration from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(sf.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return sfr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&sfr); err != nil {
+		sf.jsonParseFailures.Inc()
+		return sfr, err
+	}
+	return sfr, nil
+}
+
+// Collect gets System Features metric values
+func (sf *SystemFeatures) Collect(ch chan<- prometheus.Metric) {
+	sf.totalScrapes.Inc()
+	defer func() {
+		ch <- sf.up
+		ch <- sf.totalScrapes
+		ch <- sf.jsonParseFailures
+	}()
+
+	sfr, err := sf.fetchAndDecodeSystemFeatures()
+	if err != nil {
+		sf.up.Set(0)
+		_ = level.Warn(sf.logger).Log(
+			"msg", "failed to fetch and decode system features migration",
+			"err", err,
+		)
+		return
+	}
+	sf.up.Set(1)
+
+	for _, status := range systemFeatureMigrationStatuses {
+		var value float64
+		if strings.ToLower(sfr.MigrationStatus) == status {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(sf.migrationStatus, prometheus.GaugeValue, value, status)
+	}
+	for _, feature := range sfr.Features {
+		for _, status := range systemFeatureMigrationStatuses {
+			var value float64
+			if strings.ToLower(feature.MigrationStatus) == status {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(sf.featureMigrationStatus, prometheus.GaugeValue, value, feature.FeatureName, status)
+		}
+		ch <- prometheus.MustNewConstMetric(sf.featureIndices, prometheus.GaugeValue, float64(len(feature.Indices)), feature.FeatureName)
+	}
+}
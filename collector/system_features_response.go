@@ -0,0 +1,21 @@
+package collector
+
+// systemFeaturesResponse is a representation of the Elasticsearch _migration/system_features endpoint
+type systemFeaturesResponse struct {
+	Features        []systemFeatureResponse `json:"features"`
+	MigrationStatus string                  `json:"migration_status"`
+}
+
+// systemFeatureResponse defines the migration status of the system indices of a single feature
+type systemFeatureResponse struct {
+	FeatureName         string                       `json:"feature_name"`
+	MinimumIndexVersion string                       `json:"minimum_index_version"`
+	MigrationStatus     string                       `json:"migration_status"`
+	Indices             []systemFeatureIndexResponse `json:"indices"`
+}
+
+// systemFeatureIndexResponse defines a system index of a feature
+type systemFeatureIndexResponse struct {
+	Index   string `json:"index"`
+	Version string `json:"version"`
+}
@@ -0,0 +1,47 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestSystemFeatures(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_migration/system_features
+	tcs := map[string]string{
+		"7.17.0": `{"features":[{"feature_name":"async_search","minimum_index_version":"7.17.0","migration_status":"NO_MIGRATION_NEEDED","indices":[]},{"feature_name":"kibana","minimum_index_version":"6.8.23","migration_status":"MIGRATION_NEEDED","indices":[{"index":".kibana_1","version":"6.8.23"},{"index":".kibana_task_manager_1","version":"6.8.23"}]},{"feature_name":"tasks","minimum_index_version":"7.17.0","migration_status":"NO_MIGRATION_NEEDED","indices":[{"index":".tasks","version":"7.17.0"}]}],"migration_status":"MIGRATION_NEEDED"}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		sf := NewSystemFeatures(log.NewNopLogger(), http.DefaultClient, u)
+		sfr, err := sf.fetchAndDecodeSystemFeatures()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode system features: %s", err)
+		}
+		t.Logf("[%s] System Features Response: %+v", ver, sfr)
+		if sfr.MigrationStatus != "MIGRATION_NEEDED" {
+			t.Errorf("Wrong overall migration status")
+		}
+		if len(sfr.Features) != 3 {
+			t.Fatalf("Wrong number of features")
+		}
+		kibana := sfr.Features[1]
+		if kibana.FeatureName != "kibana" || kibana.MigrationStatus != "MIGRATION_NEEDED" || len(kibana.Indices) != 2 {
+			t.Errorf("Wrong kibana feature %+v", kibana)
+		}
+	}
+}
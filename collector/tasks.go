@@ -0,0 +1,256 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	defaultTaskLabels = []string{"task_id", "action"}
+
+	// taskActions maps the task actions which report their progress to the action label
+	taskActions = map[string]string{
+		"indices:data/write/reindex":        "reindex",
+		"indices:data/write/update/byquery": "update_by_query",
+		"indices:data/write/delete/byquery": "delete_by_query",
+	}
+)
+
+type taskMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(task taskResponse) float64
+}
+
+// Tasks information struct
+type Tasks struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	taskMetrics []*taskMetric
+}
+
+func init() {
+	registerCollector("tasks", defaultDisabled, "Export progress of running reindex, update by query and delete by query tasks.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewTasks(logger, client, url)
+	})
+}
+
+// NewTasks defines Tasks Prometheus metrics
+func NewTasks(logger log.Logger, client *http.Client, url *url.URL) *Tasks {
+	subsystem := "tasks"
+
+	return &Tasks{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch tasks endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch tasks scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		taskMetrics: []*taskMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "docs"),
+					"Total number of documents the task has to process",
+					defaultTaskLabels, nil,
+				),
+				Value: func(task taskResponse) float64 {
+					return float64(task.Status.Total)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "created_docs_total"),
+					"Number of documents created by the task",
+					defaultTaskLabels, nil,
+				),
+				Value: func(task taskResponse) float64 {
+					return float64(task.Status.Created)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "updated_docs_total"),
+					"Number of documents updated by the task",
+					defaultTaskLabels, nil,
+				),
+				Value: func(task taskResponse) float64 {
+					return float64(task.Status.Updated)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "deleted_docs_total"),
+					"Number of documents deleted by the task",
+					defaultTaskLabels, nil,
+				),
+				Value: func(task taskResponse) float64 {
+					return float64(task.Status.Deleted)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "noop_docs_total"),
+					"Number of documents the task left unchanged",
+					defaultTaskLabels, nil,
+				),
+				Value: func(task taskResponse) float64 {
+					return float64(task.Status.Noops)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "version_conflicts_total"),
+					"Number of version conflicts the task encountered",
+					defaultTaskLabels, nil,
+				),
+				Value: func(task taskResponse) float64 {
+					return float64(task.Status.VersionConflicts)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "batches_total"),
+					"Number of scroll responses pulled back by the task",
+					defaultTaskLabels, nil,
+				),
+				Value: func(task taskResponse) float64 {
+					return float64(task.Status.Batches)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "throttled_seconds_total"),
+					"Time the task was throttled to conform to requests_per_second in seconds",
+					defaultTaskLabels, nil,
+				),
+				Value: func(task taskResponse) float64 {
+					return float64(task.Status.ThrottledMillis) / 1000
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "running_time_seconds"),
+					"Time the task has been running for in seconds",
+					defaultTaskLabels, nil,
+				),
+				Value: func(task taskResponse) float64 {
+					return float64(task.RunningTimeInNanos) / 1e9
+				},
+			},
+		},
+	}
+}
+
+// Describe add Tasks metrics descriptions
+func (t *Tasks) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range t.taskMetrics {
+		ch <- metric.Desc
+	}
+	ch <- t.up.Desc()
+	ch <- t.totalScrapes.Desc()
+	ch <- t.jsonParseFailures.Desc()
+}
+
+func (t *Tasks) fetchAndDecodeTasks() (tasksResponse, error) {
+	var tr tasksResponse
+
+	u := *t.url
+	u.Path = path.Join(u.Path, "/_tasks")
+	u.RawQuery = "detailed=true&actions=*reindex,*byquery"
+
+	res, err := t.client.Get(u.String())
+	if err != nil {
+		return tr, fmt.Errorf("failed to get tasks from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(t.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return tr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&tr); err != nil {
+		t.jsonParseFailures.Inc()
+		return tr, err
+	}
+	return tr, nil
+}
+
+// Collect gets Tasks metric values
+func (t *Tasks) Collect(ch chan<- prometheus.Metric) {
+	t.totalScrapes.Inc()
+	defer func() {
+		ch <- t.up
+		ch <- t.totalScrapes
+		ch <- t.jsonParseFailures
+	}()
+
+	tr, err := t.fetchAndDecodeTasks()
+	if err != nil {
+		t.up.Set(0)
+		_ = level.Warn(t.logger).Log(
+			"msg", "failed to fetch and decode tasks",
+			"err", err,
+		)
+		return
+	}
+	t.up.Set(1)
+
+	for _, node := range tr.Nodes {
+		for taskID, task := range node.Tasks {
+			action, ok := taskActions[task.Action]
+			// the progress of sliced tasks is summed up in their parent
+			if !ok || task.ParentTaskID != "" {
+				continue
+			}
+			for _, metric := range t.taskMetrics {
+				ch <- prometheus.MustNewConstMetric(
+					metric.Desc,
+					metric.Type,
+					metric.Value(task),
+					taskID, action,
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package collector
+
+// tasksResponse is a representation of the Elasticsearch _tasks endpoint
+type tasksResponse struct {
+	Nodes map[string]tasksNodeResponse `json:"nodes"`
+}
+
+// tasksNodeResponse defines the tasks running on a single node, keyed by task id
+type tasksNodeResponse struct {
+	Name  string                  `json:"name"`
+	Tasks map[string]taskResponse `json:"tasks"`
+}
+
+// taskResponse defines a single running task
+type taskResponse struct {
+	Node               string             `json:"node"`
+	ID                 int64              `json:"id"`
+	Type               string             `json:"type"`
+	Action             string             `json:"action"`
+	Status             taskStatusResponse `json:"status"`
+	Description        string             `json:"description"`
+	StartTimeInMillis  int64              `json:"start_time_in_millis"`
+	RunningTimeInNanos int64              `json:"running_time_in_nanos"`
+	Cancellable        bool               `json:"cancellable"`
+	ParentTaskID       string             `json:"parent_task_id"`
+}
+
+// taskStatusResponse defines the progress of a reindex, update by query or delete by query task
+type taskStatusResponse struct {
+	Total            int64 `json:"total"`
+	Updated          int64 `json:"updated"`
+	Created          int64 `json:"created"`
+	Deleted          int64 `json:"deleted"`
+	Batches          int64 `json:"batches"`
+	VersionConflicts int64 `json:"version_conflicts"`
+	Noops            int64 `json:"noops"`
+	ThrottledMillis  int64 `json:"throttled_millis"`
+}
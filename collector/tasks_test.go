@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestTasks(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPOST http://localhost:9200/_reindex?wait_for_completion=false&slices=2&requests_per_second=500 -H "Content-Type: application/json" -d '{"source":{"index":"foo_1"},"dest":{"index":"foo_2"}}'
+	//  curl -XPOST http://localhost:9200/foo_1/_delete_by_query?wait_for_completion=false -H "Content-Type: application/json" -d '{"query":{"match":{"title":"abc"}}}'
+	//  curl http://localhost:9200/_tasks?detailed=true&actions=*reindex,*byquery
+	tcs := map[string]string{
+		"7.10.0": `{"nodes":{"tMTocMvQQgGCkj7QDHl3OA":{"name":"es01","transport_address":"172.17.0.2:9300","host":"172.17.0.2","ip":"172.17.0.2:9300","roles":["data","ingest","master"],"tasks":{"tMTocMvQQgGCkj7QDHl3OA:1024":{"node":"tMTocMvQQgGCkj7QDHl3OA","id":1024,"type":"transport","action":"indices:data/write/reindex","status":{"slice_id":null,"total":20000,"updated":0,"created":6000,"deleted":0,"batches":6,"version_conflicts":3,"noops":0,"retries":{"bulk":0,"search":0},"throttled_millis":5400,"requests_per_second":500.0,"throttled_until_millis":0,"slices":[{"slice_id":0,"total":10000,"updated":0,"created":3000,"deleted":0,"batches":3,"version_conflicts":1,"noops":0,"retries":{"bulk":0,"search":0},"throttled_millis":2700,"requests_per_second":250.0,"throttled_until_millis":0},{"slice_id":1,"total":10000,"updated":0,"created":3000,"deleted":0,"batches":3,"version_conflicts":2,"noops":0,"retries":{"bulk":0,"search":0},"throttled_millis":2700,"requests_per_second":250.0,"throttled_until_millis":0}]},"description":"reindex from [foo_1] to [foo_2][_doc]","start_time_in_millis":1610120231047,"running_time_in_nanos":12841000000,"cancellable":true,"headers":{}},"tMTocMvQQgGCkj7QDHl3OA:1025":{"node":"tMTocMvQQgGCkj7QDHl3OA","id":1025,"type":"transport","action":"indices:data/write/reindex","status":{"slice_id":0,"total":10000,"updated":0,"created":3000,"deleted":0,"batches":3,"version_conflicts":1,"noops":0,"retries":{"bulk":0,"search":0},"throttled_millis":2700,"requests_per_second":250.0,"throttled_until_millis":0},"description":"reindex from [foo_1] to [foo_2][_doc]","start_time_in_millis":1610120231049,"running_time_in_nanos":12839000000,"cancellable":true,"parent_task_id":"tMTocMvQQgGCkj7QDHl3OA:1024","headers":{}},"tMTocMvQQgGCkj7QDHl3OA:1101":{"node":"tMTocMvQQgGCkj7QDHl3OA","id":1101,"type":"transport","action":"indices:data/write/delete/byquery","status":{"total":120,"updated":0,"created":0,"deleted":80,"batches":1,"version_conflicts":0,"noops":0,"retries":{"bulk":0,"search":0},"throttled_millis":0,"requests_per_second":-1.0,"throttled_until_millis":0},"description":"delete-by-query [foo_1]","start_time_in_millis":1610120240112,"running_time_in_nanos":3120000000,"cancellable":true,"headers":{}}}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("actions") != "*reindex,*byquery" {
+				t.Errorf("Tasks should be filtered by action")
+			}
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewTasks(log.NewNopLogger(), http.DefaultClient, u)
+		tr, err := c.fetchAndDecodeTasks()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode tasks: %s", err)
+		}
+		t.Logf("[%s] Tasks Response: %+v", ver, tr)
+		tasks := tr.Nodes["tMTocMvQQgGCkj7QDHl3OA"].Tasks
+		if len(tasks) != 3 {
+			t.Fatalf("Wrong number of tasks")
+		}
+		reindex := tasks["tMTocMvQQgGCkj7QDHl3OA:1024"]
+		if taskActions[reindex.Action] != "reindex" || reindex.ParentTaskID != "" {
+			t.Errorf("Wrong reindex task")
+		}
+		if reindex.Status.Total != 20000 || reindex.Status.Created != 6000 || reindex.Status.VersionConflicts != 3 || reindex.Status.ThrottledMillis != 5400 {
+			t.Errorf("Wrong reindex task status %+v", reindex.Status)
+		}
+		if tasks["tMTocMvQQgGCkj7QDHl3OA:1025"].ParentTaskID != "tMTocMvQQgGCkj7QDHl3OA:1024" {
+			t.Errorf("Reindex slice should have a parent task")
+		}
+		deleteByQuery := tasks["tMTocMvQQgGCkj7QDHl3OA:1101"]
+		if taskActions[deleteByQuery.Action] != "delete_by_query" || deleteByQuery.Status.Deleted != 80 {
+			t.Errorf("Wrong delete by query task")
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// templates combines the legacy, composable index and component templates of the cluster
+type templates struct {
+	Legacy    legacyTemplatesResponse
+	Index     []indexTemplateResponse
+	Component []componentTemplateResponse
+}
+
+// joinIndexPatterns renders index patterns as a stable label value
+func joinIndexPatterns(patterns []string) string {
+	sorted := append([]string(nil), patterns...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// Templates information struct
+type Templates struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	templates    *prometheus.Desc
+	templateInfo *prometheus.Desc
+}
+
+func init() {
+	registerCollector("templates", defaultDisabled, "Export stats for legacy, composable index and component templates.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewTemplates(logger, client, url)
+	})
+}
+
+// NewTemplates defines Templates Prometheus metrics
+func NewTemplates(logger log.Logger, client *http.Client, url *url.URL) *Templates {
+	subsystem := "template"
+
+	return &Templates{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch templates endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch templates scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		templates: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "count"),
+			"Number of templates by type (legacy, index or component)",
+			[]string{"type"}, nil,
+		),
+		templateInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "info"),
+			"Information about a template, with the index patterns it applies to",
+			[]string{"type", "template", "index_patterns"}, nil,
+		),
+	}
+}
+
+// Describe add Templates metrics descriptions
+func (t *Templates) Describe(ch chan<- *prometheus.Desc) {
+	ch <- t.templates
+	ch <- t.templateInfo
+	ch <- t.up.Desc()
+	ch <- t.totalScrapes.Desc()
+	ch <- t.jsonParseFailures.Desc()
+}
+
+func (t *Templates) getAndParseURL(u *url.URL, data interface{}) error {
+	res, err := t.client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(t.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		t.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+func (t *Templates) fetchAndDecodeTemplates() (templates, error) {
+	var tr templates
+
+	u := *t.url
+	u.Path = path.Join(u.Path, "/_template")
+	if err := t.getAndParseURL(&u, &tr.Legacy); err != nil {
+		return tr, err
+	}
+
+	u = *t.url
+	u.Path = path.Join(u.Path, "/_index_template")
+	var itr indexTemplatesResponse
+	if err := t.getAndParseURL(&u, &itr); err != nil {
+		return tr, err
+	}
+	tr.Index = itr.IndexTemplates
+
+	u = *t.url
+	u.Path = path.Join(u.Path, "/_component_template")
+	var ctr componentTemplatesResponse
+	if err := t.getAndParseURL(&u, &ctr); err != nil {
+		return tr, err
+	}
+	tr.Component = ctr.ComponentTemplates
+
+	return tr, nil
+}
+
+// Collect gets Templates metric values
+func (t *Templates) Collect(ch chan<- prometheus.Metric) {
+	t.totalScrapes.Inc()
+	defer func() {
+		ch <- t.up
+		ch <- t.totalScrapes
+		ch <- t.jsonParseFailures
+	}()
+
+	tr, err := t.fetchAndDecodeTemplates()
+	if err != nil {
+		t.up.Set(0)
+		_ = level.Warn(t.logger).Log(
+			"msg", "failed to fetch and decode templates",
+			"err", err,
+		)
+		return
+	}
+	t.up.Set(1)
+
+	ch <- prometheus.MustNewConstMetric(t.templates, prometheus.GaugeValue, float64(len(tr.Legacy)), "legacy")
+	ch <- prometheus.MustNewConstMetric(t.templates, prometheus.GaugeValue, float64(len(tr.Index)), "index")
+	ch <- prometheus.MustNewConstMetric(t.templates, prometheus.GaugeValue, float64(len(tr.Component)), "component")
+
+	for name, template := range tr.Legacy {
+		ch <- prometheus.MustNewConstMetric(
+			t.templateInfo,
+			prometheus.GaugeValue,
+			1,
+			"legacy", name, joinIndexPatterns(template.Patterns()),
+		)
+	}
+	for _, template := range tr.Index {
+		ch <- prometheus.MustNewConstMetric(
+			t.templateInfo,
+			prometheus.GaugeValue,
+			1,
+			"index", template.Name, joinIndexPatterns(template.IndexTemplate.IndexPatterns),
+		)
+	}
+	for _, template := range tr.Component {
+		ch <- prometheus.MustNewConstMetric(
+			t.templateInfo,
+			prometheus.GaugeValue,
+			1,
+			"component", template.Name, "",
+		)
+	}
+}
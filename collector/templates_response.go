@@ -0,0 +1,45 @@
+package collector
+
+// legacyTemplatesResponse is a representation of the Elasticsearch _template endpoint
+type legacyTemplatesResponse map[string]legacyTemplateResponse
+
+// legacyTemplateResponse defines a single legacy index template
+type legacyTemplateResponse struct {
+	Order         int64    `json:"order"`
+	IndexPatterns []string `json:"index_patterns"`
+	// Template holds the single index pattern of templates before 6.0
+	Template string `json:"template"`
+}
+
+// Patterns returns the index patterns of the template, regardless of the version that created it
+func (t legacyTemplateResponse) Patterns() []string {
+	if len(t.IndexPatterns) == 0 && t.Template != "" {
+		return []string{t.Template}
+	}
+	return t.IndexPatterns
+}
+
+// indexTemplatesResponse is a representation of the Elasticsearch _index_template endpoint
+type indexTemplatesResponse struct {
+	IndexTemplates []indexTemplateResponse `json:"index_templates"`
+}
+
+// indexTemplateResponse defines a single composable index template
+type indexTemplateResponse struct {
+	Name          string `json:"name"`
+	IndexTemplate struct {
+		IndexPatterns []string `json:"index_patterns"`
+		ComposedOf    []string `json:"composed_of"`
+		Priority      int64    `json:"priority"`
+	} `json:"index_template"`
+}
+
+// componentTemplatesResponse is a representation of the Elasticsearch _component_template endpoint
+type componentTemplatesResponse struct {
+	ComponentTemplates []componentTemplateResponse `json:"component_templates"`
+}
+
+// componentTemplateResponse defines a single component template
+type componentTemplateResponse struct {
+	Name string `json:"name"`
+}
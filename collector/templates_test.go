@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestTemplates(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_template/legacy -H "Content-Type: application/json" -d '{"index_patterns":["legacy-*"]}'
+	//  curl -XPUT http://localhost:9200/_component_template/mappings -H "Content-Type: application/json" -d '{"template":{"mappings":{"properties":{"@timestamp":{"type":"date"}}}}}'
+	//  curl -XPUT http://localhost:9200/_index_template/logs -H "Content-Type: application/json" -d '{"index_patterns":["logs-*","app-*"],"composed_of":["mappings"],"priority":200}'
+	//  curl http://localhost:9200/_template
+	//  curl http://localhost:9200/_index_template
+	//  curl http://localhost:9200/_component_template
+	tcs := map[string]map[string]string{
+		"7.10.0": {
+			"/_template":           `{"legacy":{"order":0,"index_patterns":["legacy-*"],"settings":{},"mappings":{},"aliases":{}}}`,
+			"/_index_template":     `{"index_templates":[{"name":"logs","index_template":{"index_patterns":["logs-*","app-*"],"composed_of":["mappings"],"priority":200}}]}`,
+			"/_component_template": `{"component_templates":[{"name":"mappings","component_template":{"template":{"mappings":{"properties":{"@timestamp":{"type":"date"}}}}}}]}`,
+		},
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out[r.URL.Path])
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewTemplates(log.NewNopLogger(), http.DefaultClient, u)
+		tr, err := c.fetchAndDecodeTemplates()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode templates: %s", err)
+		}
+		t.Logf("[%s] Templates Response: %+v", ver, tr)
+		if len(tr.Legacy) != 1 || len(tr.Index) != 1 || len(tr.Component) != 1 {
+			t.Fatalf("Wrong number of templates")
+		}
+		if got := joinIndexPatterns(tr.Legacy["legacy"].Patterns()); got != "legacy-*" {
+			t.Errorf("Wrong legacy template index patterns %s", got)
+		}
+		if got := joinIndexPatterns(tr.Index[0].IndexTemplate.IndexPatterns); got != "app-*,logs-*" {
+			t.Errorf("Wrong index template index patterns %s", got)
+		}
+		if tr.Component[0].Name != "mappings" {
+			t.Errorf("Wrong component template name %s", tr.Component[0].Name)
+		}
+	}
+}
+
+func TestLegacyTemplatePatterns(t *testing.T) {
+	// templates created before 6.0 have a single pattern in the template field
+	tpl := legacyTemplateResponse{Template: "logstash-*"}
+	if got := joinIndexPatterns(tpl.Patterns()); got != "logstash-*" {
+		t.Errorf("Wrong index patterns %s", got)
+	}
+}
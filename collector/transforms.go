@@ -0,0 +1,285 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	defaultTransformLabels = []string{"transform"}
+
+	transformStates = []string{"started", "indexing", "aborting", "stopping", "stopped", "failed", "waiting"}
+)
+
+type transformMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(transform transformResponse) float64
+}
+
+// Transforms information struct
+type Transforms struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	transformMetrics []*transformMetric
+	state            *prometheus.Desc
+}
+
+func init() {
+	registerCollector("transforms", defaultDisabled, "Export stats for transforms.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewTransforms(logger, client, url)
+	})
+}
+
+// NewTransforms defines Transforms Prometheus metrics
+func NewTransforms(logger log.Logger, client *http.Client, url *url.URL) *Transforms {
+	subsystem := "transform"
+
+	return &Transforms{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch transform stats endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch transform stats scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		transformMetrics: []*transformMetric{
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "documents_processed_total"),
+					"Total number of documents read from the source indices",
+					defaultTransformLabels, nil,
+				),
+				Value: func(transform transformResponse) float64 {
+					return float64(transform.Stats.DocumentsProcessed)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "documents_indexed_total"),
+					"Total number of documents indexed into the destination index",
+					defaultTransformLabels, nil,
+				),
+				Value: func(transform transformResponse) float64 {
+					return float64(transform.Stats.DocumentsIndexed)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "pages_processed_total"),
+					"Total number of search or bulk index pages processed",
+					defaultTransformLabels, nil,
+				),
+				Value: func(transform transformResponse) float64 {
+					return float64(transform.Stats.PagesProcessed)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "trigger_count_total"),
+					"Total number of times the transform has been triggered",
+					defaultTransformLabels, nil,
+				),
+				Value: func(transform transformResponse) float64 {
+					return float64(transform.Stats.TriggerCount)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "search_failures_total"),
+					"Total number of search failures of the transform",
+					defaultTransformLabels, nil,
+				),
+				Value: func(transform transformResponse) float64 {
+					return float64(transform.Stats.SearchFailures)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_failures_total"),
+					"Total number of indexing failures of the transform",
+					defaultTransformLabels, nil,
+				),
+				Value: func(transform transformResponse) float64 {
+					return float64(transform.Stats.IndexFailures)
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "search_time_seconds_total"),
+					"Total time spent searching the source indices in seconds",
+					defaultTransformLabels, nil,
+				),
+				Value: func(transform transformResponse) float64 {
+					return float64(transform.Stats.SearchTimeInMs) / 1000
+				},
+			},
+			{
+				Type: prometheus.CounterValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "index_time_seconds_total"),
+					"Total time spent indexing into the destination index in seconds",
+					defaultTransformLabels, nil,
+				),
+				Value: func(transform transformResponse) float64 {
+					return float64(transform.Stats.IndexTimeInMs) / 1000
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "checkpoint_operations_behind"),
+					"Number of operations in the source indices which have not been processed by the transform yet",
+					defaultTransformLabels, nil,
+				),
+				Value: func(transform transformResponse) float64 {
+					return float64(transform.Checkpointing.OperationsBehind)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "last_checkpoint"),
+					"Sequence number of the last completed checkpoint",
+					defaultTransformLabels, nil,
+				),
+				Value: func(transform transformResponse) float64 {
+					return float64(transform.Checkpointing.Last.Checkpoint)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "last_checkpoint_timestamp_seconds"),
+					"Timestamp of the last completed checkpoint",
+					defaultTransformLabels, nil,
+				),
+				Value: func(transform transformResponse) float64 {
+					return float64(transform.Checkpointing.Last.TimestampMillis) / 1000
+				},
+			},
+		},
+		state: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "state"),
+			"State of the transform",
+			append(defaultTransformLabels, "state"), nil,
+		),
+	}
+}
+
+// Describe add Transforms metrics descriptions
+func (tr *Transforms) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range tr.transformMetrics {
+		ch <- metric.Desc
+	}
+	ch <- tr.state
+	ch <- tr.up.Desc()
+	ch <- tr.totalScrapes.Desc()
+	ch <- tr.jsonParseFailures.Desc()
+}
+
+func (tr *Transforms) fetchAndDecodeTransformStats() (transformStatsResponse, error) {
+	var tsr transformStatsResponse
+
+	u := *tr.url
+	u.Path = path.Join(u.Path, "/_transform/_stats")
+	u.RawQuery = "size=1000"
+
+	res, err := tr.client.Get(u.String())
+	if err != nil {
+		return tsr, fmt.Errorf("failed to get transform stats from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(tr.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return tsr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&tsr); err != nil {
+		tr.jsonParseFailures.Inc()
+		return tsr, err
+	}
+	return tsr, nil
+}
+
+// Collect gets Transforms metric values
+func (tr *Transforms) Collect(ch chan<- prometheus.Metric) {
+	tr.totalScrapes.Inc()
+	defer func() {
+		ch <- tr.up
+		ch <- tr.totalScrapes
+		ch <- tr.jsonParseFailures
+	}()
+
+	tsr, err := tr.fetchAndDecodeTransformStats()
+	if err != nil {
+		tr.up.Set(0)
+		_ = level.Warn(tr.logger).Log(
+			"msg", "failed to fetch and decode transform stats",
+			"err", err,
+		)
+		return
+	}
+	tr.up.Set(1)
+
+	for _, transform := range tsr.Transforms {
+		for _, metric := range tr.transformMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				metric.Desc,
+				metric.Type,
+				metric.Value(transform),
+				transform.ID,
+			)
+		}
+		for _, state := range transformStates {
+			var value float64
+			if transform.State == state {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				tr.state,
+				prometheus.GaugeValue,
+				value,
+				transform.ID, state,
+			)
+		}
+	}
+}
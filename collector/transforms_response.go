@@ -0,0 +1,46 @@
+package collector
+
+// transformStatsResponse is a representation of the Elasticsearch _transform/_stats endpoint
+type transformStatsResponse struct {
+	Count      int64               `json:"count"`
+	Transforms []transformResponse `json:"transforms"`
+}
+
+// transformResponse defines the stats of a single transform
+type transformResponse struct {
+	ID            string                         `json:"id"`
+	State         string                         `json:"state"`
+	Reason        string                         `json:"reason"`
+	Stats         transformIndexerStatsResponse  `json:"stats"`
+	Checkpointing transformCheckpointingResponse `json:"checkpointing"`
+}
+
+// transformIndexerStatsResponse defines the indexer stats of a transform
+type transformIndexerStatsResponse struct {
+	PagesProcessed     int64 `json:"pages_processed"`
+	DocumentsProcessed int64 `json:"documents_processed"`
+	DocumentsIndexed   int64 `json:"documents_indexed"`
+	DocumentsDeleted   int64 `json:"documents_deleted"`
+	TriggerCount       int64 `json:"trigger_count"`
+	IndexTimeInMs      int64 `json:"index_time_in_ms"`
+	IndexTotal         int64 `json:"index_total"`
+	IndexFailures      int64 `json:"index_failures"`
+	SearchTimeInMs     int64 `json:"search_time_in_ms"`
+	SearchTotal        int64 `json:"search_total"`
+	SearchFailures     int64 `json:"search_failures"`
+	ProcessingTimeInMs int64 `json:"processing_time_in_ms"`
+	ProcessingTotal    int64 `json:"processing_total"`
+}
+
+// transformCheckpointingResponse defines the checkpointing information of a transform
+type transformCheckpointingResponse struct {
+	Last             transformCheckpointResponse `json:"last"`
+	Next             transformCheckpointResponse `json:"next"`
+	OperationsBehind int64                       `json:"operations_behind"`
+}
+
+// transformCheckpointResponse defines a single transform checkpoint
+type transformCheckpointResponse struct {
+	Checkpoint      int64 `json:"checkpoint"`
+	TimestampMillis int64 `json:"timestamp_millis"`
+}
@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestTransforms(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/_transform/ecommerce -d '{"source":{"index":"ecommerce"},"dest":{"index":"ecommerce-by-customer"},"frequency":"1m","sync":{"time":{"field":"order_date"}},"pivot":{"group_by":{"customer_id":{"terms":{"field":"customer_id"}}},"aggregations":{"max_price":{"max":{"field":"taxful_total_price"}}}}}'
+	//  curl -XPOST http://localhost:9200/_transform/ecommerce/_start
+	//  curl http://localhost:9200/_transform/_stats?size=1000
+	tcs := map[string]string{
+		"7.10.0": `{"count":2,"transforms":[{"id":"ecommerce","state":"started","node":{"id":"Bu9Dc0qkQVmPSbRx9B0KjQ","name":"8b3c5c2f6a54","ephemeral_id":"wxg7x0kNQJCgJCtpOgq7BA","transport_address":"172.17.0.2:9300","attributes":{}},"stats":{"pages_processed":12,"documents_processed":4675,"documents_indexed":3321,"trigger_count":3,"index_time_in_ms":950,"index_total":6,"index_failures":0,"search_time_in_ms":148,"search_total":12,"search_failures":1,"processing_time_in_ms":12,"processing_total":12,"exponential_avg_checkpoint_duration_ms":1227.0,"exponential_avg_documents_indexed":3321.0,"exponential_avg_documents_processed":4675.0},"checkpointing":{"last":{"checkpoint":2,"timestamp_millis":1605733345105,"time_upper_bound_millis":1605733285105},"operations_behind":127,"changes_last_detected_at":1605733345099}},{"id":"broken","state":"failed","reason":"task encountered irrecoverable failure","stats":{"pages_processed":0,"documents_processed":0,"documents_indexed":0,"trigger_count":1,"index_time_in_ms":0,"index_total":0,"index_failures":0,"search_time_in_ms":0,"search_total":1,"search_failures":1,"processing_time_in_ms":0,"processing_total":0},"checkpointing":{"last":{"checkpoint":0}}}]}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		tr := NewTransforms(log.NewNopLogger(), http.DefaultClient, u)
+		tsr, err := tr.fetchAndDecodeTransformStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode transform stats: %s", err)
+		}
+		t.Logf("[%s] Transform Stats Response: %+v", ver, tsr)
+		if len(tsr.Transforms) != 2 {
+			t.Fatalf("Wrong number of transforms")
+		}
+		transform := tsr.Transforms[0]
+		if transform.ID != "ecommerce" || transform.State != "started" {
+			t.Errorf("Wrong transform")
+		}
+		if transform.Stats.DocumentsProcessed != 4675 || transform.Stats.SearchFailures != 1 {
+			t.Errorf("Wrong transform stats")
+		}
+		if transform.Checkpointing.OperationsBehind != 127 || transform.Checkpointing.Last.Checkpoint != 2 {
+			t.Errorf("Wrong transform checkpointing")
+		}
+		if tsr.Transforms[1].State != "failed" {
+			t.Errorf("Transform broken should be failed")
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type vectorSearchFieldMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(fieldType clusterStatsFieldTypeResponse) float64
+}
+
+// VectorSearch information struct
+type VectorSearch struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	fieldMetrics []*vectorSearchFieldMetric
+
+	indexTypeFields *prometheus.Desc
+	knnQueries      *prometheus.Desc
+	knnSections     *prometheus.Desc
+}
+
+func init() {
+	registerCollector("vector_search", defaultDisabled, "Export dense_vector field usage and kNN search counts from cluster stats.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewVectorSearch(logger, client, url)
+	})
+}
+
+// NewVectorSearch defines VectorSearch Prometheus metrics
+func NewVectorSearch(logger log.Logger, client *http.Client, url *url.URL) *VectorSearch {
+	subsystem := "vector_search"
+
+	return &VectorSearch{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch vector search cluster stats endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch vector search cluster stats scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		fieldMetrics: []*vectorSearchFieldMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "dense_vector_fields"),
+					"Number of dense_vector fields in the mappings of the cluster",
+					nil, nil,
+				),
+				Value: func(fieldType clusterStatsFieldTypeResponse) float64 {
+					return float64(fieldType.Count)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "dense_vector_indices"),
+					"Number of indices with dense_vector fields",
+					nil, nil,
+				),
+				Value: func(fieldType clusterStatsFieldTypeResponse) float64 {
+					return float64(fieldType.IndexCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "dense_vector_indexed_fields"),
+					"Number of dense_vector fields which are indexed for kNN search",
+					nil, nil,
+				),
+				Value: func(fieldType clusterStatsFieldTypeResponse) float64 {
+					return float64(fieldType.IndexedVectorCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "dense_vector_indexed_dims_max"),
+					"Largest number of dimensions of an indexed dense_vector field",
+					nil, nil,
+				),
+				Value: func(fieldType clusterStatsFieldTypeResponse) float64 {
+					return float64(fieldType.IndexedVectorDimMax)
+				},
+			},
+		},
+		indexTypeFields: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "dense_vector_index_type_fields"),
+			"Number of dense_vector fields by the type of their vector index",
+			[]string{"index_type"}, nil,
+		),
+		knnQueries: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "knn_queries_total"),
+			"Number of search requests using a knn query",
+			nil, nil,
+		),
+		knnSections: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "knn_sections_total"),
+			"Number of search requests using a top-level knn section",
+			nil, nil,
+		),
+	}
+}
+
+// Describe add VectorSearch metrics descriptions
+func (v *VectorSearch) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range v.fieldMetrics {
+		ch <- metric.Desc
+	}
+	ch <- v.indexTypeFields
+	ch <- v.knnQueries
+	ch <- v.knnSections
+	ch <- v.up.Desc()
+	ch <- v.totalScrapes.Desc()
+	ch <- v.jsonParseFailures.Desc()
+}
+
+func (v *VectorSearch) fetchAndDecodeClusterStats() (clusterStatsResponse, error) {
+	var csr clusterStatsResponse
+
+	u := *v.url
+	u.Path = path.Join(u.Path, "/_cluster/stats")
+	u.RawQuery = "filter_path=cluster_name,indices.mappings.field_types,indices.search"
+
+	res, err := v.client.Get(u.String())
+	if err != nil {
+		return csr, fmt.Errorf("failed to get vector search cluster stats from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(v.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return csr, fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&csr); err != nil {
+		v.jsonParseFailures.Inc()
+		return csr, err
+	}
+	return csr, nil
+}
+
+// Collect gets VectorSearch metric values
+func (v *VectorSearch) Collect(ch chan<- prometheus.Metric) {
+	v.totalScrapes.Inc()
+	defer func() {
+		ch <- v.up
+		ch <- v.totalScrapes
+		ch <- v.jsonParseFailures
+	}()
+
+	csr, err := v.fetchAndDecodeClusterStats()
+	if err != nil {
+		v.up.Set(0)
+		_ = level.Warn(v.logger).Log(
+			"msg", "failed to fetch and decode vector search cluster stats",
+			"err", err,
+		)
+		return
+	}
+	v.up.Set(1)
+
+	var denseVector clusterStatsFieldTypeResponse
+	for _, fieldType := range csr.Indices.Mappings.FieldTypes {
+		if fieldType.Name == "dense_vector" {
+			denseVector = fieldType
+		}
+	}
+	for _, metric := range v.fieldMetrics {
+		ch <- prometheus.MustNewConstMetric(
+			metric.Desc,
+			metric.Type,
+			metric.Value(denseVector),
+		)
+	}
+	for indexType, count := range denseVector.VectorIndexTypeCount {
+		ch <- prometheus.MustNewConstMetric(
+			v.indexTypeFields,
+			prometheus.GaugeValue,
+			float64(count),
+			indexType,
+		)
+	}
+
+	// the search usage is only part of the cluster stats since 8.16
+	if csr.Indices.Search.Queries != nil {
+		ch <- prometheus.MustNewConstMetric(
+			v.knnQueries,
+			prometheus.CounterValue,
+			float64(csr.Indices.Search.Queries["knn"]),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			v.knnSections,
+			prometheus.CounterValue,
+			float64(csr.Indices.Search.Sections["knn"]),
+		)
+	}
+}
@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestVectorSearch(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl -XPUT http://localhost:9200/foo_1 -H "Content-Type: application/json" -d '{"mappings":{"properties":{"title_vector":{"type":"dense_vector","dims":384,"index":true},"body_vector":{"type":"dense_vector","dims":768,"index":true,"index_options":{"type":"int8_hnsw"}}}}}'
+	//  curl -XPOST http://localhost:9200/foo_1/_search -H "Content-Type: application/json" -d '{"knn":{"field":"title_vector","query_vector":[...],"k":10,"num_candidates":100}}'
+	//  curl http://localhost:9200/_cluster/stats?filter_path=cluster_name,indices.mappings.field_types,indices.search
+	tcs := map[string]string{
+		"8.16.0": `{"cluster_name":"docker-cluster","indices":{"mappings":{"field_types":[{"name":"dense_vector","count":2,"index_count":1,"script_count":0,"indexed_vector_count":2,"indexed_vector_dim_min":384,"indexed_vector_dim_max":768,"vector_index_type_count":{"hnsw":1,"int8_hnsw":1},"vector_similarity_type_count":{"cosine":2},"vector_element_type_count":{"float":2}},{"name":"keyword","count":4,"index_count":2,"script_count":0}]},"search":{"total":25,"queries":{"knn":3,"match_all":10},"rescorers":{},"sections":{"knn":12,"query":13},"retrievers":{}}}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		c := NewVectorSearch(log.NewNopLogger(), http.DefaultClient, u)
+		csr, err := c.fetchAndDecodeClusterStats()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode cluster stats: %s", err)
+		}
+		t.Logf("[%s] Cluster Stats Response: %+v", ver, csr)
+		fieldTypes := csr.Indices.Mappings.FieldTypes
+		if len(fieldTypes) != 2 || fieldTypes[0].Name != "dense_vector" {
+			t.Fatalf("Wrong field types")
+		}
+		if dv := fieldTypes[0]; dv.Count != 2 || dv.IndexedVectorCount != 2 || dv.IndexedVectorDimMax != 768 || dv.VectorIndexTypeCount["int8_hnsw"] != 1 {
+			t.Errorf("Wrong dense_vector usage %+v", dv)
+		}
+		if csr.Indices.Search.Queries["knn"] != 3 || csr.Indices.Search.Sections["knn"] != 12 {
+			t.Errorf("Wrong knn search usage")
+		}
+	}
+}
@@ -0,0 +1,401 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// bool2Float converts a boolean to a gauge value
+func bool2Float(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// xpackUsage combines the feature flags and the key counters of the _xpack/usage endpoint
+type xpackUsage struct {
+	Features map[string]xpackFeatureResponse
+	Counters xpackUsageResponse
+}
+
+type xpackUsageMetric struct {
+	Type  prometheus.ValueType
+	Desc  *prometheus.Desc
+	Value func(usage xpackUsageResponse) float64
+}
+
+// XPackUsage information struct
+type XPackUsage struct {
+	logger log.Logger
+	client *http.Client
+	url    *url.URL
+
+	up                              prometheus.Gauge
+	totalScrapes, jsonParseFailures prometheus.Counter
+
+	available    *prometheus.Desc
+	enabled      *prometheus.Desc
+	usageMetrics []*xpackUsageMetric
+
+	securityRealmEnabled *prometheus.Desc
+	securityRealms       *prometheus.Desc
+	securityRoles        *prometheus.Desc
+	securityRoleMappings *prometheus.Desc
+}
+
+func init() {
+	registerCollector("xpack_usage", defaultDisabled, "Export X-Pack feature usage.", func(logger log.Logger, client *http.Client, url *url.URL) prometheus.Collector {
+		return NewXPackUsage(logger, client, url)
+	})
+}
+
+// NewXPackUsage defines X-Pack Usage Prometheus metrics
+func NewXPackUsage(logger log.Logger, client *http.Client, url *url.URL) *XPackUsage {
+	subsystem := "xpack"
+
+	return &XPackUsage{
+		logger: logger,
+		client: client,
+		url:    url,
+
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "up"),
+			Help: "Was the last scrape of the ElasticSearch X-Pack usage endpoint successful.",
+		}),
+		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "total_scrapes"),
+			Help: "Current total ElasticSearch X-Pack usage scrapes.",
+		}),
+		jsonParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: prometheus.BuildFQName(namespace, subsystem, "json_parse_failures"),
+			Help: "Number of errors while parsing JSON.",
+		}),
+		available: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "feature_available"),
+			"Whether the X-Pack feature is available with the current license",
+			[]string{"feature"}, nil,
+		),
+		enabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "feature_enabled"),
+			"Whether the X-Pack feature is enabled",
+			[]string{"feature"}, nil,
+		),
+		securityRealmEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "security_realm_enabled"),
+			"Whether a realm of the realm type is enabled",
+			[]string{"type"}, nil,
+		),
+		securityRealms: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "security_realms"),
+			"Number of configured realms of the realm type",
+			[]string{"type"}, nil,
+		),
+		securityRoles: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "security_roles"),
+			"Number of roles defined in the role store",
+			[]string{"store"}, nil,
+		),
+		securityRoleMappings: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "security_role_mappings"),
+			"Number of role mappings defined in the role mapping store",
+			[]string{"store"}, nil,
+		),
+		usageMetrics: []*xpackUsageMetric{
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "ml_jobs"),
+					"Number of machine learning anomaly detection jobs",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return float64(usage.ML.Jobs.All.Count)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "ml_datafeeds"),
+					"Number of machine learning datafeeds",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return float64(usage.ML.Datafeeds.All.Count)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "transforms"),
+					"Number of transforms",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return float64(usage.Transform.Transforms.All)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "watcher_watches"),
+					"Number of watches",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return float64(usage.Watcher.Count.Total)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "watcher_active_watches"),
+					"Number of active watches",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return float64(usage.Watcher.Count.Active)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "ilm_policies"),
+					"Number of index lifecycle management policies",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return float64(usage.ILM.PolicyCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "slm_policies"),
+					"Number of snapshot lifecycle management policies",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return float64(usage.SLM.PolicyCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "data_streams"),
+					"Number of data streams",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return float64(usage.DataStreams.DataStreams)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "searchable_snapshots_full_copy_indices"),
+					"Number of fully mounted searchable snapshot indices, as used by the cold tier",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return float64(usage.SearchableSnapshots.FullCopyIndicesCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "searchable_snapshots_shared_cache_indices"),
+					"Number of partially mounted searchable snapshot indices backed by the shared cache, as used by the frozen tier",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return float64(usage.SearchableSnapshots.SharedCacheIndicesCount)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "security_token_service_enabled"),
+					"Whether the token service issuing access and refresh tokens is enabled",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return bool2Float(usage.Security.TokenService.Enabled)
+				},
+			},
+			{
+				Type: prometheus.GaugeValue,
+				Desc: prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, subsystem, "security_api_key_service_enabled"),
+					"Whether the API key service is enabled",
+					nil, nil,
+				),
+				Value: func(usage xpackUsageResponse) float64 {
+					return bool2Float(usage.Security.APIKeyService.Enabled)
+				},
+			},
+		},
+	}
+}
+
+// Describe add X-Pack Usage metrics descriptions
+func (x *XPackUsage) Describe(ch chan<- *prometheus.Desc) {
+	ch <- x.available
+	ch <- x.enabled
+	ch <- x.securityRealmEnabled
+	ch <- x.securityRealms
+	ch <- x.securityRoles
+	ch <- x.securityRoleMappings
+	for _, metric := range x.usageMetrics {
+		ch <- metric.Desc
+	}
+	ch <- x.up.Desc()
+	ch <- x.totalScrapes.Desc()
+	ch <- x.jsonParseFailures.Desc()
+}
+
+func (x *XPackUsage) getAndParseURL(u *url.URL, data interface{}) error {
+	res, err := x.client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to get from %s://%s:%s%s: %s",
+			u.Scheme, u.Hostname(), u.Port(), u.Path, err)
+	}
+
+	defer func() {
+		err = res.Body.Close()
+		if err != nil {
+			_ = level.Warn(x.logger).Log(
+				"msg", "failed to close http.Client",
+				"err", err,
+			)
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Request failed with code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		x.jsonParseFailures.Inc()
+		return err
+	}
+	return nil
+}
+
+func (x *XPackUsage) fetchAndDecodeXPackUsage() (xpackUsage, error) {
+	var usage xpackUsage
+
+	u := *x.url
+	u.Path = path.Join(u.Path, "/_xpack/usage")
+	var raw json.RawMessage
+	if err := x.getAndParseURL(&u, &raw); err != nil {
+		return usage, err
+	}
+
+	var features map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &features); err != nil {
+		x.jsonParseFailures.Inc()
+		return usage, err
+	}
+	usage.Features = make(map[string]xpackFeatureResponse, len(features))
+	for name, feature := range features {
+		var xfr xpackFeatureResponse
+		// entries which are not feature objects can't be reported
+		if err := json.Unmarshal(feature, &xfr); err != nil {
+			continue
+		}
+		usage.Features[name] = xfr
+	}
+
+	if err := json.Unmarshal(raw, &usage.Counters); err != nil {
+		x.jsonParseFailures.Inc()
+		return usage, err
+	}
+	return usage, nil
+}
+
+// Collect gets X-Pack Usage metric values
+func (x *XPackUsage) Collect(ch chan<- prometheus.Metric) {
+	x.totalScrapes.Inc()
+	defer func() {
+		ch <- x.up
+		ch <- x.totalScrapes
+		ch <- x.jsonParseFailures
+	}()
+
+	usage, err := x.fetchAndDecodeXPackUsage()
+	if err != nil {
+		x.up.Set(0)
+		_ = level.Warn(x.logger).Log(
+			"msg", "failed to fetch and decode X-Pack usage",
+			"err", err,
+		)
+		return
+	}
+	x.up.Set(1)
+
+	for name, feature := range usage.Features {
+		ch <- prometheus.MustNewConstMetric(
+			x.available,
+			prometheus.GaugeValue,
+			bool2Float(feature.Available),
+			name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			x.enabled,
+			prometheus.GaugeValue,
+			bool2Float(feature.Enabled),
+			name,
+		)
+	}
+	for _, metric := range x.usageMetrics {
+		ch <- prometheus.MustNewConstMetric(
+			metric.Desc,
+			metric.Type,
+			metric.Value(usage.Counters),
+		)
+	}
+	// realm, role and role mapping usage is only reported while security is enabled
+	security := usage.Counters.Security
+	for realm, stats := range security.Realms {
+		ch <- prometheus.MustNewConstMetric(
+			x.securityRealmEnabled,
+			prometheus.GaugeValue,
+			bool2Float(stats.Enabled),
+			realm,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			x.securityRealms,
+			prometheus.GaugeValue,
+			float64(len(stats.Name)),
+			realm,
+		)
+	}
+	for store, stats := range security.Roles {
+		// the document level security bit set cache is reported next to the role stores
+		if store == "dls" {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			x.securityRoles,
+			prometheus.GaugeValue,
+			float64(stats.Size),
+			store,
+		)
+	}
+	for store, stats := range security.RoleMapping {
+		ch <- prometheus.MustNewConstMetric(
+			x.securityRoleMappings,
+			prometheus.GaugeValue,
+			float64(stats.Size),
+			store,
+		)
+	}
+}
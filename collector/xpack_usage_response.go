@@ -0,0 +1,71 @@
+package collector
+
+// xpackFeatureResponse defines the availability of a single X-Pack feature in the _xpack/usage endpoint
+type xpackFeatureResponse struct {
+	Available bool `json:"available"`
+	Enabled   bool `json:"enabled"`
+}
+
+// xpackSecurityRealmResponse defines the usage of a single realm type in the _xpack/usage endpoint
+type xpackSecurityRealmResponse struct {
+	Available bool     `json:"available"`
+	Enabled   bool     `json:"enabled"`
+	Name      []string `json:"name"`
+}
+
+// xpackSecurityStoreResponse defines the number of roles or role mappings of a single store in the _xpack/usage endpoint
+type xpackSecurityStoreResponse struct {
+	Size int64 `json:"size"`
+}
+
+// xpackUsageResponse is a representation of the key counters of the Elasticsearch _xpack/usage endpoint
+type xpackUsageResponse struct {
+	ML struct {
+		Jobs struct {
+			All struct {
+				Count int64 `json:"count"`
+			} `json:"_all"`
+		} `json:"jobs"`
+		Datafeeds struct {
+			All struct {
+				Count int64 `json:"count"`
+			} `json:"_all"`
+		} `json:"datafeeds"`
+	} `json:"ml"`
+	Transform struct {
+		Transforms struct {
+			All int64 `json:"_all"`
+		} `json:"transforms"`
+	} `json:"transform"`
+	Watcher struct {
+		Count struct {
+			Total  int64 `json:"total"`
+			Active int64 `json:"active"`
+		} `json:"count"`
+	} `json:"watcher"`
+	ILM struct {
+		PolicyCount int64 `json:"policy_count"`
+	} `json:"ilm"`
+	SLM struct {
+		PolicyCount int64 `json:"policy_count"`
+	} `json:"slm"`
+	DataStreams struct {
+		DataStreams int64 `json:"data_streams"`
+	} `json:"data_streams"`
+	Security struct {
+		Realms       map[string]xpackSecurityRealmResponse `json:"realms"`
+		Roles        map[string]xpackSecurityStoreResponse `json:"roles"`
+		RoleMapping  map[string]xpackSecurityStoreResponse `json:"role_mapping"`
+		TokenService struct {
+			Enabled bool `json:"enabled"`
+		} `json:"token_service"`
+		APIKeyService struct {
+			Enabled bool `json:"enabled"`
+		} `json:"api_key_service"`
+	} `json:"security"`
+	SearchableSnapshots struct {
+		IndicesCount            int64 `json:"indices_count"`
+		FullCopyIndicesCount    int64 `json:"full_copy_indices_count"`
+		SharedCacheIndicesCount int64 `json:"shared_cache_indices_count"`
+	} `json:"searchable_snapshots"`
+}
@@ -0,0 +1,114 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestXPackUsage(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 elasticsearch:VERSION
+	//  curl http://localhost:9200/_xpack/usage
+	tcs := map[string]string{
+		"7.10.0": `{"security":{"available":true,"enabled":false},"monitoring":{"available":true,"enabled":true,"collection_enabled":false,"enabled_exporters":{}},"watcher":{"available":true,"enabled":true,"execution":{"actions":{}},"watch":{"input":{},"trigger":{}},"count":{"total":3,"active":2}},"ml":{"available":true,"enabled":true,"jobs":{"_all":{"count":4,"detectors":{"total":4.0,"min":1.0,"avg":1.0,"max":1.0}},"opened":{"count":2}},"datafeeds":{"_all":{"count":3}},"data_frame_analytics_jobs":{"_all":{"count":0}},"inference":{},"node_count":1},"transform":{"available":true,"enabled":true,"transforms":{"_all":5,"started":4,"failed":1}},"ilm":{"policy_count":12,"policy_stats":[]},"slm":{"available":true,"enabled":true,"policy_count":2},"data_streams":{"available":true,"enabled":true,"data_streams":7,"indices_count":14},"voting_only":{"available":true,"enabled":true}}`,
+	}
+	for ver, out := range tcs {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, out)
+		}))
+		defer ts.Close()
+
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL: %s", err)
+		}
+		x := NewXPackUsage(log.NewNopLogger(), http.DefaultClient, u)
+		usage, err := x.fetchAndDecodeXPackUsage()
+		if err != nil {
+			t.Fatalf("Failed to fetch or decode X-Pack usage: %s", err)
+		}
+		t.Logf("[%s] X-Pack Usage Response: %+v", ver, usage)
+		if security := usage.Features["security"]; !security.Available || security.Enabled {
+			t.Errorf("Security should be available but disabled")
+		}
+		if !usage.Features["ml"].Enabled {
+			t.Errorf("ML should be enabled")
+		}
+		if usage.Counters.ML.Jobs.All.Count != 4 || usage.Counters.ML.Datafeeds.All.Count != 3 {
+			t.Errorf("Wrong ML counters")
+		}
+		if usage.Counters.Transform.Transforms.All != 5 || usage.Counters.Watcher.Count.Active != 2 {
+			t.Errorf("Wrong transform or watcher counters")
+		}
+		if usage.Counters.ILM.PolicyCount != 12 || usage.Counters.DataStreams.DataStreams != 7 {
+			t.Errorf("Wrong ILM or data stream counters")
+		}
+	}
+}
+
+func TestXPackUsageSecurity(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e xpack.security.enabled=true elasticsearch:VERSION
+	//  curl -u elastic:changeme http://localhost:9200/_xpack/usage
+	out := `{"security":{"available":true,"enabled":true,"realms":{"file":{"name":["default_file"],"available":true,"size":[0],"enabled":true,"order":[2147483647]},"ldap":{"available":true,"enabled":false},"native":{"name":["default_native"],"available":true,"size":[2],"enabled":true,"order":[2147483647]},"saml":{"available":true,"enabled":false}},"role_mapping":{"native":{"size":3,"enabled":3}},"roles":{"native":{"size":5,"fls":false,"dls":false},"dls":{"bit_set_cache":{"count":0,"memory":"0b","memory_in_bytes":0}},"file":{"size":1,"fls":false,"dls":false}},"ssl":{"http":{"enabled":true},"transport":{"enabled":true}},"token_service":{"enabled":true},"api_key_service":{"enabled":true},"audit":{"enabled":false},"ipfilter":{"http":false,"transport":false},"anonymous":{"enabled":false},"fips_140":{"enabled":false}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	x := NewXPackUsage(log.NewNopLogger(), http.DefaultClient, u)
+	usage, err := x.fetchAndDecodeXPackUsage()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode X-Pack usage: %s", err)
+	}
+	security := usage.Counters.Security
+	if len(security.Realms) != 4 || !security.Realms["native"].Enabled || security.Realms["ldap"].Enabled || len(security.Realms["file"].Name) != 1 {
+		t.Errorf("Wrong security realms %+v", security.Realms)
+	}
+	if security.Roles["native"].Size != 5 || security.Roles["file"].Size != 1 || security.RoleMapping["native"].Size != 3 {
+		t.Errorf("Wrong security roles or role mappings")
+	}
+	if !security.TokenService.Enabled || !security.APIKeyService.Enabled {
+		t.Errorf("Token and API key services should be enabled")
+	}
+}
+
+func TestXPackUsageSearchableSnapshots(t *testing.T) {
+	// Testcases created using:
+	//  docker run -d -p 9200:9200 -e xpack.searchable.snapshot.shared_cache.size=1gb elasticsearch:VERSION
+	//  curl -XPOST http://localhost:9200/_snapshot/repo/snap/_mount?storage=full_copy -H "Content-Type: application/json" -d '{"index":"foo_1"}'
+	//  curl -XPOST http://localhost:9200/_snapshot/repo/snap/_mount?storage=shared_cache -H "Content-Type: application/json" -d '{"index":"foo_2","renamed_index":"partial-foo_2"}'
+	//  curl -XPOST http://localhost:9200/_snapshot/repo/snap/_mount?storage=shared_cache -H "Content-Type: application/json" -d '{"index":"foo_3","renamed_index":"partial-foo_3"}'
+	//  curl http://localhost:9200/_xpack/usage
+	out := `{"searchable_snapshots":{"available":true,"enabled":true,"indices_count":3,"full_copy_indices_count":1,"shared_cache_indices_count":2}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, out)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %s", err)
+	}
+	x := NewXPackUsage(log.NewNopLogger(), http.DefaultClient, u)
+	usage, err := x.fetchAndDecodeXPackUsage()
+	if err != nil {
+		t.Fatalf("Failed to fetch or decode X-Pack usage: %s", err)
+	}
+	searchableSnapshots := usage.Counters.SearchableSnapshots
+	if searchableSnapshots.IndicesCount != 3 || searchableSnapshots.FullCopyIndicesCount != 1 || searchableSnapshots.SharedCacheIndicesCount != 2 {
+		t.Errorf("Wrong searchable snapshots counters")
+	}
+	if !usage.Features["searchable_snapshots"].Enabled {
+		t.Errorf("Searchable snapshots should be enabled")
+	}
+}
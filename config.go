@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/justwatchcom/elasticsearch_exporter/collector"
+	"github.com/justwatchcom/elasticsearch_exporter/pkg/clusterinfo"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v2"
+)
+
+// configClusterLabel is added to all metrics of a cluster defined in the configuration file
+const configClusterLabel = "es_cluster"
+
+// exporterConfig is the representation of the configuration file passed with --config.file
+type exporterConfig struct {
+	Clusters []clusterConfig `yaml:"clusters"`
+}
+
+// clusterConfig defines the connection to a single cluster and the collectors enabled for it
+type clusterConfig struct {
+	Name     string        `yaml:"name"`
+	URI      string        `yaml:"uri"`
+	Username string        `yaml:"username"`
+	Password string        `yaml:"password"`
+	Timeout  time.Duration `yaml:"timeout"`
+	All      bool          `yaml:"all"`
+	Node     string        `yaml:"node"`
+	TLS      struct {
+		CA                 string `yaml:"ca"`
+		ClientCert         string `yaml:"client_cert"`
+		ClientPrivateKey   string `yaml:"client_private_key"`
+		InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+	} `yaml:"tls"`
+	Collectors    []string `yaml:"collectors"`
+	HiddenIndices bool     `yaml:"hidden_indices"`
+}
+
+// loadConfig reads and validates the configuration file, clusters without timeout use the given one
+func loadConfig(file string, timeout time.Duration) (*exporterConfig, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg exporterConfig
+	if err := yaml.UnmarshalStrict(content, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", file, err)
+	}
+	if len(cfg.Clusters) == 0 {
+		return nil, fmt.Errorf("no clusters defined in %s", file)
+	}
+
+	names := make(map[string]bool, len(cfg.Clusters))
+	for i := range cfg.Clusters {
+		cc := &cfg.Clusters[i]
+		if cc.Name == "" {
+			return nil, fmt.Errorf("cluster %d has no name", i)
+		}
+		if names[cc.Name] {
+			return nil, fmt.Errorf("cluster %s is defined more than once", cc.Name)
+		}
+		names[cc.Name] = true
+		if cc.URI == "" {
+			return nil, fmt.Errorf("cluster %s has no uri", cc.Name)
+		}
+		if _, err := url.Parse(cc.URI); err != nil {
+			return nil, fmt.Errorf("cluster %s has no valid uri: %s", cc.Name, err)
+		}
+		if cc.Timeout == 0 {
+			cc.Timeout = timeout
+		}
+		if cc.Node == "" {
+			cc.Node = "_local"
+		}
+	}
+	return &cfg, nil
+}
+
+// registerCluster creates the collectors enabled for a cluster and registers them with the name of the cluster as label.
+// The returned transport is used by all collectors of the cluster
+func registerCluster(ctx context.Context, logger log.Logger, reg prometheus.Registerer, cc clusterConfig, clusterInfoInterval time.Duration) (*http.Transport, error) {
+	logger = log.With(logger, configClusterLabel, cc.Name)
+	reg = prometheus.WrapRegistererWith(prometheus.Labels{configClusterLabel: cc.Name}, reg)
+
+	esURL, err := url.Parse(cc.URI)
+	if err != nil {
+		return nil, err
+	}
+	if cc.Username != "" {
+		esURL.User = url.UserPassword(cc.Username, cc.Password)
+	}
+
+	tlsConfig, err := createTLSConfig(cc.TLS.CA, cc.TLS.ClientCert, cc.TLS.ClientPrivateKey, cc.TLS.InsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{
+		TLSClientConfig: tlsConfig,
+		Proxy:           http.ProxyFromEnvironment,
+	}
+	httpClient := &http.Client{
+		Timeout:   cc.Timeout,
+		Transport: transport,
+	}
+
+	clusterInfoRetriever := clusterinfo.New(logger, httpClient, esURL, clusterInfoInterval)
+
+	collectors := []prometheus.Collector{
+		collector.NewClusterHealth(logger, httpClient, esURL),
+		collector.NewNodes(logger, httpClient, esURL, cc.All, cc.Node),
+		clusterInfoRetriever,
+	}
+	// the indices and shards collectors include hidden indices per cluster, all others are taken from the collector registry
+	var indices, shards bool
+	var names []string
+	for _, name := range cc.Collectors {
+		switch name {
+		case "indices":
+			indices = true
+		case "shards":
+			shards = true
+		case "repository_cleanup":
+			// the cleanup endpoint is not bound to a cluster
+			return nil, fmt.Errorf("collector %s is only available without config.file", name)
+		default:
+			names = append(names, name)
+		}
+	}
+	// without names the collectors enabled by their flags would be created
+	if len(names) > 0 {
+		registered, err := collector.NewCollectors(logger, httpClient, esURL, names...)
+		if err != nil {
+			return nil, err
+		}
+		collectors = append(collectors, registered...)
+	}
+	if indices || shards {
+		collectors = append(collectors, collector.NewIndices(logger, httpClient, esURL, shards, cc.HiddenIndices))
+	}
+
+	var runners []runner
+	for _, c := range collectors {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+		if consumer, ok := c.(clusterInfoConsumer); ok {
+			if err := clusterInfoRetriever.RegisterConsumer(consumer); err != nil {
+				return nil, err
+			}
+		}
+		if r, ok := c.(runner); ok {
+			runners = append(runners, r)
+		}
+	}
+	// the loops of the periodic collectors stop with the configuration
+	for _, r := range runners {
+		r.Run(ctx)
+	}
+
+	// the retrievers of all clusters start concurrently, an unreachable cluster must not delay the others
+	go func() {
+		switch runErr := clusterInfoRetriever.Run(ctx); runErr {
+		case nil:
+			_ = level.Info(logger).Log(
+				"msg", "started cluster info retriever",
+				"interval", clusterInfoInterval.String(),
+			)
+		case clusterinfo.ErrInitialCallTimeout:
+			_ = level.Info(logger).Log("msg", "initial cluster info call timed out")
+		default:
+			_ = level.Error(logger).Log("msg", "failed to run cluster info retriever", "err", runErr)
+		}
+	}()
+	return transport, nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// writeConfig writes the configuration file to dir and returns its path
+func writeConfig(t *testing.T, dir, content string) string {
+	file := filepath.Join(dir, "config.yml")
+	if err := ioutil.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %s", err)
+	}
+	return file
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elasticsearch_exporter")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg, err := loadConfig(writeConfig(t, dir, `
+clusters:
+  - name: logging
+    uri: http://logging:9200
+    hidden_indices: true
+    collectors: [indices, snapshots]
+  - name: search
+    uri: http://search:9200
+    timeout: 10s
+    node: search-1
+`), 7*time.Second)
+	if err != nil {
+		t.Fatalf("Failed to load config: %s", err)
+	}
+	if len(cfg.Clusters) != 2 {
+		t.Fatalf("Wrong number of clusters %d", len(cfg.Clusters))
+	}
+	logging, search := cfg.Clusters[0], cfg.Clusters[1]
+	if logging.Timeout != 7*time.Second || logging.Node != "_local" || !logging.HiddenIndices || len(logging.Collectors) != 2 {
+		t.Errorf("Wrong defaults of cluster logging %+v", logging)
+	}
+	if search.Timeout != 10*time.Second || search.Node != "search-1" || search.HiddenIndices {
+		t.Errorf("Wrong settings of cluster search %+v", search)
+	}
+
+	tcs := map[string]struct {
+		content string
+		err     string
+	}{
+		"empty": {
+			content: `clusters: []`,
+			err:     "no clusters defined",
+		},
+		"no name": {
+			content: `
+clusters:
+  - uri: http://logging:9200
+`,
+			err: "cluster 0 has no name",
+		},
+		"duplicate name": {
+			content: `
+clusters:
+  - name: logging
+    uri: http://logging:9200
+  - name: logging
+    uri: http://search:9200
+`,
+			err: "cluster logging is defined more than once",
+		},
+		"no uri": {
+			content: `
+clusters:
+  - name: logging
+`,
+			err: "cluster logging has no uri",
+		},
+		"bad uri": {
+			content: `
+clusters:
+  - name: logging
+    uri: "http://logging:port"
+`,
+			err: "cluster logging has no valid uri",
+		},
+		"unknown key": {
+			content: `
+clusters:
+  - name: logging
+    uri: http://logging:9200
+    colectors: [snapshots]
+`,
+			err: "field colectors not found",
+		},
+	}
+	for name, tc := range tcs {
+		_, err := loadConfig(writeConfig(t, dir, tc.content), 5*time.Second)
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("[%s] Wrong error %v, expected %q", name, err, tc.err)
+		}
+	}
+}
+
+func TestRegisterCluster(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cc := clusterConfig{
+		Name:       "logging",
+		URI:        ts.URL,
+		Timeout:    time.Second,
+		Node:       "_local",
+		Collectors: []string{"indices", "snapshots", "search_probe"},
+	}
+	reg := prometheus.NewRegistry()
+	if _, err := registerCluster(ctx, log.NewNopLogger(), reg, cc, time.Hour); err != nil {
+		t.Fatalf("Failed to register cluster: %s", err)
+	}
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %s", err)
+	}
+	names := make(map[string]bool, len(mfs))
+	for _, mf := range mfs {
+		names[mf.GetName()] = true
+		for _, m := range mf.GetMetric() {
+			ok := false
+			for _, l := range m.GetLabel() {
+				ok = ok || l.GetName() == configClusterLabel && l.GetValue() == "logging"
+			}
+			if !ok {
+				t.Errorf("Metric %s without %s label", mf.GetName(), configClusterLabel)
+			}
+		}
+	}
+	for _, name := range []string{
+		"elasticsearch_cluster_health_up",
+		"elasticsearch_node_stats_up",
+		"elasticsearch_index_stats_up",
+		"elasticsearch_snapshot_stats_up",
+		"elasticsearch_search_probe_up",
+	} {
+		if !names[name] {
+			t.Errorf("Missing metric %s", name)
+		}
+	}
+	if names["elasticsearch_clustersettings_stats_up"] {
+		t.Errorf("Collector cluster_settings should not be registered")
+	}
+
+	cc.Name = "search"
+	cc.Collectors = []string{"unknown"}
+	if _, err := registerCluster(ctx, log.NewNopLogger(), prometheus.NewRegistry(), cc, time.Hour); err == nil {
+		t.Errorf("Unknown collector should fail")
+	}
+
+	cc.Collectors = []string{"repository_cleanup"}
+	if _, err := registerCluster(ctx, log.NewNopLogger(), prometheus.NewRegistry(), cc, time.Hour); err == nil {
+		t.Errorf("Repository cleanup should not be available per cluster")
+	}
+
+	cc.Collectors = nil
+	cc.TLS.CA = "/nonexistent/ca.pem"
+	if _, err := registerCluster(ctx, log.NewNopLogger(), prometheus.NewRegistry(), cc, time.Hour); err == nil {
+		t.Errorf("Unreadable CA should fail")
+	}
+}
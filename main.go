@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/justwatchcom/elasticsearch_exporter/collector"
 	"github.com/justwatchcom/elasticsearch_exporter/pkg/clusterinfo"
@@ -26,6 +29,12 @@ func main() {
 		metricsPath = kingpin.Flag("web.telemetry-path",
 			"Path under which to expose metrics.").
 			Default("/metrics").Envar("WEB_TELEMETRY_PATH").String()
+		webReloadToken = kingpin.Flag("web.reload-token",
+			"Bearer token required to reload the config.file through POST /-/reload and to trigger POST /-/snapshot_repository_cleanup, both endpoints are disabled without a token.").
+			Default("").Envar("WEB_RELOAD_TOKEN").String()
+		configFile = kingpin.Flag("config.file",
+			"Path to a YAML file defining the clusters to export. If set, es.uri, the TLS flags and the collector flags are ignored.").
+			Default("").Envar("CONFIG_FILE").String()
 		esURI = kingpin.Flag("es.uri",
 			"HTTP API address of an Elasticsearch node.").
 			Default("http://localhost:9200").Envar("ES_URI").String()
@@ -38,24 +47,6 @@ func main() {
 		esNode = kingpin.Flag("es.node",
 			"Node's name of which metrics should be exposed.").
 			Default("_local").Envar("ES_NODE").String()
-		esExportIndices = kingpin.Flag("es.indices",
-			"Export stats for indices in the cluster.").
-			Default("false").Envar("ES_INDICES").Bool()
-		esExportIndicesSettings = kingpin.Flag("es.indices_settings",
-			"Export stats for settings of all indices of the cluster.").
-			Default("false").Envar("ES_INDICES_SETTINGS").Bool()
-		esExportRemoteInfo = kingpin.Flag("es.remote_info",
-			"Export stats associated with configured remote clusters.").
-			Default("false").Envar("ES_REMOTE_INFO").Bool()
-		esExportClusterSettings = kingpin.Flag("es.cluster_settings",
-			"Export stats for cluster settings.").
-			Default("false").Envar("ES_CLUSTER_SETTINGS").Bool()
-		esExportShards = kingpin.Flag("es.shards",
-			"Export stats for shards in the cluster (implies --es.indices).").
-			Default("false").Envar("ES_SHARDS").Bool()
-		esExportSnapshots = kingpin.Flag("es.snapshots",
-			"Export stats for the cluster snapshots.").
-			Default("false").Envar("ES_SNAPSHOTS").Bool()
 		esClusterInfoInterval = kingpin.Flag("es.clusterinfo.interval",
 			"Cluster info update interval for the cluster label").
 			Default("5m").Envar("ES_CLUSTERINFO_INTERVAL").Duration()
@@ -88,6 +79,36 @@ func main() {
 
 	logger := getLogger(*logLevel, *logOutput, *logFormat)
 
+	// version metric
+	versionMetric := version.NewCollector(Name)
+	prometheus.MustRegister(versionMetric)
+
+	// with a configuration file the clusters and their collectors are defined by the file instead of the flags
+	if *configFile != "" {
+		clusters := newClusters(logger, *configFile, *esTimeout, *esClusterInfoInterval)
+		prometheus.MustRegister(clusters)
+		if err := clusters.reload(); err != nil {
+			os.Exit(1)
+		}
+
+		// reload the configuration file on SIGHUP
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		go func() {
+			for range hup {
+				_ = clusters.reload()
+			}
+		}()
+
+		handlers := make(map[string]http.Handler)
+		if *webReloadToken != "" {
+			handlers["/-/reload"] = clusters.reloadHandler(*webReloadToken)
+		}
+
+		serve(logger, *listenAddress, *metricsPath, promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer, clusters), handlers, clusters.stop)
+		return
+	}
+
 	esURL, err := url.Parse(*esURI)
 	if err != nil {
 		_ = level.Error(logger).Log(
@@ -98,7 +119,14 @@ func main() {
 	}
 
 	// returns nil if not provided and falls back to simple TCP.
-	tlsConfig := createTLSConfig(*esCA, *esClientCert, *esClientPrivateKey, *esInsecureSkipVerify)
+	tlsConfig, err := createTLSConfig(*esCA, *esClientCert, *esClientPrivateKey, *esInsecureSkipVerify)
+	if err != nil {
+		_ = level.Error(logger).Log(
+			"msg", "failed to create TLS config",
+			"err", err,
+		)
+		os.Exit(1)
+	}
 
 	httpClient := &http.Client{
 		Timeout: *esTimeout,
@@ -108,9 +136,8 @@ func main() {
 		},
 	}
 
-	// version metric
-	versionMetric := version.NewCollector(Name)
-	prometheus.MustRegister(versionMetric)
+	// create a context that is cancelled on SIGKILL
+	ctx, cancel := context.WithCancel(context.Background())
 
 	// cluster info retriever
 	clusterInfoRetriever := clusterinfo.New(logger, httpClient, esURL, *esClusterInfoInterval)
@@ -118,38 +145,38 @@ func main() {
 	prometheus.MustRegister(collector.NewClusterHealth(logger, httpClient, esURL))
 	prometheus.MustRegister(collector.NewNodes(logger, httpClient, esURL, *esAllNodes, *esNode))
 
-	if *esExportIndices || *esExportShards {
-		iC := collector.NewIndices(logger, httpClient, esURL, *esExportShards)
-		prometheus.MustRegister(iC)
-		if registerErr := clusterInfoRetriever.RegisterConsumer(iC); registerErr != nil {
-			_ = level.Error(logger).Log("msg", "failed to register indices collector in cluster info")
-			os.Exit(1)
-		}
-	}
-
-	if *esExportRemoteInfo {
-		// Create Remote info Collector
-		prometheus.MustRegister(collector.NewRemoteInfo(logger, httpClient, esURL))
-	}
-
-	if *esExportSnapshots {
-		prometheus.MustRegister(collector.NewSnapshots(logger, httpClient, esURL))
-	}
-
-	if *esExportClusterSettings {
-		prometheus.MustRegister(collector.NewClusterSettings(logger, httpClient, esURL))
+	// collectors enabled by their --collector.<name> flag
+	collectors, err := collector.NewCollectors(logger, httpClient, esURL)
+	if err != nil {
+		_ = level.Error(logger).Log(
+			"msg", "failed to create collectors",
+			"err", err,
+		)
+		os.Exit(1)
 	}
-
-	if *esExportIndicesSettings {
-		prometheus.MustRegister(collector.NewIndicesSettings(logger, httpClient, esURL))
+	handlers := make(map[string]http.Handler)
+	var runners []runner
+	for _, c := range collectors {
+		prometheus.MustRegister(c)
+		if consumer, ok := c.(clusterInfoConsumer); ok {
+			if registerErr := clusterInfoRetriever.RegisterConsumer(consumer); registerErr != nil {
+				_ = level.Error(logger).Log("msg", "failed to register collector in cluster info", "consumer", consumer.String())
+				os.Exit(1)
+			}
+		}
+		if r, ok := c.(runner); ok {
+			runners = append(runners, r)
+		}
+		// snapshot repository cleanup endpoint, cleanup deletes data and is only served with a token
+		if repositoryCleanup, ok := c.(*collector.RepositoryCleanup); ok {
+			if *webReloadToken != "" {
+				handlers["/-/snapshot_repository_cleanup"] = bearerTokenHandler(*webReloadToken, repositoryCleanup)
+			} else {
+				_ = level.Warn(logger).Log("msg", "collector.repository_cleanup requires web.reload-token, the cleanup endpoint is disabled")
+			}
+		}
 	}
 
-	// create a http server
-	server := &http.Server{}
-
-	// create a context that is cancelled on SIGKILL
-	ctx, cancel := context.WithCancel(context.Background())
-
 	// start the cluster info retriever
 	switch runErr := clusterInfoRetriever.Run(ctx); runErr {
 	case nil:
@@ -160,21 +187,34 @@ func main() {
 	case clusterinfo.ErrInitialCallTimeout:
 		_ = level.Info(logger).Log("msg", "initial cluster info call timed out")
 	default:
-		_ = level.Error(logger).Log("msg", "failed to run cluster info retriever", "err", err)
+		_ = level.Error(logger).Log("msg", "failed to run cluster info retriever", "err", runErr)
 		os.Exit(1)
 	}
 
+	// start the loops of the periodic collectors
+	for _, r := range runners {
+		r.Run(ctx)
+	}
+
 	// register cluster info retriever as prometheus collector
 	prometheus.MustRegister(clusterInfoRetriever)
 
+	serve(logger, *listenAddress, *metricsPath, promhttp.Handler(), handlers, cancel)
+}
+
+// serve runs the http server with the metrics and the additional handlers until an interrupt is received
+func serve(logger log.Logger, listenAddress, metricsPath string, metricsHandler http.Handler, handlers map[string]http.Handler, cancel context.CancelFunc) {
+	// create a http server
+	server := &http.Server{}
+
 	mux := http.DefaultServeMux
-	mux.Handle(*metricsPath, promhttp.Handler())
+	mux.Handle(metricsPath, metricsHandler)
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		_, err = w.Write([]byte(`<html>
+		_, err := w.Write([]byte(`<html>
 			<head><title>Elasticsearch Exporter</title></head>
 			<body>
 			<h1>Elasticsearch Exporter</h1>
-			<p><a href="` + *metricsPath + `">Metrics</a></p>
+			<p><a href="` + metricsPath + `">Metrics</a></p>
 			</body>
 			</html>`))
 		if err != nil {
@@ -190,12 +230,16 @@ func main() {
 		http.Error(w, http.StatusText(http.StatusOK), http.StatusOK)
 	})
 
+	for pattern, handler := range handlers {
+		mux.Handle(pattern, handler)
+	}
+
 	server.Handler = mux
-	server.Addr = *listenAddress
+	server.Addr = listenAddress
 
 	_ = level.Info(logger).Log(
 		"msg", "starting elasticsearch_exporter",
-		"addr", *listenAddress,
+		"addr", listenAddress,
 	)
 
 	go func() {
@@ -219,3 +263,25 @@ func main() {
 	_ = server.Shutdown(srvCtx)
 	cancel()
 }
+
+// bearerTokenHandler passes requests authenticated with the bearer token to next
+func bearerTokenHandler(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clusterInfoConsumer is implemented by collectors which label their metrics with the cluster info
+type clusterInfoConsumer interface {
+	ClusterLabelUpdates() *chan *clusterinfo.Response
+	String() string
+}
+
+// runner is implemented by collectors which sample the cluster in a loop instead of on every scrape
+type runner interface {
+	Run(ctx context.Context)
+}
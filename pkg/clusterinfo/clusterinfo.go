@@ -151,7 +151,8 @@ func (r *Retriever) RegisterConsumer(c consumer) error {
 }
 
 // Run starts the update loop and periodically queries the / endpoint
-// The update loop is terminated upon ctx cancellation. The call blocks until the first
+// The update loop is terminated upon ctx cancellation, which closes the update channels of the
+// registered consumers. The call blocks until the first
 // call to the cluster info endpoint was successful
 func (r *Retriever) Run(ctx context.Context) error {
 	startupComplete := make(chan struct{})
@@ -164,6 +165,10 @@ func (r *Retriever) Run(ctx context.Context) error {
 					"msg", "context cancelled, exiting cluster info update loop",
 					"err", ctx.Err(),
 				)
+				// the update loop is the only sender, closing the channels stops the consumers
+				for _, consumerCh := range r.consumerChannels {
+					close(*consumerCh)
+				}
 				return
 			case <-r.sync:
 				_ = level.Info(r.logger).Log(
@@ -211,6 +216,7 @@ func (r *Retriever) Run(ctx context.Context) error {
 			return
 		}
 		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
@@ -223,7 +229,10 @@ func (r *Retriever) Run(ctx context.Context) error {
 				_ = level.Debug(r.logger).Log(
 					"msg", "triggering periodic update",
 				)
-				r.sync <- struct{}{}
+				select {
+				case r.sync <- struct{}{}:
+				case <-ctx.Done():
+				}
 			}
 		}
 	}(ctx)
@@ -70,7 +70,10 @@ func newMockConsumer(ctx context.Context, name string, t *testing.T) *mockConsum
 	go func() {
 		for {
 			select {
-			case d := <-mc.ch:
+			case d, ok := <-mc.ch:
+				if !ok {
+					return
+				}
 				mc.data = d
 				t.Logf("consumer %s received data from channel: %+v\n", mc, mc.data)
 			case <-ctx.Done():
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// generation holds the collectors of a single load of the configuration file
+type generation struct {
+	registry   *prometheus.Registry
+	cancel     context.CancelFunc
+	transports []*http.Transport
+	scrapes    sync.WaitGroup
+}
+
+// close stops the cluster info retrievers, which stops their consumers, and closes the idle connections
+// of the clusters once the scrapes in flight have completed
+func (g *generation) close() {
+	g.cancel()
+	g.scrapes.Wait()
+	for _, transport := range g.transports {
+		transport.CloseIdleConnections()
+	}
+}
+
+// clusters manages the collectors of the clusters defined in the configuration file. Every reload creates
+// all collectors in a new registry which replaces the current one once all clusters are registered,
+// scrapes in flight complete against the previous registry
+type clusters struct {
+	logger              log.Logger
+	file                string
+	timeout             time.Duration
+	clusterInfoInterval time.Duration
+
+	reloadMutex sync.Mutex
+
+	mutex   sync.RWMutex
+	current *generation
+
+	lastReloadSuccessful prometheus.Gauge
+	lastReloadSuccess    prometheus.Gauge
+}
+
+func newClusters(logger log.Logger, file string, timeout, clusterInfoInterval time.Duration) *clusters {
+	return &clusters{
+		logger:              logger,
+		file:                file,
+		timeout:             timeout,
+		clusterInfoInterval: clusterInfoInterval,
+		current: &generation{
+			registry: prometheus.NewRegistry(),
+			cancel:   func() {},
+		},
+
+		lastReloadSuccessful: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "elasticsearch_exporter_config_last_reload_successful",
+			Help: "Whether the last configuration reload attempt was successful.",
+		}),
+		lastReloadSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "elasticsearch_exporter_config_last_reload_success_timestamp_seconds",
+			Help: "Timestamp of the last successful configuration reload.",
+		}),
+	}
+}
+
+// Describe add configuration reload metrics descriptions
+func (c *clusters) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.lastReloadSuccessful.Desc()
+	ch <- c.lastReloadSuccess.Desc()
+}
+
+// Collect gets configuration reload metric values
+func (c *clusters) Collect(ch chan<- prometheus.Metric) {
+	ch <- c.lastReloadSuccessful
+	ch <- c.lastReloadSuccess
+}
+
+// load creates the collectors of all clusters of the configuration file and replaces the current ones
+func (c *clusters) load() error {
+	cfg, err := loadConfig(c.file, c.timeout)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	gen := &generation{
+		registry: prometheus.NewRegistry(),
+		cancel:   cancel,
+	}
+	for _, cc := range cfg.Clusters {
+		transport, err := registerCluster(ctx, c.logger, gen.registry, cc, c.clusterInfoInterval)
+		if err != nil {
+			gen.close()
+			return fmt.Errorf("failed to register cluster %s: %s", cc.Name, err)
+		}
+		gen.transports = append(gen.transports, transport)
+	}
+
+	c.mutex.Lock()
+	previous := c.current
+	c.current = gen
+	c.mutex.Unlock()
+
+	// release the previous configuration without delaying the reload by scrapes in flight
+	go previous.close()
+	return nil
+}
+
+// reload loads the configuration file, the current collectors are kept if the file is invalid
+func (c *clusters) reload() error {
+	c.reloadMutex.Lock()
+	defer c.reloadMutex.Unlock()
+
+	if err := c.load(); err != nil {
+		c.lastReloadSuccessful.Set(0)
+		_ = level.Error(c.logger).Log(
+			"msg", "failed to reload config.file",
+			"err", err,
+		)
+		return err
+	}
+	c.lastReloadSuccessful.Set(1)
+	c.lastReloadSuccess.SetToCurrentTime()
+	_ = level.Info(c.logger).Log(
+		"msg", "loaded config.file",
+		"file", c.file,
+	)
+	return nil
+}
+
+// stop stops the cluster info retrievers of the current configuration
+func (c *clusters) stop() {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	c.current.cancel()
+}
+
+// ServeHTTP serves the metrics of the default registry and of the current clusters
+func (c *clusters) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mutex.RLock()
+	gen := c.current
+	gen.scrapes.Add(1)
+	c.mutex.RUnlock()
+	defer gen.scrapes.Done()
+
+	promhttp.HandlerFor(
+		prometheus.Gatherers{prometheus.DefaultGatherer, gen.registry},
+		promhttp.HandlerOpts{},
+	).ServeHTTP(w, r)
+}
+
+// reloadHandler reloads the configuration file on POST requests authenticated with the bearer token
+func (c *clusters) reloadHandler(token string) http.Handler {
+	return bearerTokenHandler(token, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		if err := c.reload(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusOK), http.StatusOK)
+	}))
+}
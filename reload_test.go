@@ -0,0 +1,187 @@
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// lastReloadSuccessful returns the value of the reload status gauge of c
+func lastReloadSuccessful(t *testing.T, c *clusters) float64 {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %s", err)
+	}
+	for _, mf := range mfs {
+		if mf.GetName() == "elasticsearch_exporter_config_last_reload_successful" {
+			return mf.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Fatalf("Missing reload status metric")
+	return 0
+}
+
+func TestReload(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "elasticsearch_exporter")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := writeConfig(t, dir, `
+clusters:
+  - name: logging
+    uri: `+ts.URL+`
+`)
+	c := newClusters(log.NewNopLogger(), file, time.Second, time.Hour)
+	defer c.stop()
+
+	initial := c.current
+	if err := c.reload(); err != nil {
+		t.Fatalf("Failed to reload: %s", err)
+	}
+	if c.current == initial {
+		t.Errorf("Reload should replace the registry")
+	}
+	if v := lastReloadSuccessful(t, c); v != 1 {
+		t.Errorf("Wrong reload status %v after successful reload", v)
+	}
+
+	loaded := c.current
+	writeConfig(t, dir, `
+clusters:
+  - name: logging
+    uri: `+ts.URL+`
+  - name: search
+    uri: `+ts.URL+`
+    tls:
+      ca: /nonexistent/ca.pem
+`)
+	if err := c.reload(); err == nil {
+		t.Errorf("Reload with unreadable CA should fail")
+	}
+	if c.current != loaded {
+		t.Errorf("Failed reload should keep the registry")
+	}
+	if v := lastReloadSuccessful(t, c); v != 0 {
+		t.Errorf("Wrong reload status %v after failed reload", v)
+	}
+}
+
+func TestReloadClosesPreviousConnections(t *testing.T) {
+	var mutex sync.Mutex
+	conns := make(map[net.Conn]http.ConnState)
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		conns[conn] = state
+	}
+	ts.Start()
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "elasticsearch_exporter")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := newClusters(log.NewNopLogger(), writeConfig(t, dir, `
+clusters:
+  - name: logging
+    uri: `+ts.URL+`
+    collectors: [indices]
+`), time.Second, time.Hour)
+	defer c.stop()
+
+	if err := c.reload(); err != nil {
+		t.Fatalf("Failed to reload: %s", err)
+	}
+	// the scrape leaves idle connections of the first configuration
+	c.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	mutex.Lock()
+	var previous []net.Conn
+	for conn, state := range conns {
+		if state != http.StateClosed {
+			previous = append(previous, conn)
+		}
+	}
+	mutex.Unlock()
+	if len(previous) == 0 {
+		t.Fatalf("Scrape should leave idle connections")
+	}
+
+	if err := c.reload(); err != nil {
+		t.Fatalf("Failed to reload: %s", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for _, conn := range previous {
+		for {
+			mutex.Lock()
+			state := conns[conn]
+			mutex.Unlock()
+			if state == http.StateClosed {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Idle connections of the previous configuration should be closed")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func TestReloadHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elasticsearch_exporter")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := newClusters(log.NewNopLogger(), writeConfig(t, dir, `
+clusters:
+  - name: logging
+    uri: http://localhost:9200
+`), time.Second, time.Hour)
+	defer c.stop()
+	handler := c.reloadHandler("secret")
+
+	tcs := map[string]struct {
+		method, authorization string
+		code                  int
+	}{
+		"get":        {method: http.MethodGet, authorization: "Bearer secret", code: http.StatusMethodNotAllowed},
+		"no token":   {method: http.MethodPost, code: http.StatusUnauthorized},
+		"bad token":  {method: http.MethodPost, authorization: "Bearer wrong", code: http.StatusUnauthorized},
+		"bad scheme": {method: http.MethodPost, authorization: "secret", code: http.StatusUnauthorized},
+		"token":      {method: http.MethodPost, authorization: "Bearer secret", code: http.StatusOK},
+	}
+	for name, tc := range tcs {
+		req := httptest.NewRequest(tc.method, "/-/reload", nil)
+		if tc.authorization != "" {
+			req.Header.Set("Authorization", tc.authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tc.code {
+			t.Errorf("[%s] Wrong status code %d, expected %d", name, rec.Code, tc.code)
+		}
+	}
+}
@@ -3,11 +3,11 @@ package main
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"io/ioutil"
-	"log"
 )
 
-func createTLSConfig(pemFile, pemCertFile, pemPrivateKeyFile string, insecureSkipVerify bool) *tls.Config {
+func createTLSConfig(pemFile, pemCertFile, pemPrivateKeyFile string, insecureSkipVerify bool) (*tls.Config, error) {
 	tlsConfig := tls.Config{}
 	if insecureSkipVerify {
 		// pem settings are irrelevant if we're skipping verification anyway
@@ -16,20 +16,18 @@ func createTLSConfig(pemFile, pemCertFile, pemPrivateKeyFile string, insecureSki
 	if len(pemFile) > 0 {
 		rootCerts, err := loadCertificatesFrom(pemFile)
 		if err != nil {
-			log.Fatalf("Couldn't load root certificate from %s. Got %s.", pemFile, err)
-			return nil
+			return nil, fmt.Errorf("couldn't load root certificate from %s: %s", pemFile, err)
 		}
 		tlsConfig.RootCAs = rootCerts
 	}
 	if len(pemCertFile) > 0 && len(pemPrivateKeyFile) > 0 {
 		clientPrivateKey, err := loadPrivateKeyFrom(pemCertFile, pemPrivateKeyFile)
 		if err != nil {
-			log.Fatalf("Couldn't setup client authentication. Got %s.", err)
-			return nil
+			return nil, fmt.Errorf("couldn't setup client authentication: %s", err)
 		}
 		tlsConfig.Certificates = []tls.Certificate{*clientPrivateKey}
 	}
-	return &tlsConfig
+	return &tlsConfig, nil
 }
 
 func loadCertificatesFrom(pemFile string) (*x509.CertPool, error) {